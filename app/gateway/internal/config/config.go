@@ -15,6 +15,7 @@ type Config struct {
 	RateLimit   RateLimitConfig   // 新增: 限流配置
 	Breaker     BreakerConfig     // 新增: 熔断配置
 	HealthCheck HealthCheckConfig // 新增: 健康检查配置
+	ApiKeys     []ApiKeyConfig    `json:",optional"` // 可开启交易会话的API Key
 }
 
 type GatewayConfig struct {
@@ -46,3 +47,11 @@ type HealthCheckConfig struct {
 	Path     string `json:",default=/health"`
 	LivePath string `json:",default=/health/live"`
 }
+
+// ApiKeyConfig API Key配置，交易会话按API Key绑定账户
+type ApiKeyConfig struct {
+	Key     string
+	Account string
+	// 开启后会话断开或死人开关到期时撤销该账户全部挂单
+	CancelOnDisconnect bool `json:",optional"`
+}
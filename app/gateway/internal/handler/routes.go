@@ -26,11 +26,46 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 					Path:    "/api/v1/order/batch",
 					Handler: createBatchOrderHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/order/amend",
+					Handler: amendOrderHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/order/massCancel",
+					Handler: massCancelHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/session/open",
+					Handler: openSessionHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/session/heartbeat",
+					Handler: heartbeatHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/session/close",
+					Handler: closeSessionHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodPost,
+					Path:    "/api/v1/session/cancelAllAfter",
+					Handler: cancelAllAfterHandler(serverCtx),
+				},
 				{
 					Method:  http.MethodGet,
 					Path:    "/api/v1/orderbook/:symbol",
 					Handler: getOrderBookHandler(serverCtx),
 				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/api/v1/exchangeInfo",
+					Handler: getExchangeInfoHandler(serverCtx),
+				},
 				{
 					Method:  http.MethodGet,
 					Path:    "/metrics",
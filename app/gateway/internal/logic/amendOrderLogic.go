@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/types"
+	"github.com/tsfdsong/tradeengin/app/matching/matchservice"
+	"github.com/tsfdsong/tradeengin/app/pkg/fixed"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	ErrInvalidOrderID = errors.New("invalid orderId")
+	ErrEmptyAmend     = errors.New("invalid amend: price or quantity must be provided")
+)
+
+type AmendOrderLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewAmendOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AmendOrderLogic {
+	return &AmendOrderLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *AmendOrderLogic) validateAmend(req *types.AmendOrderReq) error {
+	if req.OrderID == 0 {
+		return ErrInvalidOrderID
+	}
+	if req.Symbol == "" {
+		return ErrInvalidSymbol
+	}
+	price, err := decimalSign(req.Price, ErrInvalidPrice)
+	if err != nil {
+		return err
+	}
+	if price < 0 {
+		return ErrInvalidPrice
+	}
+	quantity, err := decimalSign(req.Quantity, ErrInvalidQuantity)
+	if err != nil {
+		return err
+	}
+	if quantity < 0 {
+		return ErrInvalidQuantity
+	}
+	if price == 0 && quantity == 0 {
+		return ErrEmptyAmend
+	}
+	return nil
+}
+
+func (l *AmendOrderLogic) AmendOrder(req *types.AmendOrderReq) (*types.AmendOrderResp, error) {
+	// 参数校验
+	if err := l.validateAmend(req); err != nil {
+		return nil, err
+	}
+
+	// 调用 Matching 服务改单
+	resp, err := l.svcCtx.MatchRpc.AmendOrder(l.ctx, &matchservice.AmendOrderRequest{
+		OrderId:  req.OrderID,
+		Symbol:   req.Symbol,
+		Price:    req.Price,
+		Quantity: req.Quantity,
+		Version:  req.Version,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "AmendOrder: %+v", req)
+	}
+	if !resp.Success {
+		return nil, errors.Errorf("amend order rejected: %s", resp.Message)
+	}
+
+	// 成交数量按撮合服务返回的最大小数位数累加
+	var scale int32
+	for _, trade := range resp.Trades {
+		scale = max(scale, fixed.ScaleOf(trade.Quantity))
+	}
+	var filledQty int64
+	for _, trade := range resp.Trades {
+		qty, err := fixed.Parse(trade.Quantity, scale)
+		if err != nil {
+			return nil, errors.Wrapf(err, "AmendOrder: invalid trade quantity %q", trade.Quantity)
+		}
+		filledQty += qty
+	}
+
+	return &types.AmendOrderResp{
+		OrderID:    resp.OrderId,
+		Version:    resp.Version,
+		RestingQty: resp.RestingQuantity,
+		FilledQty:  fixed.Format(filledQty, scale),
+	}, nil
+}
@@ -0,0 +1,55 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/types"
+	"github.com/tsfdsong/tradeengin/app/order/orderservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CancelAllAfterLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCancelAllAfterLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelAllAfterLogic {
+	return &CancelAllAfterLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CancelAllAfterLogic) CancelAllAfter(req *types.CancelAllAfterReq) (*types.SessionResp, error) {
+	key, err := lookupApiKey(l.svcCtx, req.ApiKey)
+	if err != nil {
+		return nil, err
+	}
+	if req.SessionID == "" {
+		return nil, ErrInvalidSessionID
+	}
+	if req.Timeout < 0 {
+		return nil, ErrInvalidTimeout
+	}
+
+	// 只有开启断线撤单的API Key可以使用死人开关
+	if !key.CancelOnDisconnect {
+		return nil, errors.Errorf("cancel all after not enabled for account %s", key.Account)
+	}
+	resp, err := l.svcCtx.OrderRpc.CancelAllAfter(l.ctx, &orderservice.CancelAllAfterRequest{
+		SessionId: req.SessionID,
+		Account:   key.Account,
+		Timeout:   req.Timeout,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "CancelAllAfter: session %s, account %s", req.SessionID, key.Account)
+	}
+
+	return toSessionResp(resp), nil
+}
@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/types"
+	"github.com/tsfdsong/tradeengin/app/order/orderservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CloseSessionLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCloseSessionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CloseSessionLogic {
+	return &CloseSessionLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CloseSessionLogic) CloseSession(req *types.SessionReq) (*types.SessionResp, error) {
+	key, err := lookupApiKey(l.svcCtx, req.ApiKey)
+	if err != nil {
+		return nil, err
+	}
+	if req.SessionID == "" {
+		return nil, ErrInvalidSessionID
+	}
+
+	// 开启断线撤单的会话断开时订单服务撤销账户全部挂单
+	resp, err := l.svcCtx.OrderRpc.CloseSession(l.ctx, &orderservice.SessionRequest{
+		SessionId: req.SessionID,
+		Account:   key.Account,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "CloseSession: session %s, account %s", req.SessionID, key.Account)
+	}
+
+	return toSessionResp(resp), nil
+}
@@ -30,13 +30,22 @@ func (l *CreateBatchOrderLogic) CreateBatchOrder(req *types.BatchOrderReq) (*typ
 	var orders []*orderservice.Order
 	for _, orderReq := range req.Orders {
 		orders = append(orders, &orderservice.Order{
-			Symbol:    orderReq.Symbol,
-			Price:     orderReq.Price,
-			Quantity:  orderReq.Quantity,
-			Side:      int32(orderReq.Side),
-			Type:      int32(orderReq.Type),
-			ClientId:  orderReq.ClientID,
-			Timestamp: time.Now().UnixNano(),
+			Symbol:      orderReq.Symbol,
+			Price:       orderReq.Price,
+			Quantity:    orderReq.Quantity,
+			Side:        int32(orderReq.Side),
+			Type:        int32(orderReq.Type),
+			ClientId:    orderReq.ClientID,
+			Account:     orderReq.Account,
+			Timestamp:   time.Now().UnixNano(),
+			TimeInForce: int32(orderReq.TimeInForce),
+			ExpireTime:  orderReq.ExpireTime,
+			PostOnly:    int32(orderReq.PostOnly),
+			StopPrice:   orderReq.StopPrice,
+			DisplayQty:  orderReq.DisplayQty,
+			StpMode:     int32(orderReq.STPMode),
+			QuoteQty:    orderReq.QuoteQty,
+			Async:       orderReq.Async,
 		})
 	}
 
@@ -50,11 +59,7 @@ func (l *CreateBatchOrderLogic) CreateBatchOrder(req *types.BatchOrderReq) (*typ
 
 	var results []types.OrderResp
 	for _, result := range resp.Results {
-		results = append(results, types.OrderResp{
-			OrderID:   result.OrderId,
-			Status:    int8(result.Status),
-			Timestamp: result.Timestamp,
-		})
+		results = append(results, toOrderResp(result))
 	}
 
 	return &types.BatchOrderResp{
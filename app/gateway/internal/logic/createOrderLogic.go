@@ -10,6 +10,7 @@ import (
 	"github.com/tsfdsong/tradeengin/app/gateway/internal/types"
 	"github.com/tsfdsong/tradeengin/app/order/order"
 	"github.com/tsfdsong/tradeengin/app/order/orderservice"
+	"github.com/tsfdsong/tradeengin/app/pkg/fixed"
 	pkgtypes "github.com/tsfdsong/tradeengin/app/pkg/types"
 
 	"github.com/zeromicro/go-zero/core/logx"
@@ -20,8 +21,15 @@ var (
 	ErrInvalidQuantity = errors.New("invalid quantity: must be positive")
 	ErrInvalidPrice    = errors.New("invalid price: must be non-negative")
 	ErrInvalidSide     = errors.New("invalid side: must be 1(buy) or 2(sell)")
-	ErrInvalidType     = errors.New("invalid type: must be 1(limit) or 2(market)")
+	ErrInvalidType     = errors.New("invalid type: must be 1(limit), 2(market), 3(stop market) or 4(stop limit)")
 	ErrLimitPriceZero  = errors.New("limit order must have positive price")
+	ErrStopPriceZero   = errors.New("stop order must have positive stopPrice")
+	ErrInvalidTIF      = errors.New("invalid timeInForce: must be 1(GTC), 2(IOC), 3(FOK) or 4(GTD)")
+	ErrInvalidExpire   = errors.New("invalid expireTime: GTD order must expire in the future")
+	ErrInvalidPostOnly = errors.New("invalid postOnly: must be 1(reject) or 2(slide) on a GTC/GTD limit order")
+	ErrInvalidDisplay  = errors.New("invalid displayQty: iceberg order must be a limit order with 0 < displayQty <= quantity")
+	ErrInvalidQuoteQty = errors.New("invalid quoteQty: only market orders without quantity may be sized by quote amount")
+	ErrInvalidSTPMode  = errors.New("invalid stpMode: must be 1(cancel newest), 2(cancel oldest), 3(cancel both) or 4(decrement and cancel)")
 )
 
 type CreateOrderLogic struct {
@@ -43,12 +51,33 @@ func (l *CreateOrderLogic) validateOrder(req *types.OrderReq) error {
 	if req.Symbol == "" {
 		return ErrInvalidSymbol
 	}
-	// 校验数量
-	if req.Quantity <= 0 {
+	// 价格数量均为十进制字符串，具体精度由撮合服务按交易对校验
+	quantity, err := decimalSign(req.Quantity, ErrInvalidQuantity)
+	if err != nil {
+		return err
+	}
+	price, err := decimalSign(req.Price, ErrInvalidPrice)
+	if err != nil {
+		return err
+	}
+	stopPrice, err := decimalSign(req.StopPrice, ErrStopPriceZero)
+	if err != nil {
+		return err
+	}
+	// 校验数量，按金额下单的市价单只需指定金额
+	if req.QuoteQty != "" {
+		quoteQty, err := decimalSign(req.QuoteQty, ErrInvalidQuoteQty)
+		if err != nil {
+			return err
+		}
+		if quoteQty <= 0 || quantity != 0 || req.Type != pkgtypes.TypeMarketValue {
+			return ErrInvalidQuoteQty
+		}
+	} else if quantity <= 0 {
 		return ErrInvalidQuantity
 	}
 	// 校验价格
-	if req.Price < 0 {
+	if price < 0 {
 		return ErrInvalidPrice
 	}
 	// 校验方向 - 修复: Side应为1(买)或2(卖)
@@ -56,16 +85,67 @@ func (l *CreateOrderLogic) validateOrder(req *types.OrderReq) error {
 		return ErrInvalidSide
 	}
 	// 校验订单类型
-	if req.Type != pkgtypes.TypeLimitValue && req.Type != pkgtypes.TypeMarketValue {
+	switch req.Type {
+	case pkgtypes.TypeLimitValue, pkgtypes.TypeMarketValue, pkgtypes.TypeStopMarketValue, pkgtypes.TypeStopLimitValue:
+	default:
 		return ErrInvalidType
 	}
-	// 限价单必须有价格
-	if req.Type == pkgtypes.TypeLimitValue && req.Price <= 0 {
+	// 限价单/止损限价单必须有价格
+	if (req.Type == pkgtypes.TypeLimitValue || req.Type == pkgtypes.TypeStopLimitValue) && price <= 0 {
 		return ErrLimitPriceZero
 	}
+	// 止损单必须有触发价
+	if (req.Type == pkgtypes.TypeStopMarketValue || req.Type == pkgtypes.TypeStopLimitValue) && stopPrice <= 0 {
+		return ErrStopPriceZero
+	}
+	// 校验有效期，0表示默认GTC
+	switch req.TimeInForce {
+	case 0, pkgtypes.TimeInForceGTCValue, pkgtypes.TimeInForceIOCValue, pkgtypes.TimeInForceFOKValue:
+	case pkgtypes.TimeInForceGTDValue:
+		if req.ExpireTime <= time.Now().UnixNano() {
+			return ErrInvalidExpire
+		}
+	default:
+		return ErrInvalidTIF
+	}
+	// 只做Maker订单必须是会挂单的限价单
+	if req.PostOnly != 0 {
+		if req.PostOnly != pkgtypes.PostOnlyRejectValue && req.PostOnly != pkgtypes.PostOnlySlideValue {
+			return ErrInvalidPostOnly
+		}
+		if req.Type != pkgtypes.TypeLimitValue ||
+			req.TimeInForce == pkgtypes.TimeInForceIOCValue || req.TimeInForce == pkgtypes.TimeInForceFOKValue {
+			return ErrInvalidPostOnly
+		}
+	}
+	// 冰山单展示数量不能超过订单数量
+	if req.DisplayQty != "" {
+		if display, err := decimalSign(req.DisplayQty, ErrInvalidDisplay); err != nil || display <= 0 {
+			return ErrInvalidDisplay
+		}
+		if c, err := fixed.Compare(req.DisplayQty, req.Quantity); err != nil || c > 0 {
+			return ErrInvalidDisplay
+		}
+		if req.Type != pkgtypes.TypeLimitValue && req.Type != pkgtypes.TypeStopLimitValue {
+			return ErrInvalidDisplay
+		}
+	}
+	// 自成交预防模式，0表示使用交易对默认模式
+	if req.STPMode != 0 && !pkgtypes.IsValidSTPMode(req.STPMode) {
+		return ErrInvalidSTPMode
+	}
 	return nil
 }
 
+// decimalSign 解析十进制字符串的符号，格式非法时返回给定的参数错误
+func decimalSign(s string, errInvalid error) (int, error) {
+	sign, err := fixed.Sign(s)
+	if err != nil {
+		return 0, errInvalid
+	}
+	return sign, nil
+}
+
 func (l *CreateOrderLogic) CreateOrder(req *types.OrderReq) (*types.OrderResp, error) {
 	// 参数校验
 	if err := l.validateOrder(req); err != nil {
@@ -75,13 +155,22 @@ func (l *CreateOrderLogic) CreateOrder(req *types.OrderReq) (*types.OrderResp, e
 	// 调用 Order 服务创建订单
 	orderResp, err := l.svcCtx.OrderRpc.CreateOrder(l.ctx, &orderservice.OrderRequest{
 		Order: &order.Order{
-			Symbol:    req.Symbol,
-			Quantity:  req.Quantity,
-			Price:     req.Price,
-			Side:      int32(req.Side),
-			Type:      int32(req.Type),
-			ClientId:  req.ClientID,
-			Timestamp: time.Now().UnixNano(),
+			Symbol:      req.Symbol,
+			Quantity:    req.Quantity,
+			Price:       req.Price,
+			Side:        int32(req.Side),
+			Type:        int32(req.Type),
+			ClientId:    req.ClientID,
+			Account:     req.Account,
+			Timestamp:   time.Now().UnixNano(),
+			TimeInForce: int32(req.TimeInForce),
+			ExpireTime:  req.ExpireTime,
+			PostOnly:    int32(req.PostOnly),
+			StopPrice:   req.StopPrice,
+			DisplayQty:  req.DisplayQty,
+			StpMode:     int32(req.STPMode),
+			QuoteQty:    req.QuoteQty,
+			Async:       req.Async,
 		},
 	})
 	if err != nil {
@@ -89,9 +178,27 @@ func (l *CreateOrderLogic) CreateOrder(req *types.OrderReq) (*types.OrderResp, e
 	}
 
 	// 返回结果
-	return &types.OrderResp{
+	resp := toOrderResp(orderResp)
+	return &resp, nil
+}
+
+// toOrderResp 转换订单服务的下单结果
+func toOrderResp(orderResp *orderservice.OrderResponse) types.OrderResp {
+	fills := make([]types.Fill, 0, len(orderResp.Fills))
+	for _, fill := range orderResp.Fills {
+		fills = append(fills, types.Fill{
+			TradeID:   fill.TradeId,
+			Price:     fill.Price,
+			Quantity:  fill.Quantity,
+			Timestamp: fill.Timestamp,
+		})
+	}
+
+	return types.OrderResp{
 		OrderID:   orderResp.OrderId,
 		Status:    int8(orderResp.Status),
 		Timestamp: orderResp.Timestamp,
-	}, nil
+		FilledQty: orderResp.FilledQuantity,
+		Fills:     fills,
+	}
 }
@@ -0,0 +1,56 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/types"
+	"github.com/tsfdsong/tradeengin/app/matching/matchservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetExchangeInfoLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetExchangeInfoLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetExchangeInfoLogic {
+	return &GetExchangeInfoLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetExchangeInfoLogic) GetExchangeInfo(req *types.ExchangeInfoReq) (*types.ExchangeInfoResp, error) {
+	// 调用 Matching RPC 服务获取交易对规格
+	resp, err := l.svcCtx.MatchRpc.GetExchangeInfo(l.ctx, &matchservice.ExchangeInfoRequest{
+		Symbol: req.Symbol,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "GetExchangeInfo: %+v", req)
+	}
+
+	symbols := make([]types.InstrumentInfo, 0, len(resp.Instruments))
+	for _, inst := range resp.Instruments {
+		symbols = append(symbols, types.InstrumentInfo{
+			Symbol:        inst.Symbol,
+			Status:        inst.Status,
+			PriceScale:    inst.PriceScale,
+			QuantityScale: inst.QuantityScale,
+			TickSize:      inst.TickSize,
+			LotSize:       inst.LotSize,
+			MinQty:        inst.MinQty,
+			MaxQty:        inst.MaxQty,
+			MinNotional:   inst.MinNotional,
+		})
+	}
+
+	return &types.ExchangeInfoResp{
+		Symbols: symbols,
+		Time:    resp.Timestamp,
+	}, nil
+}
@@ -56,9 +56,10 @@ func (l *GetOrderBookLogic) GetOrderBook(req *types.OrderBookReq) (*types.OrderB
 	}
 
 	return &types.OrderBookResp{
-		Symbol: resp.Symbol,
-		Bids:   bids,
-		Asks:   asks,
-		Time:   resp.Timestamp,
+		Symbol:   resp.Symbol,
+		Bids:     bids,
+		Asks:     asks,
+		Time:     resp.Timestamp,
+		UpdateID: resp.UpdateId,
 	}, nil
 }
@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/types"
+	"github.com/tsfdsong/tradeengin/app/order/orderservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type HeartbeatLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewHeartbeatLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HeartbeatLogic {
+	return &HeartbeatLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *HeartbeatLogic) Heartbeat(req *types.SessionReq) (*types.SessionResp, error) {
+	key, err := lookupApiKey(l.svcCtx, req.ApiKey)
+	if err != nil {
+		return nil, err
+	}
+	if req.SessionID == "" {
+		return nil, ErrInvalidSessionID
+	}
+
+	// 心跳同时顺延死人开关
+	resp, err := l.svcCtx.OrderRpc.Heartbeat(l.ctx, &orderservice.SessionRequest{
+		SessionId: req.SessionID,
+		Account:   key.Account,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Heartbeat: session %s, account %s", req.SessionID, key.Account)
+	}
+
+	return toSessionResp(resp), nil
+}
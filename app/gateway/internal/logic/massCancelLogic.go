@@ -0,0 +1,64 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/types"
+	"github.com/tsfdsong/tradeengin/app/matching/matchservice"
+	pkgtypes "github.com/tsfdsong/tradeengin/app/pkg/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var ErrEmptyMassCancel = errors.New("invalid mass cancel: account or clientId is required when symbol is empty")
+
+type MassCancelLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewMassCancelLogic(ctx context.Context, svcCtx *svc.ServiceContext) *MassCancelLogic {
+	return &MassCancelLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *MassCancelLogic) validateMassCancel(req *types.MassCancelReq) error {
+	if req.Side != 0 && req.Side != pkgtypes.SideBuyValue && req.Side != pkgtypes.SideSellValue {
+		return ErrInvalidSide
+	}
+	// 不指定交易对时必须限定账户或ClientID，避免误撤全市场订单
+	if req.Symbol == "" && req.Account == "" && req.ClientID == "" {
+		return ErrEmptyMassCancel
+	}
+	return nil
+}
+
+func (l *MassCancelLogic) MassCancel(req *types.MassCancelReq) (*types.MassCancelResp, error) {
+	// 参数校验
+	if err := l.validateMassCancel(req); err != nil {
+		return nil, err
+	}
+
+	// 调用 Matching 服务批量撤单
+	resp, err := l.svcCtx.MatchRpc.MassCancel(l.ctx, &matchservice.MassCancelRequest{
+		Symbol:   req.Symbol,
+		Side:     int32(req.Side),
+		Account:  req.Account,
+		ClientId: req.ClientID,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "MassCancel: %+v", req)
+	}
+
+	return &types.MassCancelResp{
+		OrderIDs:      resp.OrderIds,
+		FailedSymbols: resp.FailedSymbols,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/config"
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/gateway/internal/types"
+	"github.com/tsfdsong/tradeengin/app/order/orderservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	ErrInvalidApiKey    = errors.New("invalid api key")
+	ErrInvalidSessionID = errors.New("invalid sessionId")
+	ErrInvalidTimeout   = errors.New("invalid timeout")
+)
+
+type OpenSessionLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewOpenSessionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *OpenSessionLogic {
+	return &OpenSessionLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *OpenSessionLogic) OpenSession(req *types.SessionReq) (*types.SessionResp, error) {
+	key, err := lookupApiKey(l.svcCtx, req.ApiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// 调用 Order 服务开启会话，是否断线撤单由API Key配置决定
+	resp, err := l.svcCtx.OrderRpc.OpenSession(l.ctx, &orderservice.SessionRequest{
+		Account:            key.Account,
+		CancelOnDisconnect: key.CancelOnDisconnect,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "OpenSession: account %s", key.Account)
+	}
+
+	return toSessionResp(resp), nil
+}
+
+// lookupApiKey 校验API Key，会话所属账户由API Key决定
+func lookupApiKey(svcCtx *svc.ServiceContext, apiKey string) (config.ApiKeyConfig, error) {
+	key, ok := svcCtx.ApiKeys[apiKey]
+	if apiKey == "" || !ok {
+		return config.ApiKeyConfig{}, ErrInvalidApiKey
+	}
+	return key, nil
+}
+
+func toSessionResp(resp *orderservice.SessionResponse) *types.SessionResp {
+	return &types.SessionResp{
+		SessionID:  resp.SessionId,
+		ExpireTime: resp.ExpireTime,
+		CancelTime: resp.CancelTime,
+	}
+}
@@ -22,6 +22,7 @@ type ServiceContext struct {
 	RedisClient  *redis.Redis        // 新增: Redis客户端
 	OrderLimiter *limit.TokenLimiter // 新增: 订单接口限流器
 	Breaker      breaker.Breaker     // 新增: 熔断器
+	ApiKeys      map[string]config.ApiKeyConfig
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -30,6 +31,10 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		Metrics:  middleware.NewMetricsMiddleware().Handle,
 		OrderRpc: orderservice.NewOrderService(zrpc.MustNewClient(c.OrderRpc)),
 		MatchRpc: matchservice.NewMatchService(zrpc.MustNewClient(c.MatchRpc)),
+		ApiKeys:  make(map[string]config.ApiKeyConfig, len(c.ApiKeys)),
+	}
+	for _, key := range c.ApiKeys {
+		svcCtx.ApiKeys[key.Key] = key
 	}
 
 	// 初始化Redis客户端
@@ -3,6 +3,21 @@
 
 package types
 
+type AmendOrderReq struct {
+	OrderID  uint64 `json:"orderId"`
+	Symbol   string `json:"symbol"`
+	Price    string `json:"price,optional"`    // 新价格，不填不修改
+	Quantity string `json:"quantity,optional"` // 新的剩余数量，不填不修改
+	Version  uint32 `json:"version"`           // 订单当前版本
+}
+
+type AmendOrderResp struct {
+	OrderID    uint64 `json:"orderId"`
+	Version    uint32 `json:"version"`
+	RestingQty string `json:"restingQty"`
+	FilledQty  string `json:"filledQty"` // 改价后立即成交的数量
+}
+
 type BatchOrderReq struct {
 	Orders []OrderReq `json:"orders"`
 }
@@ -11,35 +26,104 @@ type BatchOrderResp struct {
 	Results []OrderResp `json:"results"`
 }
 
+type CancelAllAfterReq struct {
+	ApiKey    string `header:"X-Api-Key"`
+	SessionID string `json:"sessionId"`
+	Timeout   int64  `json:"timeout"` // 毫秒，期间未收到心跳则撤销账户全部挂单，0表示关闭
+}
+
+type ExchangeInfoReq struct {
+	Symbol string `form:"symbol,optional"` // 不填返回全部交易对
+}
+
+type ExchangeInfoResp struct {
+	Symbols []InstrumentInfo `json:"symbols"`
+	Time    int64            `json:"time"`
+}
+
+type Fill struct {
+	TradeID   uint64 `json:"tradeId"`
+	Price     string `json:"price"`
+	Quantity  string `json:"quantity"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type InstrumentInfo struct {
+	Symbol        string `json:"symbol"`
+	Status        string `json:"status"` // TRADING / HALTED
+	PriceScale    int32  `json:"priceScale"`
+	QuantityScale int32  `json:"quantityScale"`
+	TickSize      string `json:"tickSize"`
+	LotSize       string `json:"lotSize"`
+	MinQty        string `json:"minQty"`
+	MaxQty        string `json:"maxQty"` // "0"表示不限制
+	MinNotional   string `json:"minNotional"`
+}
+
+type MassCancelReq struct {
+	Symbol   string `json:"symbol,optional"`   // 不填在全部交易对上撤单，此时account与clientId至少填一个
+	Side     int8   `json:"side,optional"`     // 1:买 2:卖，不填撤销双边
+	Account  string `json:"account,optional"`  // 按账户撤单
+	ClientID string `json:"clientId,optional"` // 按ClientID撤单
+}
+
+type MassCancelResp struct {
+	OrderIDs      []uint64 `json:"orderIds"`      // 被撤销的订单
+	FailedSymbols []string `json:"failedSymbols"` // 未指定交易对时无法撤单的交易对
+}
+
 type OrderBookReq struct {
 	Symbol string `path:"symbol"`
 	Depth  int    `form:"depth,optional,default=20"`
 }
 
 type OrderBookResp struct {
-	Symbol string       `json:"symbol"`
-	Bids   []PriceLevel `json:"bids"`
-	Asks   []PriceLevel `json:"asks"`
-	Time   int64        `json:"time"`
+	Symbol   string       `json:"symbol"`
+	Bids     []PriceLevel `json:"bids"`
+	Asks     []PriceLevel `json:"asks"`
+	Time     int64        `json:"time"`
+	UpdateID uint64       `json:"updateId"`
 }
 
 type OrderReq struct {
-	Symbol   string  `json:"symbol"`
-	Price    float64 `json:"price"`
-	Quantity int64   `json:"quantity"`
-	Side     int8    `json:"side"`
-	Type     int8    `json:"type"`
-	ClientID string  `json:"clientId"`
+	Symbol      string `json:"symbol"`
+	Price       string `json:"price"`
+	Quantity    string `json:"quantity,optional"`
+	Side        int8   `json:"side"`
+	Type        int8   `json:"type"`
+	ClientID    string `json:"clientId"`
+	Account     string `json:"account,optional"`     // 下单账户，可按账户批量撤单
+	TimeInForce int8   `json:"timeInForce,optional"` // 1:GTC 2:IOC 3:FOK 4:GTD, 默认GTC
+	ExpireTime  int64  `json:"expireTime,optional"`  // GTD订单过期时间(纳秒)
+	PostOnly    int8   `json:"postOnly,optional"`    // 只做Maker: 1:会吃单时拒绝 2:会吃单时调整价格
+	StopPrice   string `json:"stopPrice,optional"`   // 止损触发价，止损单(type=3/4)必填
+	DisplayQty  string `json:"displayQty,optional"`  // 冰山单每次展示的数量，不填为普通订单
+	STPMode     int8   `json:"stpMode,optional"`     // 自成交预防: 1撤新 2撤旧 3双撤 4减量撤销, 默认按交易对配置
+	QuoteQty    string `json:"quoteQty,optional"`    // 按计价货币金额下单的市价单金额，此时不填quantity，不支持FOK
+	Async       bool   `json:"async,optional"`       // 异步下单: 不等待撮合结果，只返回订单号
 }
 
 type OrderResp struct {
 	OrderID   uint64 `json:"orderId"`
 	Status    int8   `json:"status"`
 	Timestamp int64  `json:"timestamp"`
+	FilledQty string `json:"filledQty"` // 撮合完成时的累计成交数量，异步下单为空
+	Fills     []Fill `json:"fills"`     // 本次下单的成交明细
 }
 
 type PriceLevel struct {
-	Price    float64 `json:"price"`
-	Quantity int64   `json:"quantity"`
-	Count    int     `json:"count"`
+	Price    string `json:"price"`
+	Quantity string `json:"quantity"`
+	Count    int    `json:"count"`
+}
+
+type SessionReq struct {
+	ApiKey    string `header:"X-Api-Key"`
+	SessionID string `json:"sessionId,optional"` // 开启会话时不填
+}
+
+type SessionResp struct {
+	SessionID  string `json:"sessionId"`
+	ExpireTime int64  `json:"expireTime"` // 未收到心跳时会话断开的时间(毫秒)
+	CancelTime int64  `json:"cancelTime"` // 死人开关触发撤单的时间(毫秒)，0表示未启用
 }
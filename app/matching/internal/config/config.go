@@ -1,6 +1,10 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/zrpc"
 )
@@ -12,11 +16,154 @@ type Config struct {
 }
 
 type MatchingConfig struct {
-	Symbols          []string `json:",default=[\"BTCUSD\", \"ETHUSD\"]"`
-	OrderBookShards  int      `json:",default=16"`
-	BatchSize        int      `json:",default=256"`
-	WorkerCount      int      `json:",default=16"`
-	SnapshotInterval string   `json:",default=30s"`
-	PersistEnabled   bool     `json:",default=true"` // 新增: 是否启用持久化
-	PersistInterval  string   `json:",default=5s"`   // 新增: 持久化间隔
+	Symbols             []string                     `json:",default=[\"BTCUSD\",\"ETHUSD\"]"`
+	OrderBookShards     int                          `json:",default=16"`
+	BatchSize           int                          `json:",default=256"`
+	WorkerCount         int                          `json:",default=16"`
+	WorkerAssignments   map[string]int               `json:",optional"` // 交易对 -> worker编号，未配置的按一致性哈希分配
+	SnapshotInterval    string                       `json:",default=30s"`
+	PersistEnabled      bool                         `json:",default=true"`  // 新增: 是否启用持久化
+	PersistInterval     string                       `json:",default=5s"`    // 新增: 持久化间隔
+	ExpiryCheckInterval string                       `json:",default=100ms"` // GTD订单到期时间轮每格时长，即过期检查间隔
+	ProcessTimeout      string                       `json:",default=1s"`    // 同步下单等待撮合结果的超时时间，需小于RPC超时(默认2s)
+	DefaultSTPMode      int8                         `json:",default=1"`     // 默认自成交预防模式: 1撤新 2撤旧 3双撤 4减量撤销
+	STPModes            map[string]int8              `json:",optional"`      // 交易对 -> 自成交预防模式，覆盖默认模式
+	MaxSlippageBps      int64                        `json:",default=0"`     // 市价单最大滑点(基点)，0表示不限制
+	DefaultMatchPolicy  MatchPolicyConfig            `json:",optional"`      // 默认撮合策略，不填为价格时间优先
+	MatchPolicies       map[string]MatchPolicyConfig `json:",optional"`      // 交易对 -> 撮合策略，覆盖默认策略
+	PriceScale          int32                        `json:",default=2"`     // 未配置规格的交易对默认价格小数位数
+	QuantityScale       int32                        `json:",default=0"`     // 未配置规格的交易对默认数量小数位数
+	Instruments         map[string]InstrumentConfig  `json:",optional"`      // 交易对 -> 交易规格
+	Journal             JournalConfig                `json:",optional"`      // 命令日志，重启时重放恢复订单簿
+	SnapshotStore       SnapshotStoreConfig          `json:",optional"`      // 订单簿快照存储，重启时先恢复快照再重放之后的日志
+	Replication         ReplicationConfig            `json:",optional"`      // 主备复制
+}
+
+// ReplicationConfig 主备复制配置，primary将命令日志实时复制给follower
+type ReplicationConfig struct {
+	Role       string             `json:",default=primary,options=primary|follower"`
+	FollowerID string             `json:",optional"`      // follower标识，默认使用ListenOn
+	Primary    zrpc.RpcClientConf `json:",optional"`      // follower连接的primary
+	RequireAck bool               `json:",default=false"` // primary: 至少一个follower确认写入后才返回下单/撤单/改单结果
+	AckTimeout string             `json:",default=500ms"` // 等待follower确认的超时时间
+	BufferSize int                `json:",default=65536"` // primary为每个follower缓存的命令数，积压超过时断开该follower由其重连追赶
+}
+
+// SnapshotStoreConfig 订单簿快照存储配置，需同时启用命令日志才会在启动时从快照恢复
+type SnapshotStoreConfig struct {
+	Type   string `json:",default=none,options=file|redis|none"` // 存储后端: file本地目录, redis使用RedisConf
+	Dir    string `json:",default=data/snapshots"`               // file后端的快照目录
+	Retain int    `json:",default=5"`                            // 每个交易对保留的快照数，最新的快照始终保留
+	MaxAge string `json:",default=24h"`                          // 快照最长保留时间
+}
+
+// JournalConfig 命令日志配置
+type JournalConfig struct {
+	Enabled      bool   `json:",default=false"`
+	Dir          string `json:",default=data/journal"`                    // 日志目录
+	SegmentSize  int64  `json:",default=67108864"`                        // 单个日志段大小上限(字节)
+	SyncMode     string `json:",default=batch,options=always|batch|none"` // 刷盘策略: always每条命令fsync, batch按间隔fsync, none由操作系统刷盘
+	SyncInterval string `json:",default=10ms"`                            // batch模式的刷盘间隔
+}
+
+// STPModeOf 获取交易对的自成交预防模式
+func (c MatchingConfig) STPModeOf(symbol string) int8 {
+	if mode, ok := c.STPModes[symbol]; ok {
+		return mode
+	}
+	return c.DefaultSTPMode
+}
+
+// MatchPolicyConfig 价格层级内的成交分配策略
+type MatchPolicyConfig struct {
+	Algorithm     string `json:",default=fifo,options=fifo|prorata|prorata_top"` // fifo价格时间优先, prorata按比例, prorata_top队首优先后按比例
+	MinAllocation string `json:",optional"`                                      // 按比例分配时单个挂单的最小成交数量，不足的部分按时间优先分配
+}
+
+// MatchPolicyOf 获取交易对的撮合策略
+func (c MatchingConfig) MatchPolicyOf(symbol string) MatchPolicyConfig {
+	if policy, ok := c.MatchPolicies[symbol]; ok {
+		return policy
+	}
+	return c.DefaultMatchPolicy
+}
+
+// InstrumentConfig 交易对规格，数量与价格均为十进制字符串，不填时取精度的最小单位
+type InstrumentConfig struct {
+	PriceScale    int32  `json:",default=2"`                              // 价格小数位数
+	QuantityScale int32  `json:",default=0"`                              // 数量小数位数
+	TickSize      string `json:",optional"`                               // 最小价格变动
+	LotSize       string `json:",optional"`                               // 最小数量变动
+	MinQty        string `json:",optional"`                               // 单笔最小数量
+	MaxQty        string `json:",optional"`                               // 单笔最大数量，不填不限制
+	MinNotional   string `json:",optional"`                               // 单笔最小金额(价格*数量)
+	Status        string `json:",default=TRADING,options=TRADING|HALTED"` // 交易状态
+}
+
+// PrecisionOf 获取交易对的价格及数量精度
+func (c MatchingConfig) PrecisionOf(symbol string) types.Precision {
+	if inst, ok := c.Instruments[symbol]; ok {
+		return types.Precision{PriceScale: inst.PriceScale, QuantityScale: inst.QuantityScale}
+	}
+	return types.Precision{PriceScale: c.PriceScale, QuantityScale: c.QuantityScale}
+}
+
+// InstrumentOf 解析交易对规格，未配置的交易对只按默认精度校验
+func (c MatchingConfig) InstrumentOf(symbol string) (types.InstrumentSpec, error) {
+	inst, ok := c.Instruments[symbol]
+	if !ok {
+		inst = InstrumentConfig{
+			PriceScale:    c.PriceScale,
+			QuantityScale: c.QuantityScale,
+			Status:        types.InstrumentTrading,
+		}
+	}
+	return inst.Spec(symbol)
+}
+
+// Spec 将十进制字符串表示的规格换算为按精度的整数最小单位
+func (inst InstrumentConfig) Spec(symbol string) (types.InstrumentSpec, error) {
+	precision := types.Precision{PriceScale: inst.PriceScale, QuantityScale: inst.QuantityScale}
+	spec := types.InstrumentSpec{
+		Symbol:    symbol,
+		Precision: precision,
+		Status:    inst.Status,
+	}
+
+	var err error
+	if spec.TickSize, err = parseStep(inst.TickSize, precision.ParsePrice); err != nil {
+		return spec, fmt.Errorf("instrument %s: invalid TickSize %q: %w", symbol, inst.TickSize, err)
+	}
+	if spec.LotSize, err = parseStep(inst.LotSize, precision.ParseQty); err != nil {
+		return spec, fmt.Errorf("instrument %s: invalid LotSize %q: %w", symbol, inst.LotSize, err)
+	}
+	if spec.MinQty, err = precision.ParseQty(inst.MinQty); err != nil {
+		return spec, fmt.Errorf("instrument %s: invalid MinQty %q: %w", symbol, inst.MinQty, err)
+	}
+	if spec.MaxQty, err = precision.ParseQty(inst.MaxQty); err != nil {
+		return spec, fmt.Errorf("instrument %s: invalid MaxQty %q: %w", symbol, inst.MaxQty, err)
+	}
+	if spec.MinNotional, err = precision.ParseQuote(inst.MinNotional); err != nil {
+		return spec, fmt.Errorf("instrument %s: invalid MinNotional %q: %w", symbol, inst.MinNotional, err)
+	}
+	if spec.MaxQty > 0 && spec.MaxQty < spec.MinQty {
+		return spec, fmt.Errorf("instrument %s: MaxQty is less than MinQty", symbol)
+	}
+
+	return spec, nil
+}
+
+// parseStep 解析最小变动单位，不填时为1个最小单位
+func parseStep(s string, parse func(string) (int64, error)) (int64, error) {
+	step, err := parse(s)
+	if err != nil {
+		return 0, err
+	}
+	if step < 0 {
+		return 0, errors.New("step must be positive")
+	}
+	if step == 0 {
+		return 1, nil
+	}
+	return step, nil
 }
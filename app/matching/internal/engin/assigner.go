@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"github.com/zeromicro/go-zero/core/hash"
+)
+
+// SymbolAssigner 交易对 -> worker 分配器
+// 每个交易对只归属一个worker，保证同一交易对的订单严格按到达顺序单线程撮合
+// 优先使用配置中的显式分配，其余按一致性哈希分配，新增交易对不会改变已有交易对的归属
+type SymbolAssigner struct {
+	workerCount int
+	ring        *hash.ConsistentHash
+	overrides   map[string]int
+}
+
+// NewSymbolAssigner 创建分配器
+func NewSymbolAssigner(workerCount int, overrides map[string]int) *SymbolAssigner {
+	ring := hash.NewConsistentHash()
+	for i := 0; i < workerCount; i++ {
+		ring.Add(i)
+	}
+
+	return &SymbolAssigner{
+		workerCount: workerCount,
+		ring:        ring,
+		overrides:   overrides,
+	}
+}
+
+// Assign 获取交易对归属的worker编号
+func (a *SymbolAssigner) Assign(symbol string) int {
+	if id, ok := a.overrides[symbol]; ok && id >= 0 && id < a.workerCount {
+		return id
+	}
+
+	node, ok := a.ring.Get(symbol)
+	if !ok {
+		return 0
+	}
+	return node.(int)
+}
+
+// AssignAll 计算一组交易对的分配结果: worker编号 -> 交易对列表
+func (a *SymbolAssigner) AssignAll(symbols []string) map[int][]string {
+	assignments := make(map[int][]string, a.workerCount)
+	for _, symbol := range symbols {
+		id := a.Assign(symbol)
+		assignments[id] = append(assignments[id], symbol)
+	}
+	return assignments
+}
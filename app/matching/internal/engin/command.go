@@ -0,0 +1,317 @@
+package engine
+
+import (
+	"errors"
+	"time"
+	"unsafe"
+
+	"github.com/tsfdsong/tradeengin/app/matching/internal/journal"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/orderbook"
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// command 交易对输入队列中的命令，与新订单一样由交易对所属worker按序执行
+type command struct {
+	*journal.Command
+	apply func(orderBook *orderbook.HybridOrderBook) *commandReply // 撤单/改单/过期清理的执行逻辑，新订单为nil
+	done  chan *commandReply                                       // 等待执行结果，不需要等待时为nil
+
+	readOnly bool // 只读命令(如拍摄快照)不写日志也不分配新序号，Seq为入队时最后一条命令的序号
+}
+
+// commandReply 撤单/改单等命令的执行结果
+type commandReply struct {
+	amend      *AmendResult
+	snapshot   *orderbook.BookSnapshot
+	instrument *types.InstrumentSpec // 拍摄快照时的交易对规格
+	cancelled  int                   // 下架撤销的挂单数
+	orderIDs   []uint64              // 批量撤销的订单
+	retired    bool                  // 交易对已下架，worker不再轮询其队列
+	err        error
+}
+
+// bindCommand 为日志命令绑定执行逻辑，实时执行与启动重放共用
+func (e *MatchingEngine) bindCommand(jc *journal.Command, done chan *commandReply) *command {
+	cmd := &command{Command: jc, done: done}
+
+	switch jc.Type {
+	case journal.CommandCancel:
+		cmd.apply = func(orderBook *orderbook.HybridOrderBook) *commandReply {
+			return e.applyCancel(orderBook, jc)
+		}
+	case journal.CommandAmend:
+		cmd.apply = func(orderBook *orderbook.HybridOrderBook) *commandReply {
+			return e.applyAmend(orderBook, jc)
+		}
+	case journal.CommandMassCancel:
+		cmd.apply = func(orderBook *orderbook.HybridOrderBook) *commandReply {
+			return e.applyMassCancel(orderBook, jc)
+		}
+	case journal.CommandExpire:
+		cmd.apply = func(orderBook *orderbook.HybridOrderBook) *commandReply {
+			e.applyExpire(orderBook, jc)
+			return &commandReply{}
+		}
+	case journal.CommandAddSymbol:
+		// 交易对在写入日志时已创建，worker只需按序回复
+		cmd.apply = func(orderBook *orderbook.HybridOrderBook) *commandReply {
+			return &commandReply{}
+		}
+	case journal.CommandHalt, journal.CommandResume, journal.CommandSetPhase:
+		cmd.apply = func(orderBook *orderbook.HybridOrderBook) *commandReply {
+			return e.applyPhase(orderBook, jc)
+		}
+	case journal.CommandDelist:
+		cmd.apply = func(orderBook *orderbook.HybridOrderBook) *commandReply {
+			return e.applyDelist(orderBook, jc)
+		}
+	}
+
+	return cmd
+}
+
+// enqueueCommand 分配序号、写入日志后放入交易对输入队列
+// 全程持有commandMu，保证日志中的命令顺序与各交易对的执行顺序一致
+// 切换交易阶段/下架命令写入日志后立即修改交易对状态，之后入队的命令按新状态校验
+func (e *MatchingEngine) enqueueCommand(cmd *command) error {
+	e.commandMu.Lock()
+	defer e.commandMu.Unlock()
+
+	// 下架中的交易对不再接收任何命令，worker执行完下架命令后即移除该交易对
+	m, exists := e.market(cmd.Symbol)
+	if !exists || m.spec.Status == types.InstrumentDelisting {
+		return ErrSymbolNotFound
+	}
+
+	// 只有持有commandMu才能入队，检查通过后入队必然成功，不会出现已写日志却未执行的命令
+	if m.queue.IsFull() {
+		return ErrQueueFull
+	}
+
+	if !cmd.readOnly && e.follower.Load() {
+		return ErrNotPrimary
+	}
+	// 下单前已校验过交易阶段，这里再次确认，避免校验后交易阶段被切换
+	if err := checkPhase(m.spec, cmd); err != nil {
+		return err
+	}
+
+	cmd.Timestamp = time.Now().UnixNano()
+	if cmd.readOnly {
+		cmd.Seq = e.lastSeq
+		m.queue.Push(unsafe.Pointer(cmd))
+		return nil
+	}
+
+	if err := e.appendCommand(cmd.Command); err != nil {
+		return err
+	}
+	if cmd.Type.IsSymbolCommand() {
+		if err := e.applySymbolCommand(cmd.Command); err != nil {
+			logx.Errorf("Apply symbol command %d failed: %v", cmd.Seq, err)
+		}
+	}
+
+	m.queue.Push(unsafe.Pointer(cmd))
+	return nil
+}
+
+// appendCommand 分配序号并写入日志，成功后复制给follower(调用方需持有commandMu)
+func (e *MatchingEngine) appendCommand(jc *journal.Command) error {
+	jc.Seq = e.lastSeq + 1
+	if e.journal != nil {
+		if err := e.journal.Append(jc); err != nil {
+			logx.Errorf("Append command %d to journal failed: %v", jc.Seq, err)
+			return ErrJournalWrite
+		}
+	}
+	e.lastSeq = jc.Seq
+	e.replicas.publish(jc)
+	return nil
+}
+
+// submitCommand 提交撤单/改单命令并等待交易对所属worker执行完成，开启RequireAck时还需等待follower确认
+func (e *MatchingEngine) submitCommand(jc *journal.Command) (*commandReply, error) {
+	reply, err := e.waitCommand(e.bindCommand(jc, make(chan *commandReply, 1)))
+	if err != nil {
+		return reply, err
+	}
+	if err := e.waitReplicated(jc.Seq); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// waitCommand 提交命令并等待执行结果，cmd.done不能为nil
+func (e *MatchingEngine) waitCommand(cmd *command) (*commandReply, error) {
+	if err := e.enqueueCommand(cmd); err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(e.processTimeout)
+	defer timer.Stop()
+
+	select {
+	case reply := <-cmd.done:
+		return reply, reply.err
+	case <-timer.C:
+		return nil, ErrProcessTimeout
+	}
+}
+
+// captureSnapshot 由交易对所属worker在两条命令之间拍摄订单簿快照，编码在worker之外进行
+// 快照序号即入队时最后一条命令的序号，此前的命令都已在该交易对上执行完毕
+func (e *MatchingEngine) captureSnapshot(symbol string) (*Snapshot, error) {
+	cmd := &command{
+		Command:  &journal.Command{Symbol: symbol},
+		done:     make(chan *commandReply, 1),
+		readOnly: true,
+	}
+	cmd.apply = func(orderBook *orderbook.HybridOrderBook) *commandReply {
+		m, _ := e.market(symbol)
+		snapshot := orderBook.Snapshot()
+		snapshot.Seq = cmd.Seq
+		snapshot.Timestamp = cmd.Timestamp
+		return &commandReply{snapshot: snapshot, instrument: m.spec}
+	}
+
+	reply, err := e.waitCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return newSnapshot(reply.snapshot, reply.instrument)
+}
+
+// applyCancel 从订单簿撤单并更新订单状态
+func (e *MatchingEngine) applyCancel(orderBook *orderbook.HybridOrderBook, jc *journal.Command) *commandReply {
+	if !orderBook.CancelOrder(jc.OrderID) {
+		return &commandReply{err: ErrOrderNotFound}
+	}
+
+	e.stateMu.Lock()
+	e.markOrderCancelled(jc.OrderID)
+	e.stateMu.Unlock()
+
+	logx.Infof("Order %d cancelled successfully", jc.OrderID)
+	return &commandReply{}
+}
+
+// applyAmend 在订单簿中改单并更新订单状态
+func (e *MatchingEngine) applyAmend(orderBook *orderbook.HybridOrderBook, jc *journal.Command) *commandReply {
+	result, err := orderBook.AmendOrderAt(jc.OrderID, jc.Price, jc.Quantity, jc.Version, jc.Timestamp)
+	if errors.Is(err, orderbook.ErrOrderNotFound) {
+		return &commandReply{err: ErrOrderNotFound}
+	}
+	if err != nil {
+		return &commandReply{err: err}
+	}
+
+	amend := &AmendResult{
+		OrderID:    jc.OrderID,
+		Version:    result.Order.Version,
+		RestingQty: result.RestingQty,
+	}
+	for _, trade := range result.Trades {
+		amend.Trades = append(amend.Trades, *trade)
+	}
+
+	// 改单后订单总数量 = 已成交数量 + 新的剩余数量
+	e.stateMu.Lock()
+	if state, ok := e.orderStates.Load(jc.OrderID); ok {
+		os := state.(*OrderState)
+		os.Version = amend.Version
+		os.OriginalQty = os.FilledQuantity + result.TotalFilledQty() + result.RestingQty + result.CancelledQty
+	}
+	e.stateMu.Unlock()
+
+	e.handleMatchResult(result)
+
+	logx.Infof("Order %d amended, version: %d", jc.OrderID, amend.Version)
+	return &commandReply{amend: amend}
+}
+
+// applyExpire 撤销订单簿中已过期的GTD订单，并登记剩余订单中最早的到期时间
+func (e *MatchingEngine) applyExpire(orderBook *orderbook.HybridOrderBook, jc *journal.Command) {
+	expired := orderBook.ExpireOrders(jc.Timestamp)
+	e.scheduleExpiry(jc.Symbol, orderBook)
+
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	for _, orderID := range expired {
+		e.markOrderCancelled(orderID)
+		logx.Infof("GTD order %d expired, symbol: %s", orderID, jc.Symbol)
+	}
+}
+
+// replay 启动时按序重放命令日志，重建订单簿及订单状态
+func (e *MatchingEngine) replay() error {
+	if e.journal == nil {
+		return nil
+	}
+
+	startTime := time.Now()
+	count := 0
+	err := e.journal.Replay(e.replayFrom(), func(jc *journal.Command) error {
+		if jc.Seq > e.lastSeq {
+			e.lastSeq = jc.Seq
+		}
+
+		// 已包含在该交易对快照中的命令
+		if jc.Seq <= e.snapshotSeqs[jc.Symbol] {
+			return nil
+		}
+
+		// 上架直接创建交易对，切换交易阶段及下架还需在订单簿上执行
+		if jc.Type.IsSymbolCommand() {
+			if err := e.applySymbolCommand(jc); err != nil {
+				logx.Errorf("Skip journal command %d of symbol %s: %v", jc.Seq, jc.Symbol, err)
+				return nil
+			}
+			if jc.Type == journal.CommandAddSymbol {
+				count++
+				return nil
+			}
+		}
+
+		m, exists := e.market(jc.Symbol)
+		if !exists {
+			logx.Errorf("Skip journal command %d of unknown symbol %s", jc.Seq, jc.Symbol)
+			return nil
+		}
+
+		if jc.Type == journal.CommandNewOrder {
+			e.trackOrder(jc.Order)
+			e.handleMatchResult(m.orderBook.MatchAt(jc.Order, jc.Timestamp))
+		} else if cmd := e.bindCommand(jc, nil); cmd.apply != nil {
+			cmd.apply(m.orderBook)
+		}
+		// 重放产生的行情不再推送，订阅方从恢复后的快照开始
+		m.orderBook.DiscardFeeds()
+
+		count++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logx.Infof("Replayed %d journal commands in %v, last seq: %d", count, time.Since(startTime), e.lastSeq)
+	return nil
+}
+
+// replayFrom 重放起点: 各交易对快照序号的最小值，存在未从快照恢复的交易对时从头重放
+func (e *MatchingEngine) replayFrom() uint64 {
+	var from uint64
+	for _, symbol := range e.GetSymbols() {
+		seq, ok := e.snapshotSeqs[symbol]
+		if !ok {
+			return 0
+		}
+		if from == 0 || seq < from {
+			from = seq
+		}
+	}
+	return from
+}
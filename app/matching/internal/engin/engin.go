@@ -3,11 +3,15 @@ package engine
 import (
 	"context"
 	"errors"
+	"maps"
+	"slices"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
-	"unsafe"
 
 	"github.com/tsfdsong/tradeengin/app/matching/internal/config"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/journal"
 	"github.com/tsfdsong/tradeengin/app/matching/internal/monitor"
 	"github.com/tsfdsong/tradeengin/app/matching/internal/orderbook"
 	"github.com/tsfdsong/tradeengin/app/pkg/lockfree"
@@ -22,8 +26,37 @@ var (
 	ErrQueueFull            = errors.New("input queue is full")
 	ErrOrderNotFound        = errors.New("order not found")
 	ErrDuplicateOrder       = errors.New("duplicate order")
+	ErrInvalidOrder         = errors.New("invalid order")
+	ErrPostOnlyWouldTake    = errors.New("post-only order would take liquidity")
+	ErrProcessTimeout       = errors.New("timed out waiting for match result")
+	ErrJournalWrite         = errors.New("failed to write command journal")
+	ErrVersionConflict      = orderbook.ErrVersionConflict
+	ErrInvalidAmend         = orderbook.ErrInvalidAmend
+	ErrStopOrderAmend       = orderbook.ErrStopOrderAmend
+
+	ErrSymbolHalted     = errors.New("symbol is not trading")
+	ErrInvalidTickSize  = errors.New("price is not a multiple of tick size")
+	ErrInvalidLotSize   = errors.New("quantity is not a multiple of lot size")
+	ErrQtyBelowMin      = errors.New("quantity below instrument minimum")
+	ErrQtyAboveMax      = errors.New("quantity above instrument maximum")
+	ErrNotionalBelowMin = errors.New("notional below instrument minimum")
+	ErrNotionalOverflow = errors.New("notional overflows int64")
 )
 
+// instrumentRejectErrors 交易对规格校验的拒绝原因 -> 错误
+var instrumentRejectErrors = map[string]error{
+	types.RejectReasonSymbolHalted:     ErrSymbolHalted,
+	types.RejectReasonCancelOnly:       ErrSymbolCancelOnly,
+	types.RejectReasonPostOnlyPhase:    ErrSymbolPostOnly,
+	types.RejectReasonAuctionPhase:     ErrSymbolAuction,
+	types.RejectReasonTickSize:         ErrInvalidTickSize,
+	types.RejectReasonLotSize:          ErrInvalidLotSize,
+	types.RejectReasonMinQty:           ErrQtyBelowMin,
+	types.RejectReasonMaxQty:           ErrQtyAboveMax,
+	types.RejectReasonMinNotional:      ErrNotionalBelowMin,
+	types.RejectReasonNotionalOverflow: ErrNotionalOverflow,
+}
+
 // OrderStatus 订单状态
 type OrderStatus int8
 
@@ -42,31 +75,105 @@ type OrderState struct {
 	FilledQuantity int64
 	OriginalQty    int64
 	CreateTime     int64
+	Version        uint32 // 订单版本，每次改单加一
+}
+
+// ProcessResult 同步下单的撮合结果
+type ProcessResult struct {
+	Result    *types.MatchResult // 撮合结果副本，不属于对象池，可安全持有
+	Status    OrderStatus        // 撮合完成后的订单状态
+	FilledQty int64              // 累计成交数量
+}
+
+// AmendResult 改单结果
+type AmendResult struct {
+	OrderID    uint64
+	Version    uint32        // 改单后的订单版本
+	RestingQty int64         // 改单后仍挂在订单簿中的数量
+	Trades     []types.Trade // 改价后立即成交产生的成交
 }
 
 type MatchingEngine struct {
 	config      *config.Config
+	symbolMu    sync.RWMutex // 保护orderBooks、instruments、inputQueues，交易对可在运行中上架/下架
 	orderBooks  map[string]*orderbook.HybridOrderBook
+	instruments map[string]*types.InstrumentSpec // 交易对规格，修改时整体替换，已取出的规格不会变化
+	delisted    map[string]uint64                // 本次运行中下架的交易对 -> 最后一笔成交的ID，受symbolMu保护
 	inputQueues map[string]*lockfree.RingBuffer
 	outputQueue *lockfree.RingBuffer
 	workers     []*MatchingWorker
+	assigner    *SymbolAssigner // 交易对 -> worker 分配
 	snapshotter *Snapshotter
 	cancel      context.CancelFunc
 	wg          sync.WaitGroup
 	started     bool
 	mu          sync.RWMutex
-	orderStates *sync.Map // 新增: 订单状态跟踪 (orderID -> *OrderState)
-	processed   *sync.Map // 新增: 幂等性检查 (orderID -> bool)
+	orderStates *sync.Map  // 新增: 订单状态跟踪 (orderID -> *OrderState)
+	stateMu     sync.Mutex // 保护OrderState字段，结果处理器、worker及查询会并发访问
+	processed   *sync.Map  // 新增: 幂等性检查 (orderID -> bool)
+	waiters     *sync.Map  // 同步下单等待撮合结果 (orderID -> chan *ProcessResult)
+
+	processTimeout time.Duration // 同步下单等待撮合结果的超时时间
+
+	journal   *journal.Journal // 命令日志，未启用时为nil
+	commandMu sync.Mutex       // 保证命令序号、日志顺序与入队顺序一致
+	lastSeq   uint64           // 最后一条已接收命令的序号，受commandMu保护
+
+	snapshotSeqs map[string]uint64 // 启动前从快照恢复的交易对 -> 快照序号，重放时跳过已包含的命令
+
+	follower      atomic.Bool   // 是否为follower，follower只应用primary复制的命令
+	replicas      *replicaHub   // primary: 已订阅的follower
+	replication   followerState // follower: 复制状态
+	requireAck    bool          // primary: 至少一个follower确认后才返回结果
+	ackTimeout    time.Duration // 等待follower确认的超时时间
+	replicaBuffer int           // 每个follower缓存的命令数
+
+	marketData *marketHub   // 行情订阅方
+	phases     phaseHistory // 最近的交易阶段切换记录
+
+	expiries *timingWheel // GTD订单到期定时器
 }
 
 func NewMatchingEngine(cfg *config.Config) *MatchingEngine {
 	engine := &MatchingEngine{
 		config:      cfg,
 		orderBooks:  make(map[string]*orderbook.HybridOrderBook),
+		instruments: make(map[string]*types.InstrumentSpec),
+		delisted:    make(map[string]uint64),
 		inputQueues: make(map[string]*lockfree.RingBuffer),
 		outputQueue: lockfree.NewRingBuffer(1024 * 1024),
 		orderStates: &sync.Map{}, // 初始化订单状态跟踪
 		processed:   &sync.Map{}, // 初始化幂等性检查
+		waiters:     &sync.Map{},
+
+		snapshotSeqs: make(map[string]uint64),
+		replicas:     newReplicaHub(),
+		marketData:   newMarketHub(),
+	}
+
+	processTimeout, err := time.ParseDuration(cfg.Matching.ProcessTimeout)
+	if err != nil || processTimeout <= 0 {
+		processTimeout = time.Second
+	}
+	engine.processTimeout = processTimeout
+
+	expiryInterval, err := time.ParseDuration(cfg.Matching.ExpiryCheckInterval)
+	if err != nil || expiryInterval <= 0 {
+		expiryInterval = 100 * time.Millisecond
+	}
+	engine.expiries = newTimingWheel(expiryInterval, time.Now().UnixNano())
+
+	// 主备复制
+	replication := cfg.Matching.Replication
+	engine.follower.Store(replication.Role == RoleFollower)
+	engine.requireAck = replication.RequireAck
+	engine.ackTimeout, err = time.ParseDuration(replication.AckTimeout)
+	if err != nil || engine.ackTimeout <= 0 {
+		engine.ackTimeout = 500 * time.Millisecond
+	}
+	engine.replicaBuffer = replication.BufferSize
+	if engine.replicaBuffer <= 0 {
+		engine.replicaBuffer = 65536
 	}
 
 	// 初始化订单簿
@@ -76,13 +183,39 @@ func NewMatchingEngine(cfg *config.Config) *MatchingEngine {
 	}
 
 	for _, symbol := range symbols {
-		engine.orderBooks[symbol] = orderbook.NewHybridOrderBook(symbol)
-		engine.inputQueues[symbol] = lockfree.NewRingBuffer(uint64(65536))
+		spec, err := cfg.Matching.InstrumentOf(symbol)
+		logx.Must(err)
+		_, err = engine.matchPolicyOf(spec)
+		logx.Must(err)
+		engine.addMarket(spec)
 	}
 
+	if cfg.Matching.Journal.Enabled {
+		engine.journal = openJournal(cfg.Matching.Journal)
+	}
+
+	engine.snapshotter = NewSnapshotter(engine.GetOrderBooks, engine.captureSnapshot, cfg.Matching.SnapshotInterval)
+
 	return engine
 }
 
+// openJournal 打开命令日志目录
+func openJournal(c config.JournalConfig) *journal.Journal {
+	syncInterval, err := time.ParseDuration(c.SyncInterval)
+	if err != nil {
+		syncInterval = 10 * time.Millisecond
+	}
+
+	j, err := journal.Open(journal.Options{
+		Dir:          c.Dir,
+		SegmentSize:  c.SegmentSize,
+		SyncMode:     c.SyncMode,
+		SyncInterval: syncInterval,
+	})
+	logx.Must(err)
+	return j
+}
+
 func (e *MatchingEngine) Start() error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
@@ -91,6 +224,13 @@ func (e *MatchingEngine) Start() error {
 		return ErrEngineAlreadyStarted
 	}
 
+	// 先从快照及命令日志恢复订单簿，再开始接收新命令
+	e.restoreSnapshots()
+	if err := e.replay(); err != nil {
+		return err
+	}
+	e.scheduleExpiries()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	e.cancel = cancel
 
@@ -103,6 +243,9 @@ func (e *MatchingEngine) Start() error {
 	// 启动结果处理器
 	e.startResultProcessor(ctx)
 
+	// 启动GTD订单过期检查
+	e.startExpiryChecker(ctx)
+
 	e.started = true
 	logx.Info("Matching engine started successfully")
 
@@ -115,10 +258,15 @@ func (e *MatchingEngine) startWorkers(ctx context.Context) {
 		workerCount = 32
 	}
 
+	e.assigner = NewSymbolAssigner(workerCount, e.config.Matching.WorkerAssignments)
 	for i := 0; i < workerCount; i++ {
-		worker := NewMatchingWorker(i, e.config, e.orderBooks, e.inputQueues, e.outputQueue)
-		e.workers = append(e.workers, worker)
+		e.workers = append(e.workers, NewMatchingWorker(i, e.config, e.outputQueue, e.marketData))
+	}
+
+	// 先分配交易对再启动worker
+	e.rebalance()
 
+	for _, worker := range e.workers {
 		e.wg.Add(1)
 		threading.GoSafe(func() {
 			defer e.wg.Done()
@@ -127,9 +275,51 @@ func (e *MatchingEngine) startWorkers(ctx context.Context) {
 	}
 }
 
-func (e *MatchingEngine) startSnapshotter(ctx context.Context) {
-	e.snapshotter = NewSnapshotter(e.orderBooks, e.config.Matching.SnapshotInterval)
+// rebalance 按当前交易对集合重新计算归属并下发给worker，交易对增加后调用，worker未启动时不做处理
+// 迁移的交易对在旧worker处理完当前批次后才交给新worker，保证同一交易对始终单线程按序撮合
+func (e *MatchingEngine) rebalance() {
+	if e.assigner == nil {
+		return
+	}
+
+	// 锁住全部worker后统一切换，避免迁移过程中两个worker同时持有同一交易对
+	// 加锁顺序与worker一致: 先worker再symbolMu，此时没有worker在执行命令，交易对集合不会被下架命令修改
+	for _, worker := range e.workers {
+		worker.mu.Lock()
+	}
+	e.symbolMu.RLock()
 
+	symbols := make([]string, 0, len(e.orderBooks))
+	for symbol := range e.orderBooks {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	assignments := e.assigner.AssignAll(symbols)
+	for _, worker := range e.workers {
+		slots := make([]symbolSlot, 0, len(assignments[worker.id]))
+		for _, symbol := range assignments[worker.id] {
+			slots = append(slots, symbolSlot{
+				symbol:    symbol,
+				queue:     e.inputQueues[symbol],
+				orderBook: e.orderBooks[symbol],
+				precision: e.instruments[symbol].Precision,
+			})
+		}
+		worker.slots = slots
+	}
+
+	e.symbolMu.RUnlock()
+	for _, worker := range e.workers {
+		worker.mu.Unlock()
+	}
+
+	for id, owned := range assignments {
+		logx.Infof("Matching worker %d owns symbols %v", id, owned)
+	}
+}
+
+func (e *MatchingEngine) startSnapshotter(ctx context.Context) {
 	e.wg.Add(1)
 	threading.GoSafe(func() {
 		defer e.wg.Done()
@@ -145,6 +335,63 @@ func (e *MatchingEngine) startResultProcessor(ctx context.Context) {
 	})
 }
 
+func (e *MatchingEngine) startExpiryChecker(ctx context.Context) {
+	e.wg.Add(1)
+	threading.GoSafe(func() {
+		defer e.wg.Done()
+
+		ticker := time.NewTicker(time.Duration(e.expiries.tick))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				e.expireOrders(time.Now().UnixNano())
+			}
+		}
+	})
+}
+
+// expireOrders 推进到期定时器，为有GTD订单到期的交易对提交过期清理命令，由交易对所属worker按序执行
+// follower不自行清理，由primary复制过期清理命令，接管为primary时重新登记定时器
+func (e *MatchingEngine) expireOrders(now int64) {
+	symbols := e.expiries.advance(now)
+	if e.follower.Load() {
+		return
+	}
+
+	for _, symbol := range symbols {
+		m, exists := e.market(symbol)
+		if !exists || !m.orderBook.HasExpired(now) {
+			continue
+		}
+
+		cmd := e.bindCommand(&journal.Command{Type: journal.CommandExpire, Symbol: symbol}, nil)
+		if err := e.enqueueCommand(cmd); err != nil {
+			logx.Errorf("Submit expire command for %s failed: %v", symbol, err)
+			if !errors.Is(err, ErrSymbolNotFound) {
+				e.expiries.schedule(symbol, now) // 下次推进时重试
+			}
+		}
+	}
+}
+
+// scheduleExpiry 登记交易对下一个GTD订单的到期时间，清理后剩余订单的到期时间由该定时器依次接续
+func (e *MatchingEngine) scheduleExpiry(symbol string, orderBook *orderbook.HybridOrderBook) {
+	if at, ok := orderBook.NextExpiry(); ok {
+		e.expiries.schedule(symbol, at)
+	}
+}
+
+// scheduleExpiries 从快照及日志恢复或接管为primary后为所有交易对登记到期定时器
+func (e *MatchingEngine) scheduleExpiries() {
+	for symbol, orderBook := range e.GetOrderBooks() {
+		e.scheduleExpiry(symbol, orderBook)
+	}
+}
+
 func (e *MatchingEngine) processResults(ctx context.Context) {
 	batchSize := e.config.Matching.BatchSize
 	if batchSize <= 0 {
@@ -173,29 +420,198 @@ func (e *MatchingEngine) processResults(ctx context.Context) {
 }
 
 func (e *MatchingEngine) handleMatchResult(result *types.MatchResult) {
+	// complete之后订单可能已被撤销并回收，提前取出交易对
+	symbol := result.Order.Symbol
+
 	// 记录交易指标
-	monitor.RecordOrderMatched(result.Order.Symbol, len(result.Trades))
+	monitor.RecordOrderMatched(symbol, len(result.Trades))
+
+	e.updateResultState(result)
+	e.complete(result)
+
+	// 被本次成交触发的止损单
+	for _, triggered := range result.Triggered {
+		monitor.RecordOrderMatched(triggered.Order.Symbol, len(triggered.Trades))
+		e.updateResultState(triggered)
+	}
 
 	// 更新订单簿深度
-	if orderBook, exists := e.orderBooks[result.Order.Symbol]; exists {
+	if orderBook, err := e.GetOrderBookBySymbol(symbol); err == nil {
 		depth := orderBook.GetDepth(10)
-		monitor.SetOrderBookDepth(result.Order.Symbol, depth)
+		monitor.SetOrderBookDepth(symbol, depth)
+	}
+
+	releaseMatchResult(result)
+}
+
+// updateResultState 根据撮合结果更新订单状态: 成交数量以及IOC/FOK/市价单剩余撤销
+func (e *MatchingEngine) updateResultState(result *types.MatchResult) {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	filledQty := result.TotalFilledQty()
+
+	// 按金额下单的市价单在撮合后才确定数量，未成交则视为撤销
+	if result.Order.IsQuoteOrder() {
+		if state, ok := e.orderStates.Load(result.Order.ID); ok {
+			os := state.(*OrderState)
+			os.OriginalQty = os.FilledQuantity + filledQty
+		}
+		if filledQty == 0 {
+			e.markOrderCancelled(result.Order.ID)
+		}
+	}
+
+	if filledQty > 0 {
+		e.updateOrderState(result.Order.ID, filledQty)
+	}
+
+	// 每笔成交对应一个挂单，按实际成交数量更新挂单状态
+	for _, trade := range result.Trades {
+		e.updateOrderState(trade.MakerOrderID, trade.Quantity)
+	}
+	if result.CancelledQty > 0 {
+		e.markOrderCancelled(result.Order.ID)
+	}
+
+	// 自成交预防撤销的挂单
+	for _, event := range result.SelfTrades {
+		if event.MakerRemoved {
+			e.markOrderCancelled(event.MakerOrderID)
+		}
+	}
+
+	// 撮合前撤销的过期GTD挂单，之后的定时清理不会再返回这些订单
+	for _, orderID := range result.Expired {
+		e.markOrderCancelled(orderID)
+		logx.Infof("GTD order %d expired, symbol: %s", orderID, result.Order.Symbol)
+	}
+}
+
+// complete 将撮合结果交给等待中的同步下单请求，结果随后会被回收，因此传递副本
+func (e *MatchingEngine) complete(result *types.MatchResult) {
+	waiter, ok := e.waiters.LoadAndDelete(result.Order.ID)
+	if !ok {
+		return
+	}
+
+	processResult := &ProcessResult{
+		Result: result.Clone(),
+		Status: OrderStatusPending,
+	}
+	e.stateMu.Lock()
+	if state, ok := e.orderStates.Load(result.Order.ID); ok {
+		os := state.(*OrderState)
+		processResult.Status = os.Status
+		processResult.FilledQty = os.FilledQuantity
+	}
+	e.stateMu.Unlock()
+
+	// 通道带缓冲，等待方超时退出后写入也不会阻塞
+	waiter.(chan *ProcessResult) <- processResult
+}
+
+// markOrderCancelled 标记订单为已取消(调用方需持有stateMu)
+func (e *MatchingEngine) markOrderCancelled(orderID uint64) {
+	if state, ok := e.orderStates.Load(orderID); ok {
+		state.(*OrderState).Status = OrderStatusCancelled
+	}
+}
+
+// ProcessOrder 同步下单，等待撮合完成后返回实际成交及订单最终状态
+// 超时返回ErrProcessTimeout，此时订单可能已经或即将被撮合，需通过查询确认状态
+// 开启RequireAck时撮合完成后还需等待follower确认，超时返回ErrReplicationTimeout
+func (e *MatchingEngine) ProcessOrder(order *types.Order) (*ProcessResult, error) {
+	done := make(chan *ProcessResult, 1)
+	seq, err := e.enqueue(order, done)
+	if err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(e.processTimeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-done:
+		if err := e.waitReplicated(seq); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case <-timer.C:
+		e.waiters.Delete(order.ID)
+		return nil, ErrProcessTimeout
 	}
 }
 
-func (e *MatchingEngine) ProcessOrder(order *types.Order) (*types.MatchResult, error) {
+// SubmitOrder 异步下单，入队后立即返回，撮合结果只能通过查询获取
+func (e *MatchingEngine) SubmitOrder(order *types.Order) error {
+	_, err := e.enqueue(order, nil)
+	return err
+}
+
+// enqueue 校验订单并放入交易对输入队列，done不为空时登记同步等待，返回命令序号
+func (e *MatchingEngine) enqueue(order *types.Order, done chan *ProcessResult) (uint64, error) {
+	// 参数校验(含有效期/过期时间)
+	if !order.IsValid() {
+		return 0, ErrInvalidOrder
+	}
+
 	// 幂等性检查
 	if _, exists := e.processed.LoadOrStore(order.ID, true); exists {
-		return nil, ErrDuplicateOrder
+		return 0, ErrDuplicateOrder
 	}
 
-	queue, exists := e.inputQueues[order.Symbol]
+	m, exists := e.market(order.Symbol)
 	if !exists {
 		e.processed.Delete(order.ID) // 回滚幂等性标记
-		return nil, ErrSymbolNotFound
+		return 0, ErrSymbolNotFound
+	}
+
+	// 交易对规格校验: 交易状态、tick、lot、数量及金额范围
+	if reason := m.spec.Check(order); reason != "" {
+		e.processed.Delete(order.ID) // 回滚幂等性标记
+		monitor.RecordOrderRejected(order.Symbol, reason)
+		return 0, instrumentRejectErrors[reason]
+	}
+
+	// Post-Only拒绝模式及只挂单阶段预检查，撮合时会再次以订单簿最新状态确认
+	postOnly := order.PostOnly == types.PostOnlyReject || (order.PostOnly != types.PostOnlySlide && m.spec.Phase() == types.PhasePostOnly)
+	if postOnly && m.orderBook.WouldCross(order) {
+		e.processed.Delete(order.ID) // 回滚幂等性标记
+		monitor.RecordOrderRejected(order.Symbol, types.RejectReasonPostOnly)
+		return 0, ErrPostOnlyWouldTake
 	}
 
 	// 记录订单状态
+	e.trackOrder(order)
+
+	// 入队前登记等待，避免撮合结果先于登记返回
+	if done != nil {
+		e.waiters.Store(order.ID, done)
+	}
+
+	// 使用对象池获取订单指针
+	orderPtr := types.GetOrderFromPool()
+	*orderPtr = *order
+
+	cmd := e.bindCommand(&journal.Command{Type: journal.CommandNewOrder, Symbol: order.Symbol, Order: orderPtr}, nil)
+	if err := e.enqueueCommand(cmd); err != nil {
+		types.PutOrderToPool(orderPtr)
+		e.processed.Delete(order.ID) // 回滚幂等性标记
+		e.orderStates.Delete(order.ID)
+		e.waiters.Delete(order.ID)
+		return 0, err
+	}
+	if order.GetTimeInForce() == types.TimeInForceGTD {
+		e.expiries.schedule(order.Symbol, order.ExpireTime)
+	}
+
+	return cmd.Seq, nil
+}
+
+// trackOrder 记录已接收订单的幂等性标记及初始状态
+func (e *MatchingEngine) trackOrder(order *types.Order) {
+	e.processed.Store(order.ID, true)
 	e.orderStates.Store(order.ID, &OrderState{
 		OrderID:        order.ID,
 		Symbol:         order.Symbol,
@@ -204,33 +620,121 @@ func (e *MatchingEngine) ProcessOrder(order *types.Order) (*types.MatchResult, e
 		OriginalQty:    order.Quantity,
 		CreateTime:     order.Timestamp,
 	})
+}
 
-	// 使用对象池获取订单指针
-	orderPtr := types.GetOrderFromPool()
-	*orderPtr = *order
+// RestoreFromSnapshot 启动前用快照恢复交易对订单簿及挂单状态，Start时只重放快照之后的命令
+func (e *MatchingEngine) RestoreFromSnapshot(symbol string, data []byte) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 
-	if !queue.Push(unsafe.Pointer(orderPtr)) {
-		types.PutOrderToPool(orderPtr)
-		e.processed.Delete(order.ID) // 回滚幂等性标记
-		e.orderStates.Delete(order.ID)
-		return nil, ErrQueueFull
+	if e.started {
+		return ErrEngineAlreadyStarted
 	}
+	return e.restoreSnapshot(symbol, data)
+}
 
-	// 在实际生产环境中，这里应该等待处理结果
-	// 简化处理，直接返回空结果
-	return &types.MatchResult{
-		Order:     order,
-		Timestamp: time.Now().UnixNano(),
-	}, nil
+// SetSnapshotStore 设置快照存储，需在Start之前调用
+func (e *MatchingEngine) SetSnapshotStore(store SnapshotStore) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.snapshotter.SetStore(store)
+}
+
+// restoreSnapshots 从快照存储恢复尚未恢复的交易对，只在启用命令日志时恢复
+// 没有日志时快照之后的撤单、成交无法补齐，恢复出的订单簿反而与实际不符
+// 存储中有快照但不在配置中的交易对是运行中上架的，按快照中的规格重新创建
+func (e *MatchingEngine) restoreSnapshots() {
+	if e.journal == nil || e.snapshotter.store == nil {
+		return
+	}
+
+	symbols := e.GetSymbols()
+	stored, err := e.snapshotter.StoredSymbols()
+	if err != nil {
+		logx.Errorf("List symbols in snapshot store failed: %v", err)
+	}
+	for _, symbol := range stored {
+		if !slices.Contains(symbols, symbol) {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	for _, symbol := range symbols {
+		if _, restored := e.snapshotSeqs[symbol]; restored {
+			continue
+		}
+
+		snapshot, err := e.snapshotter.LatestSnapshot(symbol)
+		if errors.Is(err, ErrSnapshotNotFound) {
+			continue
+		}
+		if err == nil {
+			err = e.restoreStoredSnapshot(snapshot)
+		}
+		if err != nil {
+			// 恢复失败时该交易对从头重放日志
+			logx.Errorf("Restore %s from snapshot failed, fall back to full journal replay: %v", symbol, err)
+		}
+	}
+}
+
+// restoreStoredSnapshot 按快照中的规格创建运行中上架的交易对后，再恢复订单簿
+// 配置中的交易对以配置的规格为准，交易阶段以快照中的订单簿为准
+func (e *MatchingEngine) restoreStoredSnapshot(snapshot *Snapshot) error {
+	spec := snapshot.Instrument
+	if spec == nil || !e.addMarket(*spec) {
+		return e.restoreSnapshot(snapshot.Symbol, snapshot.Data)
+	}
+
+	if err := e.restoreSnapshot(snapshot.Symbol, snapshot.Data); err != nil {
+		e.removeMarket(snapshot.Symbol) // 由重放日志中的上架命令重新创建
+		return err
+	}
+	return nil
+}
+
+// restoreSnapshot 恢复交易对订单簿及挂单状态(调用方需持有mu)
+func (e *MatchingEngine) restoreSnapshot(symbol string, data []byte) error {
+	snapshot, err := e.snapshotter.RestoreFromSnapshot(symbol, data)
+	if err != nil {
+		return err
+	}
+
+	e.stateMu.Lock()
+	for _, order := range snapshot.Orders() {
+		e.trackOrder(order)
+		if state, ok := e.orderStates.Load(order.ID); ok {
+			state.(*OrderState).Version = order.Version
+		}
+	}
+	// 挂单已成交数量 = 原始数量 - 剩余数量，未触发的止损单尚未成交
+	for _, order := range slices.Concat(snapshot.Bids, snapshot.Asks) {
+		if filledQty := order.Quantity - order.VisibleQty - order.HiddenQty; filledQty > 0 {
+			e.updateOrderState(order.ID, filledQty)
+		}
+	}
+	e.stateMu.Unlock()
+
+	// 规格中的交易状态可能领先于快照，以订单簿拍摄快照时的交易阶段为准，之后的切换由重放恢复
+	if orderBook, err := e.GetOrderBookBySymbol(symbol); err == nil {
+		e.setSymbolStatus(symbol, orderBook.Phase().Status())
+	}
+
+	e.snapshotSeqs[symbol] = snapshot.Seq
+	if snapshot.Seq > e.lastSeq {
+		e.lastSeq = snapshot.Seq
+	}
+	return nil
 }
 
 func (e *MatchingEngine) GetOrderBook(symbol string, depth int) (*types.OrderBook, error) {
-	orderBook, exists := e.orderBooks[symbol]
+	m, exists := e.market(symbol)
 	if !exists {
 		return nil, ErrSymbolNotFound
 	}
 
-	return orderBook.GetSnapshot(depth), nil
+	return m.orderBook.GetSnapshot(depth), nil
 }
 
 func (e *MatchingEngine) Stop() {
@@ -248,43 +752,90 @@ func (e *MatchingEngine) Stop() {
 	e.wg.Wait()
 	e.started = false
 
+	if e.journal != nil {
+		if err := e.journal.Close(); err != nil {
+			logx.Errorf("Close journal failed: %v", err)
+		}
+	}
+
 	logx.Info("Matching engine stopped successfully")
 }
 
-// CancelOrder 取消订单
+// CancelOrder 取消订单，撤单命令写入日志后由交易对所属worker执行
 func (e *MatchingEngine) CancelOrder(orderID uint64, symbol string) (bool, error) {
-	orderBook, exists := e.orderBooks[symbol]
-	if !exists {
-		return false, ErrSymbolNotFound
+	if _, err := e.submitCommand(&journal.Command{
+		Type:    journal.CommandCancel,
+		Symbol:  symbol,
+		OrderID: orderID,
+	}); err != nil {
+		return false, err
 	}
+	return true, nil
+}
 
-	// 尝试从订单簿中取消
-	success := orderBook.CancelOrder(orderID)
-	if !success {
-		return false, ErrOrderNotFound
+// AmendOrder 修改挂单价格/数量，version为客户端持有的订单版本，用于拒绝过期的改单请求
+// 未触发的止损单不支持改单，返回ErrStopOrderAmend
+func (e *MatchingEngine) AmendOrder(orderID uint64, symbol string, price int64, qty int64, version uint32) (*AmendResult, error) {
+	if err := e.checkAmend(orderID, symbol, price, qty); err != nil {
+		return nil, err
 	}
 
-	// 更新订单状态
-	if state, ok := e.orderStates.Load(orderID); ok {
-		os := state.(*OrderState)
-		os.Status = OrderStatusCancelled
+	reply, err := e.submitCommand(&journal.Command{
+		Type:     journal.CommandAmend,
+		Symbol:   symbol,
+		OrderID:  orderID,
+		Price:    price,
+		Quantity: qty,
+		Version:  version,
+	})
+	if err != nil {
+		return nil, err
 	}
+	return reply.amend, nil
+}
 
-	logx.Infof("Order %d cancelled successfully", orderID)
-	return true, nil
+// checkAmend 按交易对规格校验改单后的价格及数量，拒绝原因与下单一致
+// 挂单不存在时不在这里拒绝，由worker按执行顺序返回ErrOrderNotFound
+func (e *MatchingEngine) checkAmend(orderID uint64, symbol string, price, qty int64) error {
+	m, exists := e.market(symbol)
+	if !exists {
+		return ErrSymbolNotFound
+	}
+	order, exists := m.orderBook.RestingOrder(orderID)
+	if !exists {
+		return nil
+	}
+	if reason := m.spec.CheckAmend(&order, price, qty); reason != "" {
+		monitor.RecordOrderRejected(symbol, reason)
+		return instrumentRejectErrors[reason]
+	}
+	return nil
 }
 
-// GetOrderState 查询订单状态
+// GetOrderState 查询订单状态，返回状态副本
 func (e *MatchingEngine) GetOrderState(orderID uint64) (*OrderState, error) {
 	state, exists := e.orderStates.Load(orderID)
 	if !exists {
 		return nil, ErrOrderNotFound
 	}
-	return state.(*OrderState), nil
+
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	os := *state.(*OrderState)
+	return &os, nil
 }
 
 // UpdateOrderState 更新订单状态(内部使用)
 func (e *MatchingEngine) UpdateOrderState(orderID uint64, filledQty int64) {
+	e.stateMu.Lock()
+	defer e.stateMu.Unlock()
+
+	e.updateOrderState(orderID, filledQty)
+}
+
+// updateOrderState 累加成交数量并更新状态(调用方需持有stateMu)
+func (e *MatchingEngine) updateOrderState(orderID uint64, filledQty int64) {
 	state, exists := e.orderStates.Load(orderID)
 	if !exists {
 		return
@@ -293,6 +844,11 @@ func (e *MatchingEngine) UpdateOrderState(orderID uint64, filledQty int64) {
 	os := state.(*OrderState)
 	os.FilledQuantity += filledQty
 
+	// 撤单由worker同步标记，撤单前的成交结果可能稍后才被处理，不能覆盖已取消状态
+	if os.Status == OrderStatusCancelled {
+		return
+	}
+
 	// 更新状态
 	if os.FilledQuantity >= os.OriginalQty {
 		os.Status = OrderStatusFilled
@@ -303,8 +859,8 @@ func (e *MatchingEngine) UpdateOrderState(orderID uint64, filledQty int64) {
 
 // GetSymbols 获取所有交易对
 func (e *MatchingEngine) GetSymbols() []string {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.symbolMu.RLock()
+	defer e.symbolMu.RUnlock()
 
 	symbols := make([]string, 0, len(e.orderBooks))
 	for symbol := range e.orderBooks {
@@ -315,24 +871,24 @@ func (e *MatchingEngine) GetSymbols() []string {
 
 // GetQueueSize 获取队列大小
 func (e *MatchingEngine) GetQueueSize(symbol string) (uint64, error) {
-	queue, exists := e.inputQueues[symbol]
+	m, exists := e.market(symbol)
 	if !exists {
 		return 0, ErrSymbolNotFound
 	}
-	return queue.Size(), nil
+	return m.queue.Size(), nil
 }
 
-// GetOrderBooks 获取所有订单簿的引用
+// GetOrderBooks 获取当前全部订单簿，返回的map为副本，之后上架/下架交易对不影响
 func (e *MatchingEngine) GetOrderBooks() map[string]*orderbook.HybridOrderBook {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
-	return e.orderBooks
+	e.symbolMu.RLock()
+	defer e.symbolMu.RUnlock()
+	return maps.Clone(e.orderBooks)
 }
 
 // GetOrderBookBySymbol 获取指定交易对的订单簿
 func (e *MatchingEngine) GetOrderBookBySymbol(symbol string) (*orderbook.HybridOrderBook, error) {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.symbolMu.RLock()
+	defer e.symbolMu.RUnlock()
 
 	ob, exists := e.orderBooks[symbol]
 	if !exists {
@@ -340,3 +896,38 @@ func (e *MatchingEngine) GetOrderBookBySymbol(symbol string) (*orderbook.HybridO
 	}
 	return ob, nil
 }
+
+// GetPrecision 获取指定交易对的价格及数量精度
+func (e *MatchingEngine) GetPrecision(symbol string) (types.Precision, error) {
+	e.symbolMu.RLock()
+	defer e.symbolMu.RUnlock()
+
+	spec, exists := e.instruments[symbol]
+	if !exists {
+		return types.Precision{}, ErrSymbolNotFound
+	}
+	return spec.Precision, nil
+}
+
+// GetInstruments 获取交易对规格，symbol为空时按交易对名称排序返回全部
+func (e *MatchingEngine) GetInstruments(symbol string) ([]types.InstrumentSpec, error) {
+	e.symbolMu.RLock()
+	defer e.symbolMu.RUnlock()
+
+	if symbol != "" {
+		spec, exists := e.instruments[symbol]
+		if !exists {
+			return nil, ErrSymbolNotFound
+		}
+		return []types.InstrumentSpec{*spec}, nil
+	}
+
+	specs := make([]types.InstrumentSpec, 0, len(e.instruments))
+	for _, spec := range e.instruments {
+		specs = append(specs, *spec)
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Symbol < specs[j].Symbol
+	})
+	return specs, nil
+}
@@ -0,0 +1,203 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	snapshotFileExt = ".snap"
+	archiveDir      = ".archive" // 下架交易对的最终快照
+)
+
+// FileSnapshotStore 本地目录快照存储，每个交易对一个子目录，每份快照一个文件
+type FileSnapshotStore struct {
+	dir    string
+	policy RetentionPolicy
+}
+
+// NewFileSnapshotStore 创建本地目录快照存储
+func NewFileSnapshotStore(dir string, policy RetentionPolicy) (*FileSnapshotStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileSnapshotStore{dir: dir, policy: policy}, nil
+}
+
+// Save 先写临时文件并fsync，再重命名为正式文件名，宕机时不会留下写了一半的快照
+func (s *FileSnapshotStore) Save(snapshot *Snapshot) error {
+	name := snapshotName(snapshot.Seq, snapshot.Timestamp) + snapshotFileExt
+	if err := writeFileAtomic(filepath.Join(s.dir, snapshot.Symbol), name, encodeSnapshotRecord(snapshot)); err != nil {
+		return err
+	}
+
+	s.prune(snapshot.Symbol)
+	return nil
+}
+
+// Symbols 列出存有快照的交易对，忽略归档目录
+func (s *FileSnapshotStore) Symbols() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var symbols []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			symbols = append(symbols, entry.Name())
+		}
+	}
+	return symbols, nil
+}
+
+// Archive 将最终快照写入归档目录后删除交易对的快照目录
+func (s *FileSnapshotStore) Archive(snapshot *Snapshot) error {
+	name := snapshotName(snapshot.Seq, snapshot.Timestamp) + snapshotFileExt
+	if err := writeFileAtomic(filepath.Join(s.dir, archiveDir, snapshot.Symbol), name, encodeSnapshotRecord(snapshot)); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(filepath.Join(s.dir, snapshot.Symbol)); err != nil {
+		return err
+	}
+	return syncDir(s.dir)
+}
+
+// Latest 从新到旧读取快照，跳过校验失败的文件
+func (s *FileSnapshotStore) Latest(symbol string) (*Snapshot, error) {
+	return s.newest(s.dir, symbol)
+}
+
+// Archived 从新到旧读取归档目录中的最终快照，跳过校验失败的文件
+func (s *FileSnapshotStore) Archived(symbol string) (*Snapshot, error) {
+	return s.newest(filepath.Join(s.dir, archiveDir), symbol)
+}
+
+// newest 读取dir下交易对序号最大且校验通过的快照
+func (s *FileSnapshotStore) newest(dir, symbol string) (*Snapshot, error) {
+	refs, err := listSnapshots(filepath.Join(dir, symbol))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range refs {
+		path := filepath.Join(dir, symbol, snapshotName(ref.seq, ref.timestamp)+snapshotFileExt)
+		record, err := os.ReadFile(path)
+		if err != nil {
+			logx.Errorf("Read snapshot %s failed: %v", path, err)
+			continue
+		}
+
+		snapshot, err := decodeSnapshotRecord(record)
+		if err == nil && (snapshot.Symbol != symbol || snapshot.Seq != ref.seq) {
+			err = fmt.Errorf("%w: snapshot of %s seq %d", ErrSnapshotChecksum, snapshot.Symbol, snapshot.Seq)
+		}
+		if err != nil {
+			logx.Errorf("Skip invalid snapshot %s: %v", path, err)
+			continue
+		}
+		return snapshot, nil
+	}
+
+	return nil, ErrSnapshotNotFound
+}
+
+// prune 按保留策略删除旧快照，没有校验通过的快照时不做清理
+func (s *FileSnapshotStore) prune(symbol string) {
+	newest, err := newestValid(s, symbol)
+	if err != nil {
+		logx.Errorf("Skip pruning snapshots of %s: %v", symbol, err)
+		return
+	}
+	refs, err := s.list(symbol)
+	if err != nil {
+		logx.Errorf("List snapshots of %s failed: %v", symbol, err)
+		return
+	}
+
+	for _, ref := range s.policy.expired(refs, newest, time.Now()) {
+		if err := os.Remove(s.path(symbol, ref)); err != nil && !os.IsNotExist(err) {
+			logx.Errorf("Remove snapshot %s failed: %v", s.path(symbol, ref), err)
+		}
+	}
+}
+
+// list 列出交易对的快照，按序号从新到旧排列
+func (s *FileSnapshotStore) list(symbol string) ([]snapshotRef, error) {
+	return listSnapshots(filepath.Join(s.dir, symbol))
+}
+
+func (s *FileSnapshotStore) path(symbol string, ref snapshotRef) string {
+	return filepath.Join(s.dir, symbol, snapshotName(ref.seq, ref.timestamp)+snapshotFileExt)
+}
+
+// listSnapshots 列出目录中的快照文件，按序号从新到旧排列
+func listSnapshots(dir string) ([]snapshotRef, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []snapshotRef
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, snapshotFileExt) {
+			continue
+		}
+		if ref, ok := parseSnapshotName(strings.TrimSuffix(name, snapshotFileExt)); ok {
+			refs = append(refs, ref)
+		}
+	}
+
+	sortSnapshotRefs(refs)
+	return refs, nil
+}
+
+// writeFileAtomic 先写临时文件并fsync，再重命名为正式文件名
+func writeFileAtomic(dir, name string, data []byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // 重命名成功后删除不存在的文件不产生影响
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, name)); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir 刷新目录项，保证重命名后的快照在宕机后可见
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
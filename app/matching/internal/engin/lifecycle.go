@@ -0,0 +1,356 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tsfdsong/tradeengin/app/matching/internal/journal"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/orderbook"
+	"github.com/tsfdsong/tradeengin/app/pkg/lockfree"
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const inputQueueSize = 65536 // 每个交易对输入队列的容量
+
+var (
+	ErrEngineNotStarted = errors.New("matching engine not started")
+	ErrSymbolExists     = errors.New("symbol already exists")
+	ErrInvalidSymbol    = errors.New("invalid symbol specification")
+)
+
+// market 交易对的规格、订单簿及输入队列
+type market struct {
+	spec      *types.InstrumentSpec
+	orderBook *orderbook.HybridOrderBook
+	queue     *lockfree.RingBuffer
+}
+
+// SymbolInfo 交易对运行状态
+type SymbolInfo struct {
+	Spec       types.InstrumentSpec
+	Worker     int    // 所属worker编号，worker未启动时为-1
+	QueueSize  uint64 // 输入队列中待执行的命令数
+	OrderCount int    // 挂单及未触发的止损单数量
+}
+
+// DelistResult 下架结果
+type DelistResult struct {
+	Symbol    string
+	Cancelled int    // 撤销的挂单数
+	Seq       uint64 // 最终快照包含的最后一条命令序号
+	Archived  bool   // 最终快照是否已归档
+}
+
+// market 获取交易对的规格、订单簿及输入队列
+func (e *MatchingEngine) market(symbol string) (market, bool) {
+	e.symbolMu.RLock()
+	defer e.symbolMu.RUnlock()
+
+	orderBook, exists := e.orderBooks[symbol]
+	if !exists {
+		return market{}, false
+	}
+	return market{
+		spec:      e.instruments[symbol],
+		orderBook: orderBook,
+		queue:     e.inputQueues[symbol],
+	}, true
+}
+
+// matchPolicyOf 按配置创建交易对的撮合策略，按比例分配的数量按交易对的lot取整
+func (e *MatchingEngine) matchPolicyOf(spec types.InstrumentSpec) (orderbook.MatchPolicy, error) {
+	c := e.config.Matching.MatchPolicyOf(spec.Symbol)
+	minAllocation, err := spec.Precision.ParseQty(c.MinAllocation)
+	if err != nil {
+		return nil, fmt.Errorf("symbol %s: invalid MinAllocation %q: %w", spec.Symbol, c.MinAllocation, err)
+	}
+	policy, err := orderbook.NewMatchPolicy(c.Algorithm, minAllocation, spec.LotSize)
+	if err != nil {
+		return nil, fmt.Errorf("symbol %s: %w: %q", spec.Symbol, err, c.Algorithm)
+	}
+	return policy, nil
+}
+
+// addMarket 按规格创建交易对的订单簿及输入队列，交易对已存在时返回false
+// 撮合策略配置无效时按价格时间优先撮合，启动时已校验配置的交易对
+func (e *MatchingEngine) addMarket(spec types.InstrumentSpec) bool {
+	orderBook := orderbook.NewHybridOrderBook(spec.Symbol)
+	if policy, err := e.matchPolicyOf(spec); err != nil {
+		logx.Errorf("Use FIFO matching: %v", err)
+	} else {
+		orderBook.SetMatchPolicy(policy)
+	}
+	orderBook.SetSTPMode(e.config.Matching.STPModeOf(spec.Symbol))
+	orderBook.SetMaxSlippage(e.config.Matching.MaxSlippageBps)
+	orderBook.SetPrecision(spec.Precision)
+	orderBook.SetTickSize(spec.TickSize)
+	orderBook.SetPhase(spec.Phase())
+
+	e.symbolMu.Lock()
+	defer e.symbolMu.Unlock()
+
+	if _, exists := e.orderBooks[spec.Symbol]; exists {
+		return false
+	}
+	e.instruments[spec.Symbol] = &spec
+	e.orderBooks[spec.Symbol] = orderBook
+	e.inputQueues[spec.Symbol] = lockfree.NewRingBuffer(uint64(inputQueueSize))
+	return true
+}
+
+// removeMarket 移除交易对，worker在执行下架命令后自行移除对应的队列
+func (e *MatchingEngine) removeMarket(symbol string) {
+	e.symbolMu.Lock()
+	defer e.symbolMu.Unlock()
+
+	delete(e.instruments, symbol)
+	delete(e.orderBooks, symbol)
+	delete(e.inputQueues, symbol)
+}
+
+// setSymbolStatus 替换交易对规格以修改交易状态，交易对不存在时返回false
+func (e *MatchingEngine) setSymbolStatus(symbol, status string) bool {
+	e.symbolMu.Lock()
+	defer e.symbolMu.Unlock()
+
+	spec, exists := e.instruments[symbol]
+	if !exists {
+		return false
+	}
+	updated := *spec
+	updated.Status = status
+	e.instruments[symbol] = &updated
+	return true
+}
+
+// applySymbolCommand 执行上架/切换交易阶段/下架命令对交易对集合及状态的修改，实时执行、复制与重放共用
+// 调用方需持有commandMu或处于启动重放中，之后的命令都能看到修改后的状态
+// 订单簿的交易阶段切换、下架撤单及移除由交易对所属worker按序执行
+func (e *MatchingEngine) applySymbolCommand(jc *journal.Command) error {
+	switch jc.Type {
+	case journal.CommandAddSymbol:
+		if jc.Instrument == nil || jc.Instrument.Symbol != jc.Symbol {
+			return ErrInvalidSymbol
+		}
+		if !e.addMarket(*jc.Instrument) {
+			return ErrSymbolExists
+		}
+		if jc.TradeSeq > 0 {
+			m, _ := e.market(jc.Symbol)
+			m.orderBook.SetTradeSeq(jc.TradeSeq)
+		}
+		e.rebalance()
+		logx.Infof("Symbol %s listed, seq: %d", jc.Symbol, jc.Seq)
+		return nil
+	case journal.CommandHalt, journal.CommandResume, journal.CommandSetPhase:
+		phase := commandPhase(jc)
+		if phase.Status() == "" {
+			return ErrInvalidPhase
+		}
+		if !e.setSymbolStatus(jc.Symbol, phase.Status()) {
+			return ErrSymbolNotFound
+		}
+		logx.Infof("[audit] Symbol %s trading phase set to %s by %q, reason: %q, seq: %d", jc.Symbol, phase, jc.Operator, jc.Reason, jc.Seq)
+		return nil
+	case journal.CommandDelist:
+		if !e.setSymbolStatus(jc.Symbol, types.InstrumentDelisting) {
+			return ErrSymbolNotFound
+		}
+		logx.Infof("Symbol %s delisting, seq: %d", jc.Symbol, jc.Seq)
+		return nil
+	default:
+		return fmt.Errorf("command type %d is not a symbol command", jc.Type)
+	}
+}
+
+// applyDelist 下架交易对: 拍摄最终快照，撤销全部挂单后移除交易对
+func (e *MatchingEngine) applyDelist(orderBook *orderbook.HybridOrderBook, jc *journal.Command) *commandReply {
+	m, _ := e.market(jc.Symbol)
+	reply := &commandReply{
+		snapshot:   orderBook.Snapshot(),
+		instrument: m.spec,
+		retired:    true,
+	}
+	reply.snapshot.Seq = jc.Seq - 1 // 包含下架命令之前的全部命令
+	reply.snapshot.Timestamp = jc.Timestamp
+
+	cancelled := orderBook.CancelAll()
+	e.stateMu.Lock()
+	for _, orderID := range cancelled {
+		e.markOrderCancelled(orderID)
+	}
+	e.stateMu.Unlock()
+	reply.cancelled = len(cancelled)
+
+	e.symbolMu.Lock()
+	e.delisted[jc.Symbol] = reply.snapshot.TradeSeq
+	e.symbolMu.Unlock()
+	e.removeMarket(jc.Symbol)
+	logx.Infof("Symbol %s delisted, %d orders cancelled, seq: %d", jc.Symbol, len(cancelled), jc.Seq)
+	return reply
+}
+
+// AddSymbol 上架交易对，写入日志后立即创建订单簿及输入队列并分配worker
+// 配置了快照存储时立即保存一份快照，重启时据此恢复该交易对
+func (e *MatchingEngine) AddSymbol(spec types.InstrumentSpec) error {
+	if spec.Status == "" {
+		spec.Status = types.InstrumentTrading
+	}
+	if err := validateSpec(spec); err != nil {
+		return err
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if !e.started {
+		return ErrEngineNotStarted
+	}
+
+	jc := &journal.Command{
+		Type:       journal.CommandAddSymbol,
+		Symbol:     spec.Symbol,
+		Instrument: &spec,
+	}
+	if err := e.appendSymbolCommand(jc); err != nil {
+		return err
+	}
+
+	if snapshot := e.snapshotter.takeSnapshot(spec.Symbol); snapshot != nil {
+		e.snapshotter.save(snapshot)
+	}
+	return e.waitReplicated(jc.Seq)
+}
+
+// appendSymbolCommand 分配序号、写入日志后执行上架命令
+func (e *MatchingEngine) appendSymbolCommand(jc *journal.Command) error {
+	e.commandMu.Lock()
+	defer e.commandMu.Unlock()
+
+	if e.follower.Load() {
+		return ErrNotPrimary
+	}
+	if _, exists := e.market(jc.Symbol); exists {
+		return ErrSymbolExists
+	}
+
+	tradeSeq, err := e.lastTradeSeq(jc.Symbol)
+	if err != nil {
+		return err
+	}
+	jc.TradeSeq = tradeSeq
+	jc.Timestamp = time.Now().UnixNano()
+	if err := e.appendCommand(jc); err != nil {
+		return err
+	}
+	return e.applySymbolCommand(jc)
+}
+
+// lastTradeSeq 交易对此前下架时的最后成交ID，从未上架过时返回0
+// 取本次运行中的下架记录与归档快照中的较大值，重新上架后的成交ID不会与下架前重复
+func (e *MatchingEngine) lastTradeSeq(symbol string) (uint64, error) {
+	e.symbolMu.RLock()
+	tradeSeq := e.delisted[symbol]
+	e.symbolMu.RUnlock()
+
+	snapshot, err := e.snapshotter.ArchivedSnapshot(symbol)
+	if errors.Is(err, ErrSnapshotNotFound) {
+		return tradeSeq, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read archived snapshot of %s: %w", symbol, err)
+	}
+	book := &orderbook.BookSnapshot{}
+	if err := book.UnmarshalBinary(snapshot.Data); err != nil {
+		return 0, fmt.Errorf("decode archived snapshot of %s: %w", symbol, err)
+	}
+	return max(tradeSeq, book.TradeSeq), nil
+}
+
+// HaltSymbol 暂停交易对，之后的下单及改单被拒绝，撤单照常执行
+func (e *MatchingEngine) HaltSymbol(symbol, operator, reason string) error {
+	return e.SetTradingPhase(symbol, types.PhaseHalted, operator, reason)
+}
+
+// ResumeSymbol 恢复交易对连续撮合
+func (e *MatchingEngine) ResumeSymbol(symbol, operator, reason string) error {
+	return e.SetTradingPhase(symbol, types.PhaseContinuous, operator, reason)
+}
+
+// DelistSymbol 下架交易对: 之后该交易对的命令均被拒绝，所属worker执行完此前的命令后撤销全部挂单并移除交易对
+// 下架前的最终订单簿归档到快照存储，并删除该交易对的快照
+func (e *MatchingEngine) DelistSymbol(symbol string) (*DelistResult, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if !e.started {
+		return nil, ErrEngineNotStarted
+	}
+
+	reply, err := e.submitCommand(&journal.Command{Type: journal.CommandDelist, Symbol: symbol})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DelistResult{Symbol: symbol, Cancelled: reply.cancelled, Seq: reply.snapshot.Seq}
+	snapshot, err := newSnapshot(reply.snapshot, reply.instrument)
+	if err == nil {
+		err = e.snapshotter.Archive(snapshot)
+	}
+	if err != nil {
+		// 交易对已下架，归档失败只记录日志，重启时残留的快照恢复出的交易对会被重放的下架命令再次移除
+		logx.Errorf("Archive final snapshot of %s failed: %v", symbol, err)
+	} else {
+		result.Archived = e.snapshotter.store != nil
+	}
+	return result, nil
+}
+
+// ListSymbols 获取全部交易对的运行状态，按交易对名称排序
+func (e *MatchingEngine) ListSymbols() []SymbolInfo {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	e.symbolMu.RLock()
+	infos := make([]SymbolInfo, 0, len(e.orderBooks))
+	for symbol, orderBook := range e.orderBooks {
+		infos = append(infos, SymbolInfo{
+			Spec:       *e.instruments[symbol],
+			Worker:     -1,
+			QueueSize:  e.inputQueues[symbol].Size(),
+			OrderCount: orderBook.OrderCount(),
+		})
+	}
+	e.symbolMu.RUnlock()
+
+	for i := range infos {
+		if e.assigner != nil {
+			infos[i].Worker = e.assigner.Assign(infos[i].Spec.Symbol)
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Spec.Symbol < infos[j].Spec.Symbol
+	})
+	return infos
+}
+
+// validateSpec 校验上架交易对的规格
+func validateSpec(spec types.InstrumentSpec) error {
+	_, ok := types.PhaseOf(spec.Status)
+	switch {
+	case spec.Symbol == "":
+		return fmt.Errorf("%w: empty symbol", ErrInvalidSymbol)
+	case !ok:
+		return fmt.Errorf("%w: status %q", ErrInvalidSymbol, spec.Status)
+	case spec.Precision.PriceScale < 0 || spec.Precision.QuantityScale < 0:
+		return fmt.Errorf("%w: negative scale", ErrInvalidSymbol)
+	case spec.TickSize <= 0 || spec.LotSize <= 0:
+		return fmt.Errorf("%w: tick size and lot size must be positive", ErrInvalidSymbol)
+	case spec.MinQty < 0 || spec.MaxQty < 0 || spec.MinNotional < 0:
+		return fmt.Errorf("%w: negative limit", ErrInvalidSymbol)
+	case spec.MaxQty > 0 && spec.MaxQty < spec.MinQty:
+		return fmt.Errorf("%w: MaxQty is less than MinQty", ErrInvalidSymbol)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/tsfdsong/tradeengin/app/matching/internal/orderbook"
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const marketDataBuffer = 4096 // 每个行情订阅缓存的事件数
+
+var ErrMarketDataOverflow = errors.New("market data subscriber fell too far behind")
+
+// MarketEventType 行情事件类型
+type MarketEventType uint8
+
+const (
+	MarketEventPhase      MarketEventType = 1 // 交易阶段切换
+	MarketEventIndicative MarketEventType = 2 // 集合竞价参考成交价及成交量变化
+	MarketEventUncross    MarketEventType = 3 // 集合竞价撮合
+	MarketEventDepth      MarketEventType = 4 // 增量深度
+	MarketEventOrders     MarketEventType = 5 // 逐笔委托
+)
+
+// eventMask 订阅的事件类型集合
+type eventMask uint32
+
+func maskOf(kinds ...MarketEventType) eventMask {
+	var mask eventMask
+	for _, t := range kinds {
+		mask |= 1 << t
+	}
+	return mask
+}
+
+func (m eventMask) has(t MarketEventType) bool {
+	return m&(1<<t) != 0
+}
+
+// MarketEvent 推送给行情订阅方的事件，同一交易对的事件按worker执行顺序发布
+type MarketEvent struct {
+	Type      MarketEventType
+	Symbol    string
+	Seq       uint64 // 产生事件的命令序号
+	Timestamp int64  // 命令时间(纳秒)
+	Phase     *PhaseChange
+	Auction   *orderbook.AuctionQuote // 集合竞价参考报价，竞价撮合时为实际成交价及成交量
+	Trades    []types.Trade           // 集合竞价撮合产生的成交
+	Depth     *orderbook.DepthUpdate  // 命令执行后变化的价格层级
+	Orders    []orderbook.OrderEvent  // 命令执行产生的逐笔委托事件
+}
+
+// MarketSubscription 行情订阅，积压超过缓冲区时被断开，订阅方需重新订阅
+type MarketSubscription struct {
+	hub     *marketHub
+	symbols map[string]struct{} // 订阅的交易对，为空表示全部
+	kinds   eventMask           // 接收的事件类型
+	events  chan *MarketEvent
+
+	closeOnce sync.Once
+	done      chan struct{}
+	err       error // 断开原因，done关闭后可读
+}
+
+// marketHub 行情订阅方
+type marketHub struct {
+	mu   sync.Mutex
+	subs map[*MarketSubscription]struct{}
+}
+
+func newMarketHub() *marketHub {
+	return &marketHub{subs: make(map[*MarketSubscription]struct{})}
+}
+
+// publish 将事件推送给订阅了该交易对的订阅方，不阻塞worker
+func (h *marketHub) publish(event *MarketEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for s := range h.subs {
+		if !s.wants(event) {
+			continue
+		}
+
+		select {
+		case s.events <- event:
+		default:
+			logx.Errorf("Market data subscriber fell behind at %s seq %d, disconnecting", event.Symbol, event.Seq)
+			h.removeLocked(s, ErrMarketDataOverflow)
+		}
+	}
+}
+
+func (h *marketHub) remove(s *MarketSubscription, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeLocked(s, err)
+}
+
+func (h *marketHub) removeLocked(s *MarketSubscription, err error) {
+	if _, ok := h.subs[s]; !ok {
+		return
+	}
+	delete(h.subs, s)
+	s.closeOnce.Do(func() {
+		s.err = err
+		close(s.done)
+	})
+}
+
+func (s *MarketSubscription) wants(event *MarketEvent) bool {
+	if !s.kinds.has(event.Type) {
+		return false
+	}
+	if len(s.symbols) == 0 {
+		return true
+	}
+	_, ok := s.symbols[event.Symbol]
+	return ok
+}
+
+// Close 取消订阅，Stream随后返回nil
+func (s *MarketSubscription) Close() {
+	s.hub.remove(s, nil)
+}
+
+// Stream 按序发送事件直到ctx结束或订阅被断开
+func (s *MarketSubscription) Stream(ctx context.Context, send func(event *MarketEvent) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event := <-s.events:
+			if err := send(event); err != nil {
+				return err
+			}
+		case <-s.done:
+			return s.err
+		}
+	}
+}
+
+// SubscribeMarketData 订阅交易对的行情事件，symbols为空时订阅全部交易对，depth为true时同时接收增量深度
+func (e *MatchingEngine) SubscribeMarketData(symbols []string, depth bool) *MarketSubscription {
+	kinds := maskOf(MarketEventPhase, MarketEventIndicative, MarketEventUncross)
+	if depth {
+		kinds |= maskOf(MarketEventDepth)
+	}
+	return e.subscribe(symbols, kinds)
+}
+
+// SubscribeOrderFeed 订阅交易对的逐笔委托事件，symbols为空时订阅全部交易对
+func (e *MatchingEngine) SubscribeOrderFeed(symbols []string) *MarketSubscription {
+	return e.subscribe(symbols, maskOf(MarketEventOrders))
+}
+
+func (e *MatchingEngine) subscribe(symbols []string, kinds eventMask) *MarketSubscription {
+	s := &MarketSubscription{
+		hub:     e.marketData,
+		symbols: make(map[string]struct{}, len(symbols)),
+		kinds:   kinds,
+		events:  make(chan *MarketEvent, marketDataBuffer),
+		done:    make(chan struct{}),
+	}
+	for _, symbol := range symbols {
+		s.symbols[symbol] = struct{}{}
+	}
+
+	e.marketData.mu.Lock()
+	e.marketData.subs[s] = struct{}{}
+	e.marketData.mu.Unlock()
+	return s
+}
@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/tsfdsong/tradeengin/app/matching/internal/journal"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/orderbook"
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var ErrInvalidCancelFilter = errors.New("invalid mass cancel filter")
+
+// MassCancelResult 批量撤单结果
+type MassCancelResult struct {
+	OrderIDs      []uint64 // 被撤销的挂单及未触发的止损单，同一交易对内按订单ID升序
+	FailedSymbols []string // 未指定交易对时无法执行撤单的交易对，如正在下架
+}
+
+// MassCancel 按方向/账户/ClientID批量撤单，每个交易对的撤单作为一条命令写入日志并按序执行
+// symbol为空时在全部交易对上执行，此时必须指定账户或ClientID
+func (e *MatchingEngine) MassCancel(symbol string, filter orderbook.CancelFilter) (*MassCancelResult, error) {
+	if filter.Side != 0 && filter.Side != types.SideBuy && filter.Side != types.SideSell {
+		return nil, ErrInvalidCancelFilter
+	}
+
+	if symbol != "" {
+		orderIDs, err := e.massCancel(symbol, filter)
+		if err != nil {
+			return nil, err
+		}
+		return &MassCancelResult{OrderIDs: orderIDs}, nil
+	}
+
+	if filter.Account == "" && filter.ClientID == "" {
+		return nil, ErrInvalidCancelFilter
+	}
+
+	symbols := e.GetSymbols()
+	slices.Sort(symbols)
+
+	result := &MassCancelResult{}
+	for _, symbol := range symbols {
+		orderIDs, err := e.massCancel(symbol, filter)
+		if errors.Is(err, ErrNotPrimary) {
+			return nil, err
+		}
+		if err != nil {
+			logx.Errorf("Mass cancel on symbol %s failed: %v", symbol, err)
+			result.FailedSymbols = append(result.FailedSymbols, symbol)
+			continue
+		}
+		result.OrderIDs = append(result.OrderIDs, orderIDs...)
+	}
+	return result, nil
+}
+
+func (e *MatchingEngine) massCancel(symbol string, filter orderbook.CancelFilter) ([]uint64, error) {
+	reply, err := e.submitCommand(&journal.Command{
+		Type:     journal.CommandMassCancel,
+		Symbol:   symbol,
+		Side:     filter.Side,
+		Account:  filter.Account,
+		ClientID: filter.ClientID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.orderIDs, nil
+}
+
+// applyMassCancel 从订单簿批量撤单并更新订单状态
+func (e *MatchingEngine) applyMassCancel(orderBook *orderbook.HybridOrderBook, jc *journal.Command) *commandReply {
+	orderIDs := orderBook.MassCancel(orderbook.CancelFilter{
+		Side:     jc.Side,
+		Account:  jc.Account,
+		ClientID: jc.ClientID,
+	})
+
+	e.stateMu.Lock()
+	for _, orderID := range orderIDs {
+		e.markOrderCancelled(orderID)
+	}
+	e.stateMu.Unlock()
+
+	logx.Infof("Mass cancelled %d orders on symbol %s, side: %d, account: %q, client: %q, seq: %d",
+		len(orderIDs), jc.Symbol, jc.Side, jc.Account, jc.ClientID, jc.Seq)
+	return &commandReply{orderIDs: orderIDs}
+}
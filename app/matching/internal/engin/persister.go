@@ -15,7 +15,7 @@ import (
 // RedisPersister Redis持久化服务 - 使用go-zero的Redis客户端
 type RedisPersister struct {
 	client     *redis.Redis
-	orderBooks map[string]*orderbook.HybridOrderBook
+	orderBooks func() map[string]*orderbook.HybridOrderBook // 当前全部订单簿
 	interval   time.Duration
 	keyPrefix  string
 	enabled    bool
@@ -24,7 +24,7 @@ type RedisPersister struct {
 // NewRedisPersister 创建Redis持久化服务
 func NewRedisPersister(
 	client *redis.Redis,
-	orderBooks map[string]*orderbook.HybridOrderBook,
+	orderBooks func() map[string]*orderbook.HybridOrderBook,
 	interval string,
 	enabled bool,
 ) *RedisPersister {
@@ -68,7 +68,7 @@ func (p *RedisPersister) Run(ctx context.Context) {
 
 // persistAll 持久化所有订单簿
 func (p *RedisPersister) persistAll() {
-	for symbol, orderBook := range p.orderBooks {
+	for symbol, orderBook := range p.orderBooks() {
 		if err := p.persistOrderBook(symbol, orderBook); err != nil {
 			logx.Errorf("Failed to persist orderbook for %s: %v", symbol, err)
 		}
@@ -181,7 +181,8 @@ func (p *RedisPersister) SaveTrade(trade *types.Trade) error {
 		return nil
 	}
 
-	key := fmt.Sprintf("matching:trade:%d", trade.TradeID)
+	// 成交ID在交易对内唯一
+	key := fmt.Sprintf("matching:trade:%s:%d", trade.Symbol, trade.TradeID)
 	data, err := json.Marshal(trade)
 	if err != nil {
 		return err
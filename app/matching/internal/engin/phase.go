@@ -0,0 +1,191 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/tsfdsong/tradeengin/app/matching/internal/journal"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/orderbook"
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const maxPhaseHistory = 1024 // 保留的交易阶段切换记录数
+
+var (
+	ErrSymbolCancelOnly = errors.New("symbol only accepts cancels")
+	ErrSymbolPostOnly   = errors.New("symbol only accepts post-only limit orders")
+	ErrInvalidPhase     = errors.New("invalid trading phase")
+	ErrSymbolAuction    = errors.New("symbol only accepts GTC/GTD limit orders during auction")
+	ErrAmendWouldTake   = orderbook.ErrAmendWouldTake
+	ErrPhaseRejected    = orderbook.ErrPhaseRejected
+)
+
+// PhaseChange 交易阶段切换记录，由交易对所属worker按序执行后记录并发布
+type PhaseChange struct {
+	Symbol    string
+	From      types.TradingPhase
+	To        types.TradingPhase
+	Seq       uint64 // 切换命令序号
+	Timestamp int64  // 命令时间(纳秒)
+	Operator  string // 操作人，不发布给行情订阅方
+	Reason    string
+}
+
+// phaseHistory 最近的交易阶段切换记录
+type phaseHistory struct {
+	mu      sync.Mutex
+	changes []PhaseChange
+}
+
+func (h *phaseHistory) add(change PhaseChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.changes) >= maxPhaseHistory {
+		h.changes = append(h.changes[:0], h.changes[1:]...)
+	}
+	h.changes = append(h.changes, change)
+}
+
+// commandPhase 暂停/恢复/切换交易阶段命令的目标阶段
+func commandPhase(jc *journal.Command) types.TradingPhase {
+	switch jc.Type {
+	case journal.CommandHalt:
+		return types.PhaseHalted
+	case journal.CommandResume:
+		return types.PhaseContinuous
+	default:
+		return jc.Phase
+	}
+}
+
+// checkPhase 按交易对当前的交易阶段校验命令(调用方需持有commandMu)
+// 撤单及批量撤单在任何交易阶段都接受，保证风控及断线撤单在暂停期间也能执行；过期清理由引擎发起，同样不受限制
+func checkPhase(spec *types.InstrumentSpec, cmd *command) error {
+	phase := spec.Phase()
+	switch cmd.Type {
+	case journal.CommandNewOrder:
+		if reason := phase.CheckOrder(cmd.Order); reason != "" {
+			return instrumentRejectErrors[reason]
+		}
+	case journal.CommandAmend:
+		if !phase.AcceptsAmend() {
+			return phaseError(phase)
+		}
+	}
+	return nil
+}
+
+// phaseError 交易阶段拒绝操作时返回的错误
+func phaseError(phase types.TradingPhase) error {
+	if phase == types.PhaseCancelOnly {
+		return ErrSymbolCancelOnly
+	}
+	return ErrSymbolHalted
+}
+
+// applyPhase 按执行顺序切换订单簿的交易阶段，记录切换并发布给行情订阅方
+// 退出集合竞价时先按竞价价格撮合累积的订单，保证之后的订单簿不再交叉
+func (e *MatchingEngine) applyPhase(orderBook *orderbook.HybridOrderBook, jc *journal.Command) *commandReply {
+	if orderBook.Phase() == types.PhaseAuction && commandPhase(jc) != types.PhaseAuction {
+		e.applyUncross(orderBook, jc)
+	}
+
+	change := PhaseChange{
+		Symbol:    jc.Symbol,
+		From:      orderBook.Phase(),
+		To:        commandPhase(jc),
+		Seq:       jc.Seq,
+		Timestamp: jc.Timestamp,
+		Operator:  jc.Operator,
+		Reason:    jc.Reason,
+	}
+	orderBook.SetPhase(change.To)
+
+	e.phases.add(change)
+	e.marketData.publish(&MarketEvent{
+		Type:      MarketEventPhase,
+		Symbol:    jc.Symbol,
+		Seq:       jc.Seq,
+		Timestamp: jc.Timestamp,
+		Phase:     &change,
+	})
+	return &commandReply{}
+}
+
+// applyUncross 集合竞价撮合，更新买卖双方的订单状态并推送竞价结果
+func (e *MatchingEngine) applyUncross(orderBook *orderbook.HybridOrderBook, jc *journal.Command) {
+	result := orderBook.UncrossAt(jc.Timestamp)
+
+	trades := make([]types.Trade, 0, len(result.Trades))
+	e.stateMu.Lock()
+	for _, trade := range result.Trades {
+		e.updateOrderState(trade.TakerOrderID, trade.Quantity)
+		e.updateOrderState(trade.MakerOrderID, trade.Quantity)
+		trades = append(trades, *trade)
+		types.PutTradeToPool(trade)
+	}
+	for _, orderID := range slices.Concat(result.Expired, result.Cancelled) {
+		e.markOrderCancelled(orderID)
+	}
+	e.stateMu.Unlock()
+
+	logx.Infof("Symbol %s uncrossed at price %d, volume %d, imbalance %d, trades: %d, self trades prevented: %d, seq: %d",
+		jc.Symbol, result.Price, result.Volume, result.Imbalance, len(trades), len(result.SelfTrades), jc.Seq)
+	for _, event := range result.SelfTrades {
+		logx.Infof("Auction self trade prevented on %s: taker %d, maker %d, client %s, mode %d, cancelled %d/%d",
+			event.Symbol, event.TakerOrderID, event.MakerOrderID, event.ClientID, event.Mode, event.TakerCancelledQty, event.MakerCancelledQty)
+	}
+
+	e.marketData.publish(&MarketEvent{
+		Type:      MarketEventUncross,
+		Symbol:    jc.Symbol,
+		Seq:       jc.Seq,
+		Timestamp: jc.Timestamp,
+		Auction:   &result.AuctionQuote,
+		Trades:    trades,
+	})
+}
+
+// SetTradingPhase 切换交易对的交易阶段，切换命令写入日志并复制给follower，operator及reason随命令记录用于审计
+// 切换立即对之后接收的命令生效，订单簿在执行完此前的命令后切换，由集合竞价切换到其它阶段时执行竞价撮合
+func (e *MatchingEngine) SetTradingPhase(symbol string, phase types.TradingPhase, operator, reason string) error {
+	switch phase {
+	case types.PhaseContinuous, types.PhasePostOnly, types.PhaseCancelOnly, types.PhaseHalted, types.PhaseAuction:
+	default:
+		return fmt.Errorf("%w: %d", ErrInvalidPhase, phase)
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if !e.started {
+		return ErrEngineNotStarted
+	}
+
+	jc := &journal.Command{
+		Type:     journal.CommandSetPhase,
+		Symbol:   symbol,
+		Phase:    phase,
+		Operator: operator,
+		Reason:   reason,
+	}
+	_, err := e.submitCommand(jc)
+	return err
+}
+
+// PhaseChanges 最近的交易阶段切换记录，symbol为空时返回全部交易对，按执行顺序排列
+func (e *MatchingEngine) PhaseChanges(symbol string) []PhaseChange {
+	e.phases.mu.Lock()
+	defer e.phases.mu.Unlock()
+
+	changes := make([]PhaseChange, 0, len(e.phases.changes))
+	for _, change := range e.phases.changes {
+		if symbol == "" || change.Symbol == symbol {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+// RedisSnapshotStore Redis快照存储
+// 快照内容存于 matching:snapshot:{symbol}:{name}，有序集合 matching:snapshots:{symbol} 按序号索引快照名称
+// 集合 matching:snapshot-symbols 记录存有快照的交易对，下架交易对的最终快照归档于 matching:archive:{symbol}:{name}
+// 有序集合 matching:archives:{symbol} 按序号索引归档快照名称
+type RedisSnapshotStore struct {
+	client    *redis.Redis
+	policy    RetentionPolicy
+	keyPrefix string
+}
+
+// NewRedisSnapshotStore 创建Redis快照存储
+func NewRedisSnapshotStore(client *redis.Redis, policy RetentionPolicy) *RedisSnapshotStore {
+	return &RedisSnapshotStore{
+		client:    client,
+		policy:    policy,
+		keyPrefix: "matching:",
+	}
+}
+
+// Save 先写快照内容再加入索引，读取方只会看到已完整写入的快照
+func (s *RedisSnapshotStore) Save(snapshot *Snapshot) error {
+	name := snapshotName(snapshot.Seq, snapshot.Timestamp)
+	if err := s.client.Set(s.dataKey(snapshot.Symbol, name), string(encodeSnapshotRecord(snapshot))); err != nil {
+		return fmt.Errorf("redis set: %w", err)
+	}
+	if _, err := s.client.Zadd(s.indexKey(snapshot.Symbol), int64(snapshot.Seq), name); err != nil {
+		return fmt.Errorf("redis zadd: %w", err)
+	}
+	if _, err := s.client.Sadd(s.symbolsKey(), snapshot.Symbol); err != nil {
+		return fmt.Errorf("redis sadd: %w", err)
+	}
+
+	s.prune(snapshot.Symbol)
+	return nil
+}
+
+// Latest 按序号从新到旧读取快照，跳过缺失或校验失败的快照
+func (s *RedisSnapshotStore) Latest(symbol string) (*Snapshot, error) {
+	return s.newest(symbol, s.indexKey(symbol), s.dataKey)
+}
+
+// Archived 按序号从新到旧读取归档的最终快照，跳过缺失或校验失败的快照
+func (s *RedisSnapshotStore) Archived(symbol string) (*Snapshot, error) {
+	return s.newest(symbol, s.archiveIndexKey(symbol), s.archiveKey)
+}
+
+// newest 按索引读取交易对序号最大且校验通过的快照，dataKey生成快照内容的key
+func (s *RedisSnapshotStore) newest(symbol, indexKey string, dataKey func(symbol, name string) string) (*Snapshot, error) {
+	names, err := s.client.Zrevrange(indexKey, 0, -1)
+	if err != nil {
+		return nil, fmt.Errorf("redis zrevrange: %w", err)
+	}
+
+	for _, name := range names {
+		ref, ok := parseSnapshotName(name)
+		if !ok {
+			continue
+		}
+
+		record, err := s.client.Get(dataKey(symbol, name))
+		if err != nil {
+			return nil, fmt.Errorf("redis get: %w", err)
+		}
+		if record == "" {
+			logx.Errorf("Snapshot %s of %s is indexed but missing", name, symbol)
+			continue
+		}
+
+		snapshot, err := decodeSnapshotRecord([]byte(record))
+		if err == nil && (snapshot.Symbol != symbol || snapshot.Seq != ref.seq) {
+			err = fmt.Errorf("%w: snapshot of %s seq %d", ErrSnapshotChecksum, snapshot.Symbol, snapshot.Seq)
+		}
+		if err != nil {
+			logx.Errorf("Skip invalid snapshot %s of %s: %v", name, symbol, err)
+			continue
+		}
+		return snapshot, nil
+	}
+
+	return nil, ErrSnapshotNotFound
+}
+
+// Symbols 列出存有快照的交易对
+func (s *RedisSnapshotStore) Symbols() ([]string, error) {
+	symbols, err := s.client.Smembers(s.symbolsKey())
+	if err != nil {
+		return nil, fmt.Errorf("redis smembers: %w", err)
+	}
+	return symbols, nil
+}
+
+// Archive 写入最终快照的归档后删除交易对的快照及索引
+func (s *RedisSnapshotStore) Archive(snapshot *Snapshot) error {
+	name := snapshotName(snapshot.Seq, snapshot.Timestamp)
+	if err := s.client.Set(s.archiveKey(snapshot.Symbol, name), string(encodeSnapshotRecord(snapshot))); err != nil {
+		return fmt.Errorf("redis set: %w", err)
+	}
+	if _, err := s.client.Zadd(s.archiveIndexKey(snapshot.Symbol), int64(snapshot.Seq), name); err != nil {
+		return fmt.Errorf("redis zadd: %w", err)
+	}
+
+	// 先移出交易对集合，删除中途失败时重启也不会再恢复该交易对
+	if _, err := s.client.Srem(s.symbolsKey(), snapshot.Symbol); err != nil {
+		return fmt.Errorf("redis srem: %w", err)
+	}
+	names, err := s.client.Zrange(s.indexKey(snapshot.Symbol), 0, -1)
+	if err != nil {
+		return fmt.Errorf("redis zrange: %w", err)
+	}
+	keys := []string{s.indexKey(snapshot.Symbol)}
+	for _, name := range names {
+		keys = append(keys, s.dataKey(snapshot.Symbol, name))
+	}
+	if _, err := s.client.Del(keys...); err != nil {
+		return fmt.Errorf("redis del: %w", err)
+	}
+	return nil
+}
+
+// prune 按保留策略删除旧快照，先移出索引再删除内容，没有校验通过的快照时不做清理
+func (s *RedisSnapshotStore) prune(symbol string) {
+	newest, err := newestValid(s, symbol)
+	if err != nil {
+		logx.Errorf("Skip pruning snapshots of %s: %v", symbol, err)
+		return
+	}
+	names, err := s.client.Zrevrange(s.indexKey(symbol), 0, -1)
+	if err != nil {
+		logx.Errorf("List snapshots of %s failed: %v", symbol, err)
+		return
+	}
+
+	refs := make([]snapshotRef, 0, len(names))
+	for _, name := range names {
+		if ref, ok := parseSnapshotName(name); ok {
+			refs = append(refs, ref)
+		}
+	}
+	sortSnapshotRefs(refs)
+
+	for _, ref := range s.policy.expired(refs, newest, time.Now()) {
+		name := snapshotName(ref.seq, ref.timestamp)
+		if _, err := s.client.Zrem(s.indexKey(symbol), name); err != nil {
+			logx.Errorf("Remove snapshot %s of %s from index failed: %v", name, symbol, err)
+			continue
+		}
+		if _, err := s.client.Del(s.dataKey(symbol, name)); err != nil {
+			logx.Errorf("Delete snapshot %s of %s failed: %v", name, symbol, err)
+		}
+	}
+}
+
+func (s *RedisSnapshotStore) indexKey(symbol string) string {
+	return s.keyPrefix + "snapshots:" + symbol
+}
+
+func (s *RedisSnapshotStore) symbolsKey() string {
+	return s.keyPrefix + "snapshot-symbols"
+}
+
+func (s *RedisSnapshotStore) archiveKey(symbol, name string) string {
+	return s.keyPrefix + "archive:" + symbol + ":" + name
+}
+
+func (s *RedisSnapshotStore) archiveIndexKey(symbol string) string {
+	return s.keyPrefix + "archives:" + symbol
+}
+
+func (s *RedisSnapshotStore) dataKey(symbol, name string) string {
+	return s.keyPrefix + "snapshot:" + symbol + ":" + name
+}
@@ -0,0 +1,473 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/tsfdsong/tradeengin/app/matching/internal/journal"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/monitor"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	RolePrimary  = "primary"
+	RoleFollower = "follower"
+
+	heartbeatInterval = time.Second // primary空闲时向follower发送心跳的间隔
+)
+
+var (
+	ErrNotPrimary         = errors.New("matching engine is not primary")
+	ErrNotFollower        = errors.New("matching engine is not a follower")
+	ErrReplicationTimeout = errors.New("timed out waiting for follower acknowledgement")
+	ErrReplicaOverflow    = errors.New("follower fell too far behind")
+	ErrReplicaAhead       = errors.New("follower is ahead of primary")
+	ErrReplicationGap     = errors.New("replicated command sequence gap")
+
+	errCatchUpDone = errors.New("catch-up done")
+)
+
+// ReplicationStatus 复制状态
+type ReplicationStatus struct {
+	Role      string
+	LastSeq   uint64           // 本节点最后一条命令序号
+	Followers []FollowerStatus // primary: 已连接的follower
+
+	Connected  bool          // follower: 是否已连接primary
+	PrimarySeq uint64        // follower: 已知的primary最后序号
+	Lag        uint64        // follower: 落后primary的命令数
+	LagTime    time.Duration // follower: 最近一条命令从primary接收到本地应用的延迟
+}
+
+// FollowerStatus primary端看到的follower状态
+type FollowerStatus struct {
+	ID          string
+	AckedSeq    uint64
+	Lag         uint64
+	ConnectedAt int64
+}
+
+// Replica primary端的一个follower订阅，先从命令日志追赶，再接收实时命令
+type Replica struct {
+	id          string
+	hub         *replicaHub
+	journal     *journal.Journal
+	fromSeq     uint64 // follower已有的最后序号
+	catchUpTo   uint64 // 订阅时primary的最后序号，此后的命令实时推送
+	entries     chan replicaEntry
+	ackedSeq    atomic.Uint64
+	connectedAt int64
+
+	closeOnce sync.Once
+	done      chan struct{}
+	err       error // 断开原因，done关闭后可读
+}
+
+// replicaEntry 推送给follower的命令，入队时即编码，之后撮合修改订单不影响复制内容
+type replicaEntry struct {
+	seq  uint64
+	data []byte
+}
+
+// replicaHub primary端已订阅的follower
+type replicaHub struct {
+	mu       sync.Mutex
+	replicas map[*Replica]struct{}
+	acked    chan struct{} // 有follower确认新序号时关闭并替换，唤醒等待确认的请求
+}
+
+// followerState follower端的复制状态
+type followerState struct {
+	mu         sync.Mutex
+	connected  bool
+	primarySeq uint64
+	lagTime    time.Duration
+}
+
+func newReplicaHub() *replicaHub {
+	return &replicaHub{
+		replicas: make(map[*Replica]struct{}),
+		acked:    make(chan struct{}),
+	}
+}
+
+// publish 将已写入日志的命令推送给所有follower(调用方需持有commandMu)
+// follower积压超过缓冲区时断开，由其重连后从日志追赶，不阻塞撮合
+func (h *replicaHub) publish(cmd *journal.Command) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.replicas) == 0 {
+		return
+	}
+
+	data, err := cmd.Marshal()
+	if err != nil {
+		logx.Errorf("Marshal command %d for replication failed: %v", cmd.Seq, err)
+	}
+
+	for r := range h.replicas {
+		if err != nil {
+			h.removeLocked(r, err)
+			continue
+		}
+
+		select {
+		case r.entries <- replicaEntry{seq: cmd.Seq, data: data}:
+		default:
+			logx.Errorf("Follower %s fell behind at seq %d, disconnecting", r.id, cmd.Seq)
+			h.removeLocked(r, ErrReplicaOverflow)
+		}
+	}
+}
+
+func (h *replicaHub) add(r *Replica) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.replicas[r] = struct{}{}
+	monitor.SetReplicationFollowers(len(h.replicas))
+}
+
+func (h *replicaHub) remove(r *Replica, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeLocked(r, err)
+}
+
+func (h *replicaHub) removeLocked(r *Replica, err error) {
+	if _, ok := h.replicas[r]; !ok {
+		return
+	}
+	delete(h.replicas, r)
+	r.Close(err)
+	monitor.SetReplicationFollowers(len(h.replicas))
+}
+
+// notifyAcked 唤醒等待follower确认的请求
+func (h *replicaHub) notifyAcked() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	close(h.acked)
+	h.acked = make(chan struct{})
+}
+
+// maxAcked 所有follower中已确认的最大序号，以及下次确认时会被关闭的通道
+func (h *replicaHub) maxAcked() (uint64, <-chan struct{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var acked uint64
+	for r := range h.replicas {
+		acked = max(acked, r.ackedSeq.Load())
+	}
+	return acked, h.acked
+}
+
+func (h *replicaHub) status(lastSeq uint64) []FollowerStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	followers := make([]FollowerStatus, 0, len(h.replicas))
+	for r := range h.replicas {
+		acked := r.ackedSeq.Load()
+		followers = append(followers, FollowerStatus{
+			ID:          r.id,
+			AckedSeq:    acked,
+			Lag:         lastSeq - min(acked, lastSeq),
+			ConnectedAt: r.connectedAt,
+		})
+	}
+	return followers
+}
+
+// Ack follower确认已写入序号seq及之前的命令
+func (r *Replica) Ack(seq uint64) {
+	for {
+		acked := r.ackedSeq.Load()
+		if seq <= acked {
+			return
+		}
+		if r.ackedSeq.CompareAndSwap(acked, seq) {
+			r.hub.notifyAcked()
+			return
+		}
+	}
+}
+
+// Close 断开follower，Stream随后返回err
+func (r *Replica) Close(err error) {
+	r.closeOnce.Do(func() {
+		r.err = err
+		close(r.done)
+	})
+}
+
+// Stream 按序向follower发送命令直到ctx结束或follower被断开，send的seq为0表示心跳
+func (r *Replica) Stream(ctx context.Context, send func(seq uint64, data []byte) error) error {
+	if r.catchUpTo > r.fromSeq {
+		err := r.journal.Replay(r.fromSeq, func(jc *journal.Command) error {
+			if jc.Seq > r.catchUpTo {
+				return errCatchUpDone
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-r.done:
+				return r.err
+			default:
+			}
+
+			data, err := jc.Marshal()
+			if err != nil {
+				return err
+			}
+			return send(jc.Seq, data)
+		})
+		if err != nil && !errors.Is(err, errCatchUpDone) {
+			return err
+		}
+		logx.Infof("Follower %s caught up from seq %d to %d", r.id, r.fromSeq, r.catchUpTo)
+	}
+
+	// 订阅后先发送一次心跳，follower据此得知primary的最后序号
+	if err := send(0, nil); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-r.done:
+			return r.err
+		case entry := <-r.entries:
+			if err := send(entry.seq, entry.data); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := send(0, nil); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Subscribe follower订阅序号fromSeq之后的命令
+func (e *MatchingEngine) Subscribe(followerID string, fromSeq uint64) (*Replica, error) {
+	e.commandMu.Lock()
+	defer e.commandMu.Unlock()
+
+	if e.follower.Load() {
+		return nil, ErrNotPrimary
+	}
+	if fromSeq > e.lastSeq {
+		return nil, fmt.Errorf("%w: follower at seq %d, primary at %d", ErrReplicaAhead, fromSeq, e.lastSeq)
+	}
+	if fromSeq < e.lastSeq && e.journal == nil {
+		return nil, fmt.Errorf("%w: journal disabled, cannot catch up from seq %d", ErrReplicationGap, fromSeq)
+	}
+
+	r := &Replica{
+		id:          followerID,
+		hub:         e.replicas,
+		journal:     e.journal,
+		fromSeq:     fromSeq,
+		catchUpTo:   e.lastSeq,
+		entries:     make(chan replicaEntry, e.replicaBuffer),
+		connectedAt: time.Now().UnixNano(),
+		done:        make(chan struct{}),
+	}
+	r.ackedSeq.Store(fromSeq)
+
+	// 持有commandMu登记，此后写入日志的命令都会推送给该follower
+	e.replicas.add(r)
+
+	logx.Infof("Follower %s subscribed from seq %d, primary at %d", followerID, fromSeq, e.lastSeq)
+	return r, nil
+}
+
+// Unsubscribe 移除follower订阅
+func (e *MatchingEngine) Unsubscribe(r *Replica) {
+	e.replicas.remove(r, nil)
+	logx.Infof("Follower %s unsubscribed, acked seq %d", r.id, r.ackedSeq.Load())
+}
+
+// waitReplicated 开启RequireAck时等待至少一个follower确认序号seq
+func (e *MatchingEngine) waitReplicated(seq uint64) error {
+	if !e.requireAck {
+		return nil
+	}
+
+	timer := time.NewTimer(e.ackTimeout)
+	defer timer.Stop()
+
+	for {
+		acked, wait := e.replicas.maxAcked()
+		if acked >= seq {
+			return nil
+		}
+
+		select {
+		case <-wait:
+		case <-timer.C:
+			return ErrReplicationTimeout
+		}
+	}
+}
+
+// ApplyReplicated follower按序应用primary复制的命令: 写入本地日志后放入交易对输入队列，由worker照常执行
+// 序号不大于本地最后序号的命令已应用过，直接忽略
+func (e *MatchingEngine) ApplyReplicated(jc *journal.Command, primarySeq uint64) error {
+	e.commandMu.Lock()
+	// 队列满时等待worker消费，follower上只有复制及快照命令入队
+	for {
+		m, exists := e.market(jc.Symbol)
+		if !exists || !m.queue.IsFull() {
+			break
+		}
+		e.commandMu.Unlock()
+		time.Sleep(100 * time.Microsecond)
+		e.commandMu.Lock()
+	}
+	defer e.commandMu.Unlock()
+
+	if !e.follower.Load() {
+		return ErrNotFollower
+	}
+	if jc.Seq <= e.lastSeq {
+		return nil
+	}
+	if jc.Seq != e.lastSeq+1 {
+		return fmt.Errorf("%w: expect %d, got %d", ErrReplicationGap, e.lastSeq+1, jc.Seq)
+	}
+
+	if e.journal != nil {
+		if err := e.journal.Append(jc); err != nil {
+			logx.Errorf("Append replicated command %d to journal failed: %v", jc.Seq, err)
+			return ErrJournalWrite
+		}
+	}
+	e.lastSeq = jc.Seq
+	e.observePrimary(primarySeq, time.Since(time.Unix(0, jc.Timestamp)))
+
+	if jc.Type.IsSymbolCommand() {
+		if err := e.applySymbolCommand(jc); err != nil {
+			logx.Errorf("Skip replicated command %d of symbol %s: %v", jc.Seq, jc.Symbol, err)
+			return nil
+		}
+	}
+
+	m, exists := e.market(jc.Symbol)
+	if !exists {
+		logx.Errorf("Skip replicated command %d of unknown symbol %s", jc.Seq, jc.Symbol)
+		return nil
+	}
+
+	if jc.Type == journal.CommandNewOrder {
+		e.trackOrder(jc.Order)
+	}
+	m.queue.Push(unsafe.Pointer(e.bindCommand(jc, nil)))
+	return nil
+}
+
+// ObservePrimary follower收到primary心跳时更新已知的primary最后序号
+func (e *MatchingEngine) ObservePrimary(primarySeq uint64) {
+	e.commandMu.Lock()
+	defer e.commandMu.Unlock()
+
+	e.observePrimary(primarySeq, -1)
+}
+
+// observePrimary 更新follower复制状态，lagTime小于0表示不更新延迟(调用方需持有commandMu)
+func (e *MatchingEngine) observePrimary(primarySeq uint64, lagTime time.Duration) {
+	e.replication.mu.Lock()
+	defer e.replication.mu.Unlock()
+
+	e.replication.connected = true
+	e.replication.primarySeq = max(e.replication.primarySeq, primarySeq)
+	lag := e.replication.primarySeq - min(e.lastSeq, e.replication.primarySeq)
+	if lagTime >= 0 {
+		e.replication.lagTime = lagTime
+	}
+	if lag == 0 && lagTime < 0 {
+		// 已追上primary且没有新命令
+		e.replication.lagTime = 0
+	}
+	monitor.SetReplicationLag(lag, e.replication.lagTime)
+}
+
+// MarkPrimaryDisconnected follower与primary断开连接
+func (e *MatchingEngine) MarkPrimaryDisconnected() {
+	e.replication.mu.Lock()
+	defer e.replication.mu.Unlock()
+
+	e.replication.connected = false
+}
+
+// Promote follower接管为primary，之后不再应用复制命令并开始接收客户端请求
+// 调用方需确保原primary已停止服务，否则两个primary会各自接收命令
+func (e *MatchingEngine) Promote() error {
+	e.commandMu.Lock()
+	defer e.commandMu.Unlock()
+
+	if !e.follower.Load() {
+		return ErrNotFollower
+	}
+	e.follower.Store(false)
+	e.MarkPrimaryDisconnected()
+	e.scheduleExpiries()
+
+	logx.Infof("Matching engine promoted to primary at seq %d", e.lastSeq)
+	return nil
+}
+
+// Role 本节点的复制角色
+func (e *MatchingEngine) Role() string {
+	if e.follower.Load() {
+		return RoleFollower
+	}
+	return RolePrimary
+}
+
+// LastSeq 最后一条已接收命令的序号
+func (e *MatchingEngine) LastSeq() uint64 {
+	e.commandMu.Lock()
+	defer e.commandMu.Unlock()
+
+	return e.lastSeq
+}
+
+// ReplicationStatus 获取复制状态
+func (e *MatchingEngine) ReplicationStatus() ReplicationStatus {
+	e.commandMu.Lock()
+	status := ReplicationStatus{
+		Role:    e.Role(),
+		LastSeq: e.lastSeq,
+	}
+	e.commandMu.Unlock()
+
+	if status.Role == RolePrimary {
+		status.Followers = e.replicas.status(status.LastSeq)
+		return status
+	}
+
+	e.replication.mu.Lock()
+	defer e.replication.mu.Unlock()
+
+	status.Connected = e.replication.connected
+	status.PrimarySeq = e.replication.primarySeq
+	status.Lag = status.PrimarySeq - min(status.LastSeq, status.PrimarySeq)
+	status.LagTime = e.replication.lagTime
+	return status
+}
@@ -1,9 +1,18 @@
 package engine
 
 import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/tsfdsong/tradeengin/app/matching/internal/config"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/journal"
 	"github.com/tsfdsong/tradeengin/app/matching/internal/orderbook"
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
 )
 
 func TestRedisPersister(t *testing.T) {
@@ -21,3 +30,1022 @@ func TestRedisPersister(t *testing.T) {
 		t.Error("Expected snapshot to be retrieved")
 	}
 }
+
+func TestSymbolAssigner(t *testing.T) {
+	assigner := NewSymbolAssigner(8, map[string]int{"BTCUSDT": 3, "BADUSDT": 99})
+
+	if id := assigner.Assign("BTCUSDT"); id != 3 {
+		t.Errorf("Expected configured worker 3, got %d", id)
+	}
+
+	// 非法的显式分配回退到一致性哈希
+	if id, want := assigner.Assign("BADUSDT"), NewSymbolAssigner(8, nil).Assign("BADUSDT"); id != want {
+		t.Errorf("Expected invalid override to fall back to worker %d, got %d", want, id)
+	}
+
+	// 同一交易对总是分配到同一worker
+	for _, symbol := range []string{"ETHUSDT", "BNBUSDT"} {
+		id := assigner.Assign(symbol)
+		if again := NewSymbolAssigner(8, nil).Assign(symbol); again != id {
+			t.Errorf("Assignment of %s is not deterministic: %d vs %d", symbol, id, again)
+		}
+	}
+
+	// 新增交易对不改变已有交易对的归属
+	before := assigner.AssignAll([]string{"ETHUSDT", "BNBUSDT"})
+	after := assigner.AssignAll([]string{"ETHUSDT", "BNBUSDT", "SOLUSDT", "XRPUSDT"})
+	for id, symbols := range before {
+		for _, symbol := range symbols {
+			if !slices.Contains(after[id], symbol) {
+				t.Errorf("Symbol %s moved away from worker %d", symbol, id)
+			}
+		}
+	}
+}
+
+func TestMatchingEngine_Rebalance(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT", "ETHUSDT", "BNBUSDT"}
+	cfg.Matching.WorkerCount = 4
+	engine := NewMatchingEngine(cfg)
+
+	engine.assigner = NewSymbolAssigner(4, map[string]int{"BTCUSDT": 1})
+	for i := 0; i < 4; i++ {
+		engine.workers = append(engine.workers, NewMatchingWorker(i, cfg, engine.outputQueue, engine.marketData))
+	}
+	engine.rebalance()
+
+	// 每个交易对恰好归属一个worker
+	owners := make(map[string]int)
+	for _, worker := range engine.workers {
+		for _, symbol := range worker.Symbols() {
+			if prev, ok := owners[symbol]; ok {
+				t.Errorf("Symbol %s owned by both worker %d and %d", symbol, prev, worker.id)
+			}
+			owners[symbol] = worker.id
+		}
+	}
+	if len(owners) != 3 {
+		t.Errorf("Expected 3 owned symbols, got %d", len(owners))
+	}
+	if owners["BTCUSDT"] != 1 {
+		t.Errorf("Expected BTCUSDT on worker 1, got %d", owners["BTCUSDT"])
+	}
+}
+
+func TestMatchingEngine_ProcessOrder(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT"}
+	cfg.Matching.WorkerCount = 1
+	engine := NewMatchingEngine(cfg)
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Stop()
+
+	sell := &types.Order{ID: 1, Symbol: "BTCUSDT", Price: 50000, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit}
+	result, err := engine.ProcessOrder(sell)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != OrderStatusPending || len(result.Result.Trades) != 0 {
+		t.Errorf("Expected resting sell to be pending, got %+v", result)
+	}
+
+	buy := &types.Order{ID: 2, Symbol: "BTCUSDT", Price: 50000, Quantity: 4, Side: types.SideBuy, Type: types.TypeLimit}
+	result, err = engine.ProcessOrder(buy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != OrderStatusFilled || result.FilledQty != 4 || len(result.Result.Trades) != 1 {
+		t.Errorf("Expected buy to be filled by one trade, got %+v", result)
+	}
+	if result.Result.Trades[0].MakerOrderID != 1 || result.Result.Order.ID != 2 {
+		t.Errorf("Unexpected trade %+v", result.Result.Trades[0])
+	}
+
+	// 异步下单入队即返回，结果可通过订单状态查询
+	async := &types.Order{ID: 3, Symbol: "BTCUSDT", Price: 50000, Quantity: 6, Side: types.SideBuy, Type: types.TypeLimit}
+	if err := engine.SubmitOrder(async); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if state, err := engine.GetOrderState(1); err == nil && state.Status == OrderStatusFilled {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Async order was not matched")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMatchingEngine_ProcessOrderTimeout(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT"}
+	cfg.Matching.ProcessTimeout = "10ms"
+	engine := NewMatchingEngine(cfg)
+
+	// 引擎未启动，订单停留在队列中
+	order := &types.Order{ID: 1, Symbol: "BTCUSDT", Price: 50000, Quantity: 10, Side: types.SideBuy, Type: types.TypeLimit}
+	if _, err := engine.ProcessOrder(order); !errors.Is(err, ErrProcessTimeout) {
+		t.Fatalf("Expected ErrProcessTimeout, got %v", err)
+	}
+	if _, ok := engine.waiters.Load(order.ID); ok {
+		t.Error("Waiter should be removed after timeout")
+	}
+}
+
+func TestMatchingEngine_JournalReplay(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT"}
+	cfg.Matching.WorkerCount = 1
+	cfg.Matching.Journal = config.JournalConfig{Enabled: true, Dir: t.TempDir(), SyncMode: journal.SyncAlways}
+
+	engine := NewMatchingEngine(cfg)
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	orders := []*types.Order{
+		{ID: 1, Symbol: "BTCUSDT", Price: 50000, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit},
+		{ID: 2, Symbol: "BTCUSDT", Price: 50100, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit},
+		{ID: 3, Symbol: "BTCUSDT", Price: 49900, Quantity: 7, Side: types.SideBuy, Type: types.TypeLimit},
+		{ID: 4, Symbol: "BTCUSDT", Price: 50000, Quantity: 4, Side: types.SideBuy, Type: types.TypeLimit},
+	}
+	for _, order := range orders {
+		if _, err := engine.ProcessOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := engine.CancelOrder(2, "BTCUSDT"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.AmendOrder(3, "BTCUSDT", 49950, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	want := engine.orderBooks["BTCUSDT"].GetSnapshot(10)
+	engine.Stop()
+
+	// 重启后重放日志恢复出相同的订单簿及订单状态
+	restarted := NewMatchingEngine(cfg)
+	if err := restarted.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer restarted.Stop()
+
+	got := restarted.orderBooks["BTCUSDT"].GetSnapshot(10)
+	if !reflect.DeepEqual(got.Bids, want.Bids) || !reflect.DeepEqual(got.Asks, want.Asks) {
+		t.Errorf("Replayed book differs: got %+v/%+v, want %+v/%+v", got.Bids, got.Asks, want.Bids, want.Asks)
+	}
+	if state, err := restarted.GetOrderState(1); err != nil || state.Status != OrderStatusPartial || state.FilledQuantity != 4 {
+		t.Errorf("Unexpected state of order 1: %+v, %v", state, err)
+	}
+	if state, err := restarted.GetOrderState(2); err != nil || state.Status != OrderStatusCancelled {
+		t.Errorf("Unexpected state of order 2: %+v, %v", state, err)
+	}
+	if _, err := restarted.ProcessOrder(orders[0]); !errors.Is(err, ErrDuplicateOrder) {
+		t.Errorf("Expected replayed order to be rejected as duplicate, got %v", err)
+	}
+	if restarted.lastSeq != 6 {
+		t.Errorf("Expected last seq 6, got %d", restarted.lastSeq)
+	}
+}
+
+func TestMatchingEngine_SnapshotRestore(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT"}
+	cfg.Matching.WorkerCount = 1
+	cfg.Matching.Journal = config.JournalConfig{Enabled: true, Dir: t.TempDir(), SyncMode: journal.SyncAlways}
+
+	engine := NewMatchingEngine(cfg)
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	orders := []*types.Order{
+		{ID: 1, Symbol: "BTCUSDT", Price: 50000, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit},
+		{ID: 2, Symbol: "BTCUSDT", Price: 49900, Quantity: 7, Side: types.SideBuy, Type: types.TypeLimit},
+		{ID: 3, Symbol: "BTCUSDT", Price: 50000, Quantity: 4, Side: types.SideBuy, Type: types.TypeLimit},
+		{ID: 4, Symbol: "BTCUSDT", Price: 50100, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit},
+	}
+	for _, order := range orders[:3] {
+		if _, err := engine.ProcessOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshot := engine.snapshotter.takeSnapshot("BTCUSDT")
+	if snapshot == nil || snapshot.Seq != 3 {
+		t.Fatalf("Expected snapshot at seq 3, got %+v", snapshot)
+	}
+
+	// 快照之后的命令只存在于日志中
+	if _, err := engine.ProcessOrder(orders[3]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.CancelOrder(2, "BTCUSDT"); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.RestoreFromSnapshot("BTCUSDT", snapshot.Data); !errors.Is(err, ErrEngineAlreadyStarted) {
+		t.Errorf("Expected ErrEngineAlreadyStarted, got %v", err)
+	}
+	want := engine.orderBooks["BTCUSDT"].GetSnapshot(10)
+	engine.Stop()
+
+	restarted := NewMatchingEngine(cfg)
+	if err := restarted.RestoreFromSnapshot("BTCUSDT", snapshot.Data); err != nil {
+		t.Fatal(err)
+	}
+	if state, err := restarted.GetOrderState(1); err != nil || state.Status != OrderStatusPartial || state.FilledQuantity != 4 {
+		t.Errorf("Unexpected state of order 1 after restore: %+v, %v", state, err)
+	}
+	if err := restarted.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer restarted.Stop()
+
+	got := restarted.orderBooks["BTCUSDT"].GetSnapshot(10)
+	if !reflect.DeepEqual(got.Bids, want.Bids) || !reflect.DeepEqual(got.Asks, want.Asks) {
+		t.Errorf("Restored book differs: got %+v/%+v, want %+v/%+v", got.Bids, got.Asks, want.Bids, want.Asks)
+	}
+	if state, err := restarted.GetOrderState(2); err != nil || state.Status != OrderStatusCancelled {
+		t.Errorf("Unexpected state of order 2: %+v, %v", state, err)
+	}
+	if restarted.lastSeq != 5 {
+		t.Errorf("Expected last seq 5, got %d", restarted.lastSeq)
+	}
+
+	// 恢复后的订单簿继续撮合
+	result, err := restarted.ProcessOrder(&types.Order{ID: 5, Symbol: "BTCUSDT", Price: 50000, Quantity: 6, Side: types.SideBuy, Type: types.TypeLimit})
+	if err != nil || result.Status != OrderStatusFilled || result.Result.Trades[0].MakerOrderID != 1 {
+		t.Errorf("Unexpected match after restore: %+v, %v", result, err)
+	}
+}
+
+func TestFileSnapshotStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileSnapshotStore(dir, RetentionPolicy{Retain: 2, MaxAge: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Latest("BTCUSDT"); !errors.Is(err, ErrSnapshotNotFound) {
+		t.Errorf("Expected ErrSnapshotNotFound, got %v", err)
+	}
+
+	now := time.Now().Unix()
+	for seq := uint64(1); seq <= 3; seq++ {
+		snapshot := &Snapshot{Symbol: "BTCUSDT", Seq: seq, Version: seq * 10, Timestamp: now, Data: []byte{byte(seq)}}
+		if err := store.Save(snapshot); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 超过最长保留时间的旧快照
+	if err := store.Save(&Snapshot{Symbol: "ETHUSDT", Seq: 1, Timestamp: now - 7200, Data: []byte{1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(&Snapshot{Symbol: "ETHUSDT", Seq: 2, Timestamp: now, Data: []byte{2}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if refs, _ := store.list("BTCUSDT"); len(refs) != 2 || refs[0].seq != 3 || refs[1].seq != 2 {
+		t.Errorf("Expected snapshots 3 and 2 to be retained, got %+v", refs)
+	}
+	if refs, _ := store.list("ETHUSDT"); len(refs) != 1 || refs[0].seq != 2 {
+		t.Errorf("Expected expired snapshot to be removed, got %+v", refs)
+	}
+
+	latest, err := store.Latest("BTCUSDT")
+	if err != nil || latest.Seq != 3 || latest.Version != 30 || !slices.Equal(latest.Data, []byte{3}) {
+		t.Fatalf("Unexpected latest snapshot: %+v, %v", latest, err)
+	}
+
+	// 最新快照损坏时回退到上一份有效快照
+	path := store.path("BTCUSDT", snapshotRef{seq: 3, timestamp: now})
+	record, _ := os.ReadFile(path)
+	record[len(record)-1] ^= 0xff
+	if err := os.WriteFile(path, record, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if latest, err := store.Latest("BTCUSDT"); err != nil || latest.Seq != 2 {
+		t.Errorf("Expected fallback to snapshot 2, got %+v, %v", latest, err)
+	}
+
+	// 清理时保留最新的有效快照，而不是序号最大但已损坏的快照
+	store.policy.Retain = 1
+	store.prune("BTCUSDT")
+	if latest, err := store.Latest("BTCUSDT"); err != nil || latest.Seq != 2 {
+		t.Errorf("Expected snapshot 2 to survive pruning, got %+v, %v", latest, err)
+	}
+}
+
+func TestMatchingEngine_RestoreFromStore(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT", "ETHUSDT"}
+	cfg.Matching.WorkerCount = 1
+	cfg.Matching.Journal = config.JournalConfig{Enabled: true, Dir: t.TempDir(), SyncMode: journal.SyncAlways}
+	store, err := NewSnapshotStore(config.SnapshotStoreConfig{Type: StoreTypeFile, Dir: t.TempDir(), Retain: 2}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engine := NewMatchingEngine(cfg)
+	engine.SetSnapshotStore(store)
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	orders := []*types.Order{
+		{ID: 1, Symbol: "BTCUSDT", Price: 50000, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit},
+		{ID: 2, Symbol: "ETHUSDT", Price: 3000, Quantity: 3, Side: types.SideBuy, Type: types.TypeLimit},
+		{ID: 3, Symbol: "BTCUSDT", Price: 50000, Quantity: 4, Side: types.SideBuy, Type: types.TypeLimit},
+	}
+	for _, order := range orders[:2] {
+		if _, err := engine.ProcessOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+	engine.snapshotter.takeSnapshots()
+	for len(engine.snapshotter.snapshotChan) > 0 {
+		engine.snapshotter.save(<-engine.snapshotter.snapshotChan)
+	}
+	if _, err := engine.ProcessOrder(orders[2]); err != nil {
+		t.Fatal(err)
+	}
+	want := engine.orderBooks["BTCUSDT"].GetSnapshot(10)
+	engine.Stop()
+
+	restarted := NewMatchingEngine(cfg)
+	restarted.SetSnapshotStore(store)
+	if err := restarted.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer restarted.Stop()
+
+	if restarted.snapshotSeqs["BTCUSDT"] != 2 || restarted.snapshotSeqs["ETHUSDT"] != 2 {
+		t.Errorf("Expected both symbols restored from snapshot seq 2, got %v", restarted.snapshotSeqs)
+	}
+	got := restarted.orderBooks["BTCUSDT"].GetSnapshot(10)
+	if !reflect.DeepEqual(got.Asks, want.Asks) || len(got.Bids) != 0 {
+		t.Errorf("Restored book differs: got %+v/%+v, want %+v", got.Bids, got.Asks, want.Asks)
+	}
+	if state, err := restarted.GetOrderState(2); err != nil || state.Status != OrderStatusPending {
+		t.Errorf("Unexpected state of order 2: %+v, %v", state, err)
+	}
+}
+
+func TestMatchingEngine_SymbolLifecycle(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT"}
+	cfg.Matching.WorkerCount = 2
+	cfg.Matching.Journal = config.JournalConfig{Enabled: true, Dir: t.TempDir(), SyncMode: journal.SyncAlways}
+	store, err := NewSnapshotStore(config.SnapshotStoreConfig{Type: StoreTypeFile, Dir: t.TempDir(), Retain: 2}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engine := NewMatchingEngine(cfg)
+	engine.SetSnapshotStore(store)
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	spec := types.InstrumentSpec{Symbol: "SOLUSDT", Precision: types.Precision{PriceScale: 2}, TickSize: 1, LotSize: 1, MinQty: 1}
+	if err := engine.AddSymbol(spec); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.AddSymbol(spec); !errors.Is(err, ErrSymbolExists) {
+		t.Errorf("Expected ErrSymbolExists, got %v", err)
+	}
+	orders := []*types.Order{
+		{ID: 1, Symbol: "SOLUSDT", Price: 15000, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit},
+		{ID: 2, Symbol: "SOLUSDT", Price: 14000, Quantity: 5, Side: types.SideBuy, Type: types.TypeLimit},
+		{ID: 3, Symbol: "SOLUSDT", Price: 13000, Quantity: 5, Side: types.SideBuy, Type: types.TypeLimit},
+	}
+	for _, order := range orders[:2] {
+		if _, err := engine.ProcessOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 暂停后拒绝新订单，撤单照常执行
+	if err := engine.HaltSymbol("SOLUSDT", "ops", "listing check"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.ProcessOrder(orders[2]); !errors.Is(err, ErrSymbolHalted) {
+		t.Errorf("Expected ErrSymbolHalted, got %v", err)
+	}
+	if ok, err := engine.CancelOrder(2, "SOLUSDT"); err != nil || !ok {
+		t.Errorf("Expected cancel to succeed while halted, got %v, %v", ok, err)
+	}
+	if err := engine.ResumeSymbol("SOLUSDT", "ops", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.ProcessOrder(orders[2]); err != nil {
+		t.Fatal(err)
+	}
+
+	infos := engine.ListSymbols()
+	if len(infos) != 2 || infos[1].Spec.Symbol != "SOLUSDT" || infos[1].OrderCount != 2 || infos[1].Spec.Status != types.InstrumentTrading {
+		t.Errorf("Unexpected symbols %+v", infos)
+	}
+	want := engine.orderBooks["SOLUSDT"].GetSnapshot(10)
+	engine.Stop()
+
+	// 运行中上架的交易对重启后从快照和日志恢复
+	restarted := NewMatchingEngine(cfg)
+	restarted.SetSnapshotStore(store)
+	if err := restarted.Start(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := restarted.GetOrderBook("SOLUSDT", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Asks, want.Asks) || !reflect.DeepEqual(got.Bids, want.Bids) {
+		t.Errorf("Restored book differs: got %+v/%+v, want %+v/%+v", got.Bids, got.Asks, want.Bids, want.Asks)
+	}
+
+	if result, err := restarted.ProcessOrder(&types.Order{ID: 5, Symbol: "SOLUSDT", Price: 15000, Quantity: 2, Side: types.SideBuy, Type: types.TypeLimit}); err != nil || result.Result.Trades[0].TradeID != 1 {
+		t.Fatalf("Unexpected match before delist: %+v, %v", result, err)
+	}
+
+	result, err := restarted.DelistSymbol("SOLUSDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Cancelled != 2 || !result.Archived {
+		t.Errorf("Unexpected delist result %+v", result)
+	}
+	for _, id := range []uint64{1, 3} {
+		if state, err := restarted.GetOrderState(id); err != nil || state.Status != OrderStatusCancelled {
+			t.Errorf("Expected order %d cancelled, got %+v, %v", id, state, err)
+		}
+	}
+	if _, err := restarted.ProcessOrder(&types.Order{ID: 4, Symbol: "SOLUSDT", Price: 15000, Quantity: 1, Side: types.SideBuy, Type: types.TypeLimit}); !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected ErrSymbolNotFound after delist, got %v", err)
+	}
+	if symbols, err := store.Symbols(); err != nil || slices.Contains(symbols, "SOLUSDT") {
+		t.Errorf("Expected snapshots of delisted symbol removed, got %v, %v", symbols, err)
+	}
+	restarted.Stop()
+
+	// 下架命令重放后交易对不再恢复
+	again := NewMatchingEngine(cfg)
+	again.SetSnapshotStore(store)
+	if err := again.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer again.Stop()
+	if symbols := again.GetSymbols(); !reflect.DeepEqual(symbols, []string{"BTCUSDT"}) {
+		t.Errorf("Expected only BTCUSDT after delist, got %v", symbols)
+	}
+
+	// 重新上架后成交ID延续下架前的编号，重启后从归档快照读取，运行中从下架记录读取
+	for i, wantTradeID := range []uint64{2, 3} {
+		if err := again.AddSymbol(spec); err != nil {
+			t.Fatal(err)
+		}
+		id := uint64(10 + 2*i)
+		if _, err := again.ProcessOrder(&types.Order{ID: id, Symbol: "SOLUSDT", Price: 15000, Quantity: 1, Side: types.SideSell, Type: types.TypeLimit}); err != nil {
+			t.Fatal(err)
+		}
+		result, err := again.ProcessOrder(&types.Order{ID: id + 1, Symbol: "SOLUSDT", Price: 15000, Quantity: 1, Side: types.SideBuy, Type: types.TypeLimit})
+		if err != nil || result.Result.Trades[0].TradeID != wantTradeID {
+			t.Fatalf("Expected trade %d after relisting, got %+v, %v", wantTradeID, result, err)
+		}
+		if _, err := again.DelistSymbol("SOLUSDT"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMatchingEngine_TradingPhase(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT"}
+	cfg.Matching.WorkerCount = 1
+	cfg.Matching.Journal = config.JournalConfig{Enabled: true, Dir: t.TempDir(), SyncMode: journal.SyncAlways}
+
+	engine := NewMatchingEngine(cfg)
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	sub := engine.SubscribeMarketData([]string{"BTCUSDT"}, false)
+	defer sub.Close()
+
+	if _, err := engine.ProcessOrder(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 50000, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 只挂单阶段: 会吃单的限价单及市价单被拒绝，不吃单的限价单照常挂单
+	if err := engine.SetTradingPhase("BTCUSDT", types.PhasePostOnly, "ops", "price feed incident"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.ProcessOrder(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 50000, Quantity: 1, Side: types.SideBuy, Type: types.TypeLimit}); !errors.Is(err, ErrPostOnlyWouldTake) {
+		t.Errorf("Expected ErrPostOnlyWouldTake, got %v", err)
+	}
+	if _, err := engine.ProcessOrder(&types.Order{ID: 3, Symbol: "BTCUSDT", Quantity: 1, Side: types.SideBuy, Type: types.TypeMarket}); !errors.Is(err, ErrSymbolPostOnly) {
+		t.Errorf("Expected ErrSymbolPostOnly, got %v", err)
+	}
+	if result, err := engine.ProcessOrder(&types.Order{ID: 4, Symbol: "BTCUSDT", Price: 49000, Quantity: 1, Side: types.SideBuy, Type: types.TypeLimit}); err != nil || result.Status != OrderStatusPending {
+		t.Errorf("Expected passive order to rest, got %+v, %v", result, err)
+	}
+
+	// 只撤单阶段: 拒绝下单及改单，撤单照常执行
+	if err := engine.SetTradingPhase("BTCUSDT", types.PhaseCancelOnly, "ops", "wind down"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.ProcessOrder(&types.Order{ID: 5, Symbol: "BTCUSDT", Price: 48000, Quantity: 1, Side: types.SideBuy, Type: types.TypeLimit}); !errors.Is(err, ErrSymbolCancelOnly) {
+		t.Errorf("Expected ErrSymbolCancelOnly, got %v", err)
+	}
+	if _, err := engine.AmendOrder(4, "BTCUSDT", 48500, 0, 0); !errors.Is(err, ErrSymbolCancelOnly) {
+		t.Errorf("Expected amend rejected in cancel-only phase, got %v", err)
+	}
+	if ok, err := engine.CancelOrder(4, "BTCUSDT"); err != nil || !ok {
+		t.Errorf("Expected cancel to succeed, got %v, %v", ok, err)
+	}
+
+	// 每次切换都按序发布给行情订阅方并记录操作人
+	for _, want := range []types.TradingPhase{types.PhasePostOnly, types.PhaseCancelOnly} {
+		select {
+		case event := <-sub.events:
+			if event.Type != MarketEventPhase || event.Phase.To != want {
+				t.Errorf("Expected phase event to %s, got %+v", want, event.Phase)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Phase change to %s was not published", want)
+		}
+	}
+	changes := engine.PhaseChanges("BTCUSDT")
+	if len(changes) != 2 || changes[1].From != types.PhasePostOnly || changes[1].Operator != "ops" || changes[1].Reason != "wind down" {
+		t.Errorf("Unexpected phase history %+v", changes)
+	}
+	engine.Stop()
+
+	// 重放日志后交易阶段保持不变
+	restarted := NewMatchingEngine(cfg)
+	if err := restarted.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer restarted.Stop()
+	if _, err := restarted.ProcessOrder(&types.Order{ID: 6, Symbol: "BTCUSDT", Price: 48000, Quantity: 1, Side: types.SideBuy, Type: types.TypeLimit}); !errors.Is(err, ErrSymbolCancelOnly) {
+		t.Errorf("Expected cancel-only phase after replay, got %v", err)
+	}
+	if phase := restarted.orderBooks["BTCUSDT"].Phase(); phase != types.PhaseCancelOnly {
+		t.Errorf("Expected replayed order book in cancel-only phase, got %s", phase)
+	}
+}
+
+// replicateTo 在进程内把primary的命令推送给follower，代替gRPC连接
+func replicateTo(t *testing.T, primary, follower *MatchingEngine) (stop func()) {
+	t.Helper()
+	replica, err := primary.Subscribe("follower-1", follower.LastSeq())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = replica.Stream(ctx, func(seq uint64, data []byte) error {
+			if seq == 0 {
+				follower.ObservePrimary(primary.LastSeq())
+				return nil
+			}
+			cmd := &journal.Command{}
+			if err := cmd.Unmarshal(data); err != nil {
+				return err
+			}
+			if err := follower.ApplyReplicated(cmd, primary.LastSeq()); err != nil {
+				return err
+			}
+			replica.Ack(cmd.Seq)
+			return nil
+		})
+	}()
+
+	return func() {
+		cancel()
+		<-done
+		primary.Unsubscribe(replica)
+	}
+}
+
+func TestMatchingEngine_Replication(t *testing.T) {
+	newConfig := func(role string) *config.Config {
+		cfg := &config.Config{}
+		cfg.Matching.Symbols = []string{"BTCUSDT"}
+		cfg.Matching.WorkerCount = 1
+		cfg.Matching.Journal = config.JournalConfig{Enabled: true, Dir: t.TempDir(), SyncMode: journal.SyncNone}
+		cfg.Matching.Replication = config.ReplicationConfig{Role: role, RequireAck: true, AckTimeout: "200ms"}
+		return cfg
+	}
+
+	primary := NewMatchingEngine(newConfig(RolePrimary))
+	follower := NewMatchingEngine(newConfig(RoleFollower))
+	for _, e := range []*MatchingEngine{primary, follower} {
+		if err := e.Start(); err != nil {
+			t.Fatal(err)
+		}
+		defer e.Stop()
+	}
+
+	// 没有follower确认时同步下单超时，订单已在primary撮合
+	if _, err := primary.ProcessOrder(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 50000, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit}); !errors.Is(err, ErrReplicationTimeout) {
+		t.Fatalf("Expected ErrReplicationTimeout, got %v", err)
+	}
+
+	// follower先从日志追赶，再接收实时命令
+	stop := replicateTo(t, primary, follower)
+	if _, err := primary.ProcessOrder(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 50000, Quantity: 4, Side: types.SideBuy, Type: types.TypeLimit}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := primary.CancelOrder(1, "BTCUSDT"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := follower.ProcessOrder(&types.Order{ID: 3, Symbol: "BTCUSDT", Price: 50000, Quantity: 1, Side: types.SideBuy, Type: types.TypeLimit}); !errors.Is(err, ErrNotPrimary) {
+		t.Errorf("Expected follower to reject orders, got %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		state, err := follower.GetOrderState(1)
+		if err == nil && state.Status == OrderStatusCancelled && state.FilledQuantity == 4 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Follower did not apply replicated commands: %+v, %v", state, err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if status := follower.ReplicationStatus(); status.Role != RoleFollower || status.LastSeq != 3 || status.Lag != 0 || !status.Connected {
+		t.Errorf("Unexpected follower status: %+v", status)
+	}
+	if status := primary.ReplicationStatus(); len(status.Followers) != 1 || status.Followers[0].AckedSeq != 3 {
+		t.Errorf("Unexpected primary status: %+v", status)
+	}
+	stop()
+
+	// primary下线后follower接管
+	if err := follower.Promote(); err != nil {
+		t.Fatal(err)
+	}
+	follower.requireAck = false
+	result, err := follower.ProcessOrder(&types.Order{ID: 4, Symbol: "BTCUSDT", Price: 50000, Quantity: 1, Side: types.SideSell, Type: types.TypeLimit})
+	if err != nil || result.Status != OrderStatusPending || follower.LastSeq() != 4 {
+		t.Errorf("Unexpected result after promotion: %+v, %v", result, err)
+	}
+}
+
+func TestMatchingEngine_Auction(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT"}
+	cfg.Matching.WorkerCount = 1
+	engine := NewMatchingEngine(cfg)
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Stop()
+	sub := engine.SubscribeMarketData([]string{"BTCUSDT"}, false)
+	defer sub.Close()
+
+	// 集合竞价期间交叉的限价单只挂单，市价单被拒绝
+	if err := engine.SetTradingPhase("BTCUSDT", types.PhaseAuction, "ops", "reopen"); err != nil {
+		t.Fatal(err)
+	}
+	orders := []*types.Order{
+		{ID: 1, Symbol: "BTCUSDT", Price: 100, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit},
+		{ID: 2, Symbol: "BTCUSDT", Price: 101, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit},
+		{ID: 3, Symbol: "BTCUSDT", Price: 101, Quantity: 8, Side: types.SideBuy, Type: types.TypeLimit},
+	}
+	for _, order := range orders {
+		if result, err := engine.ProcessOrder(order); err != nil || result.Status != OrderStatusPending {
+			t.Fatalf("Expected order %d to rest during auction, got %+v, %v", order.ID, result, err)
+		}
+	}
+	if _, err := engine.ProcessOrder(&types.Order{ID: 4, Symbol: "BTCUSDT", Quantity: 1, Side: types.SideBuy, Type: types.TypeMarket}); !errors.Is(err, ErrSymbolAuction) {
+		t.Errorf("Expected ErrSymbolAuction, got %v", err)
+	}
+
+	// 退出集合竞价时按参考成交价撮合
+	if err := engine.SetTradingPhase("BTCUSDT", types.PhaseContinuous, "ops", "reopen"); err != nil {
+		t.Fatal(err)
+	}
+
+	quote := orderbook.AuctionQuote{Price: 100, Volume: 8, Imbalance: -2}
+	for _, want := range []MarketEventType{MarketEventPhase, MarketEventIndicative, MarketEventUncross, MarketEventPhase} {
+		select {
+		case event := <-sub.events:
+			if event.Type != want {
+				t.Fatalf("Expected event type %d, got %+v", want, event)
+			}
+			if want != MarketEventPhase && *event.Auction != quote {
+				t.Errorf("Expected auction quote %+v, got %+v", quote, *event.Auction)
+			}
+			if want == MarketEventUncross && (len(event.Trades) != 1 || event.Trades[0].TakerOrderID != 3 || event.Trades[0].MakerOrderID != 1) {
+				t.Errorf("Unexpected auction trades %+v", event.Trades)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Event type %d was not published", want)
+		}
+	}
+
+	if state, err := engine.GetOrderState(3); err != nil || state.Status != OrderStatusFilled {
+		t.Errorf("Expected buy order filled by uncross, got %+v, %v", state, err)
+	}
+	if state, err := engine.GetOrderState(1); err != nil || state.Status != OrderStatusPartial || state.FilledQuantity != 8 {
+		t.Errorf("Expected sell order partially filled by uncross, got %+v, %v", state, err)
+	}
+	if bid, ask := engine.orderBooks["BTCUSDT"].GetBestBidAndAsk(); bid != 0 || ask != 100 {
+		t.Errorf("Expected uncrossed book with best ask 100, got %d/%d", bid, ask)
+	}
+}
+
+func TestMatchingEngine_MassCancel(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT", "ETHUSDT"}
+	cfg.Matching.WorkerCount = 2
+	engine := NewMatchingEngine(cfg)
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Stop()
+
+	orders := []*types.Order{
+		{ID: 1, Symbol: "BTCUSDT", Price: 49000, Quantity: 1, Side: types.SideBuy, Account: "A"},
+		{ID: 2, Symbol: "BTCUSDT", Price: 51000, Quantity: 1, Side: types.SideSell, Account: "A"},
+		{ID: 3, Symbol: "BTCUSDT", Price: 48000, Quantity: 1, Side: types.SideBuy, Account: "B"},
+		{ID: 4, Symbol: "ETHUSDT", Price: 3000, Quantity: 1, Side: types.SideBuy, Account: "A"},
+	}
+	for _, order := range orders {
+		order.Type = types.TypeLimit
+		if _, err := engine.ProcessOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := engine.MassCancel("BTCUSDT", orderbook.CancelFilter{Side: types.SideBuy, Account: "A"})
+	if err != nil || !reflect.DeepEqual(result.OrderIDs, []uint64{1}) {
+		t.Errorf("Expected order 1 cancelled, got %+v, %v", result, err)
+	}
+
+	// 未指定交易对时在全部交易对上撤单，暂停交易的交易对同样执行
+	if err := engine.HaltSymbol("ETHUSDT", "ops", ""); err != nil {
+		t.Fatal(err)
+	}
+	result, err = engine.MassCancel("", orderbook.CancelFilter{Account: "A"})
+	if err != nil || !reflect.DeepEqual(result.OrderIDs, []uint64{2, 4}) || len(result.FailedSymbols) != 0 {
+		t.Errorf("Expected orders 2 and 4 cancelled, got %+v, %v", result, err)
+	}
+	if state, err := engine.GetOrderState(2); err != nil || state.Status != OrderStatusCancelled {
+		t.Errorf("Expected order 2 cancelled, got %+v, %v", state, err)
+	}
+	if _, err := engine.MassCancel("", orderbook.CancelFilter{Side: types.SideBuy}); !errors.Is(err, ErrInvalidCancelFilter) {
+		t.Errorf("Expected ErrInvalidCancelFilter, got %v", err)
+	}
+}
+
+func TestTimingWheel(t *testing.T) {
+	ms := int64(time.Millisecond)
+	start := int64(time.Second)
+	wheel := newTimingWheel(time.Millisecond, start)
+
+	wheel.schedule("DUE", start-ms)
+	wheel.schedule("A", start+5*ms)
+	wheel.schedule("A", start+5*ms)
+	wheel.schedule("ROUND", start+5*ms+ms/2) // 向上取整到第6格
+	wheel.schedule("B", start+100*ms)        // 第二层
+	wheel.schedule("C", start+300000*ms)     // 第四层，需逐层下放
+
+	steps := []struct {
+		at   int64
+		want []string
+	}{
+		{start, []string{"DUE"}},
+		{start + 4*ms, nil},
+		{start + 5*ms, []string{"A"}},
+		{start + 6*ms, []string{"ROUND"}},
+		{start + 99*ms, nil},
+		{start + 100*ms, []string{"B"}},
+		{start + 299999*ms, nil},
+		{start + 300000*ms, []string{"C"}},
+	}
+	for _, step := range steps {
+		if got := wheel.advance(step.at); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("advance(+%dms): got %v, want %v", (step.at-start)/ms, got, step.want)
+		}
+	}
+}
+
+func TestMatchingEngine_GTDExpiry(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT"}
+	cfg.Matching.WorkerCount = 1
+	cfg.Matching.ExpiryCheckInterval = "5ms"
+	cfg.Matching.Journal = config.JournalConfig{Enabled: true, Dir: t.TempDir(), SyncMode: journal.SyncAlways}
+
+	engine := NewMatchingEngine(cfg)
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UnixNano()
+	orders := []*types.Order{
+		{ID: 1, Symbol: "BTCUSDT", Price: 50000, Quantity: 1, Side: types.SideSell, ExpireTime: now + int64(50*time.Millisecond)},
+		{ID: 2, Symbol: "BTCUSDT", Price: 50100, Quantity: 1, Side: types.SideSell, ExpireTime: now + int64(time.Hour)},
+	}
+	for _, order := range orders {
+		order.Type = types.TypeLimit
+		order.TimeInForce = types.TimeInForceGTD
+		if _, err := engine.ProcessOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if state, err := engine.GetOrderState(1); err == nil && state.Status == OrderStatusCancelled {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("GTD order was not expired")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if state, err := engine.GetOrderState(2); err != nil || state.Status != OrderStatusPending {
+		t.Errorf("Expected order 2 resting, got %+v, %v", state, err)
+	}
+	engine.Stop()
+
+	// 过期清理按日志中的命令时间戳重放，结果与重放时的时钟无关
+	restarted := NewMatchingEngine(cfg)
+	if err := restarted.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer restarted.Stop()
+
+	if state, err := restarted.GetOrderState(1); err != nil || state.Status != OrderStatusCancelled {
+		t.Errorf("Expected replayed order 1 cancelled, got %+v, %v", state, err)
+	}
+	if count := restarted.orderBooks["BTCUSDT"].OrderCount(); count != 1 {
+		t.Errorf("Expected 1 resting order after replay, got %d", count)
+	}
+}
+
+func TestMatchingEngine_GTDExpiredBeforeTimer(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT"}
+	cfg.Matching.WorkerCount = 1
+	cfg.Matching.ExpiryCheckInterval = "1h"
+
+	engine := NewMatchingEngine(cfg)
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Stop()
+
+	sell := &types.Order{ID: 1, Symbol: "BTCUSDT", Price: 50000, Quantity: 1, Side: types.SideSell, Type: types.TypeLimit,
+		TimeInForce: types.TimeInForceGTD, ExpireTime: time.Now().Add(20 * time.Millisecond).UnixNano()}
+	if _, err := engine.ProcessOrder(sell); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	// 定时清理触发前到达的下一个订单先撤销过期挂单，订单状态同步更新
+	buy := &types.Order{ID: 2, Symbol: "BTCUSDT", Price: 49000, Quantity: 1, Side: types.SideBuy, Type: types.TypeLimit}
+	if _, err := engine.ProcessOrder(buy); err != nil {
+		t.Fatal(err)
+	}
+	if state, err := engine.GetOrderState(1); err != nil || state.Status != OrderStatusCancelled {
+		t.Errorf("Expected expired order 1 cancelled, got %+v, %v", state, err)
+	}
+	if count := engine.orderBooks["BTCUSDT"].OrderCount(); count != 1 {
+		t.Errorf("Expected only order 2 resting, got %d", count)
+	}
+}
+
+func TestMatchingEngine_DepthFeed(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT"}
+	cfg.Matching.WorkerCount = 1
+
+	engine := NewMatchingEngine(cfg)
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Stop()
+	sub := engine.SubscribeMarketData([]string{"BTCUSDT"}, true)
+	defer sub.Close()
+
+	orders := []*types.Order{
+		{ID: 1, Symbol: "BTCUSDT", Price: 50000, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit},
+		{ID: 2, Symbol: "BTCUSDT", Price: 50000, Quantity: 4, Side: types.SideBuy, Type: types.TypeLimit},
+	}
+	for _, order := range orders {
+		if _, err := engine.ProcessOrder(order); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 每条命令一次增量，更新ID连续且与快照对应
+	var last uint64
+	for _, want := range []int64{10, 6} {
+		select {
+		case event := <-sub.events:
+			if event.Type != MarketEventDepth || event.Depth.FirstUpdateID != last+1 {
+				t.Fatalf("Expected contiguous depth event after %d, got %+v", last, event.Depth)
+			}
+			if len(event.Depth.Asks) != 1 || event.Depth.Asks[0].Quantity != want {
+				t.Errorf("Expected ask quantity %d, got %+v", want, event.Depth.Asks)
+			}
+			last = event.Depth.LastUpdateID
+		case <-time.After(time.Second):
+			t.Fatal("Depth update was not published")
+		}
+	}
+	snapshot, err := engine.GetOrderBook("BTCUSDT", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.UpdateID != last {
+		t.Errorf("Expected snapshot update id %d, got %d", last, snapshot.UpdateID)
+	}
+}
+
+func TestMatchingEngine_OrderFeed(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.Symbols = []string{"BTCUSDT"}
+	cfg.Matching.WorkerCount = 1
+
+	engine := NewMatchingEngine(cfg)
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Stop()
+	sub := engine.SubscribeOrderFeed(nil)
+	defer sub.Close()
+
+	if _, err := engine.ProcessOrder(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 50000, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit, Account: "mm"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.ProcessOrder(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 50000, Quantity: 4, Side: types.SideBuy, Type: types.TypeLimit}); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := engine.CancelOrder(1, "BTCUSDT"); err != nil || !ok {
+		t.Fatalf("Expected cancel to succeed, got %v, %v", ok, err)
+	}
+
+	// 只收到逐笔委托事件，序号在交易对内连续
+	var seq uint64
+	for _, want := range []orderbook.OrderEventType{orderbook.OrderEventAdd, orderbook.OrderEventExecute, orderbook.OrderEventDelete} {
+		select {
+		case event := <-sub.events:
+			if event.Type != MarketEventOrders || len(event.Orders) != 1 {
+				t.Fatalf("Expected one order event, got %+v", event)
+			}
+			got := event.Orders[0]
+			if got.Type != want || got.OrderID != 1 || got.Seq != seq+1 {
+				t.Errorf("Expected %d event of order 1 after seq %d, got %+v", want, seq, got)
+			}
+			seq = got.Seq
+		case <-time.After(time.Second):
+			t.Fatalf("Order event %d was not published", want)
+		}
+	}
+}
+
+func TestMatchingEngine_AmendInstrumentSpec(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Matching.WorkerCount = 1
+	engine := NewMatchingEngine(cfg)
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Stop()
+
+	spec := types.InstrumentSpec{Symbol: "SOLUSDT", TickSize: 10, LotSize: 10, MinQty: 10, MaxQty: 1000}
+	if err := engine.AddSymbol(spec); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.ProcessOrder(&types.Order{ID: 1, Symbol: "SOLUSDT", Price: 1000, Quantity: 100, Side: types.SideBuy, Type: types.TypeLimit}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 改单后的价格及数量按下单规则校验，拒绝的改单不改变订单版本
+	cases := []struct {
+		price, qty int64
+		want       error
+	}{
+		{1005, 0, ErrInvalidTickSize},
+		{0, 105, ErrInvalidLotSize},
+		{0, 2000, ErrQtyAboveMax},
+	}
+	for _, tc := range cases {
+		if _, err := engine.AmendOrder(1, "SOLUSDT", tc.price, tc.qty, 0); !errors.Is(err, tc.want) {
+			t.Errorf("Amend to %d x %d: expected %v, got %v", tc.price, tc.qty, tc.want, err)
+		}
+	}
+	if result, err := engine.AmendOrder(1, "SOLUSDT", 990, 50, 0); err != nil || result.Version != 1 {
+		t.Errorf("Expected valid amend to succeed with version 1, got %+v, %v", result, err)
+	}
+}
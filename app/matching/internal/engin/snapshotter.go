@@ -2,32 +2,43 @@ package engine
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/tsfdsong/tradeengin/app/matching/internal/orderbook"
 	"github.com/tsfdsong/tradeengin/app/pkg/types"
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
 )
 
 // Snapshotter 订单簿快照服务
 type Snapshotter struct {
-	orderBooks   map[string]*orderbook.HybridOrderBook
+	orderBooks   func() map[string]*orderbook.HybridOrderBook // 当前全部订单簿，交易对可在运行中上架/下架
+	capture      func(symbol string) (*Snapshot, error)       // 在交易对的命令序列中拍摄一致的快照
 	interval     time.Duration
 	snapshotChan chan *Snapshot
+	store        SnapshotStore // 快照存储，为nil时不保存
 	enabled      bool
 }
 
 // Snapshot 订单簿快照
 type Snapshot struct {
 	Symbol    string
-	Data      []byte
+	Data      []byte // orderbook.BookSnapshot的二进制编码
 	Timestamp int64
 	Version   uint64
+	Seq       uint64 // 快照已包含的最后一条命令序号，恢复后从下一条开始重放
+
+	Instrument *types.InstrumentSpec // 拍摄时的交易对规格，重启时据此恢复运行中上架的交易对及交易状态
 }
 
 // NewSnapshotter 创建快照服务
-func NewSnapshotter(orderBooks map[string]*orderbook.HybridOrderBook, interval string) *Snapshotter {
+func NewSnapshotter(
+	orderBooks func() map[string]*orderbook.HybridOrderBook,
+	capture func(symbol string) (*Snapshot, error),
+	interval string,
+) *Snapshotter {
 	duration, err := time.ParseDuration(interval)
 	if err != nil {
 		duration = 30 * time.Second // 默认30秒
@@ -35,6 +46,7 @@ func NewSnapshotter(orderBooks map[string]*orderbook.HybridOrderBook, interval s
 
 	return &Snapshotter{
 		orderBooks:   orderBooks,
+		capture:      capture,
 		interval:     duration,
 		snapshotChan: make(chan *Snapshot, 1000),
 		enabled:      true,
@@ -45,12 +57,21 @@ func NewSnapshotter(orderBooks map[string]*orderbook.HybridOrderBook, interval s
 func (s *Snapshotter) Run(ctx context.Context) {
 	logx.Info("Snapshotter started")
 
+	// 单独的协程把快照写入存储，写入慢时不影响按时拍摄
+	var wg sync.WaitGroup
+	wg.Add(1)
+	threading.GoSafe(func() {
+		defer wg.Done()
+		s.drain(ctx)
+	})
+
 	ticker := time.NewTicker(s.interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
+			wg.Wait()
 			logx.Info("Snapshotter stopped")
 			return
 		case <-ticker.C:
@@ -61,8 +82,12 @@ func (s *Snapshotter) Run(ctx context.Context) {
 
 // takeSnapshots 拍摄所有订单簿快照
 func (s *Snapshotter) takeSnapshots() {
-	for symbol, orderBook := range s.orderBooks {
-		snapshot := s.takeSnapshot(symbol, orderBook)
+	if !s.enabled {
+		return
+	}
+
+	for symbol := range s.orderBooks() {
+		snapshot := s.takeSnapshot(symbol)
 		if snapshot != nil {
 			select {
 			case s.snapshotChan <- snapshot:
@@ -75,31 +100,62 @@ func (s *Snapshotter) takeSnapshots() {
 }
 
 // takeSnapshot 拍摄单个订单簿快照
-func (s *Snapshotter) takeSnapshot(symbol string, orderBook *orderbook.HybridOrderBook) *Snapshot {
+func (s *Snapshotter) takeSnapshot(symbol string) *Snapshot {
 	startTime := time.Now()
 
-	// 获取订单簿快照
-	obSnapshot := orderBook.GetSnapshot(100) // 获取前100档深度
-
-	// 序列化快照数据
-	data, err := json.Marshal(obSnapshot)
+	// 获取包含全部挂单的完整快照
+	snapshot, err := s.capture(symbol)
 	if err != nil {
-		logx.Errorf("Failed to marshal snapshot for %s: %v", symbol, err)
+		logx.Errorf("Failed to capture snapshot for %s: %v", symbol, err)
 		return nil
 	}
 
-	// 获取统计信息
-	stats := orderBook.GetStats()
-
 	latency := time.Since(startTime)
-	logx.Infof("Snapshot taken for %s: depth=%d, latency=%v, stats=%+v",
-		symbol, len(obSnapshot.Bids)+len(obSnapshot.Asks), latency, stats)
+	logx.Infof("Snapshot taken for %s: seq=%d, size=%d, latency=%v",
+		symbol, snapshot.Seq, len(snapshot.Data), latency)
+
+	return snapshot
+}
+
+// newSnapshot 编码订单簿快照，附带交易对规格
+func newSnapshot(obSnapshot *orderbook.BookSnapshot, spec *types.InstrumentSpec) (*Snapshot, error) {
+	data, err := obSnapshot.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("marshal snapshot: %w", err)
+	}
 
 	return &Snapshot{
-		Symbol:    symbol,
-		Data:      data,
-		Timestamp: time.Now().Unix(),
-		Version:   stats.OrdersProcessed,
+		Symbol:     obSnapshot.Symbol,
+		Data:       data,
+		Timestamp:  time.Now().Unix(),
+		Version:    obSnapshot.Version,
+		Seq:        obSnapshot.Seq,
+		Instrument: spec,
+	}, nil
+}
+
+// drain 将快照通道中的快照保存到存储，退出前保存通道中剩余的快照
+func (s *Snapshotter) drain(ctx context.Context) {
+	for {
+		select {
+		case snapshot := <-s.snapshotChan:
+			s.save(snapshot)
+		case <-ctx.Done():
+			for {
+				select {
+				case snapshot := <-s.snapshotChan:
+					s.save(snapshot)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *Snapshotter) save(snapshot *Snapshot) {
+	if err := s.SaveSnapshot(snapshot); err != nil {
+		logx.Errorf("Failed to save snapshot for %s, seq=%d: %v", snapshot.Symbol, snapshot.Seq, err)
 	}
 }
 
@@ -108,32 +164,83 @@ func (s *Snapshotter) GetSnapshotChan() <-chan *Snapshot {
 	return s.snapshotChan
 }
 
+// SetStore 设置快照存储
+func (s *Snapshotter) SetStore(store SnapshotStore) {
+	s.store = store
+}
+
 // SaveSnapshot 保存快照到持久化存储
 func (s *Snapshotter) SaveSnapshot(snapshot *Snapshot) error {
-	// 这里可以实现保存到Redis、数据库或文件系统
-	// 例如:
-	// - Redis: 使用有序集合存储历史快照
-	// - 数据库: 插入到snapshots表
-	// - 文件系统: 写入到本地文件
+	if s.store == nil {
+		logx.Debugf("No snapshot store configured, discard snapshot for %s", snapshot.Symbol)
+		return nil
+	}
 
-	logx.Debugf("Saving snapshot for %s, size=%d bytes",
-		snapshot.Symbol, len(snapshot.Data))
+	if err := s.store.Save(snapshot); err != nil {
+		return err
+	}
 
+	logx.Debugf("Saved snapshot for %s, seq=%d, size=%d bytes",
+		snapshot.Symbol, snapshot.Seq, len(snapshot.Data))
 	return nil
 }
 
-// RestoreFromSnapshot 从快照恢复订单簿
-func (s *Snapshotter) RestoreFromSnapshot(symbol string, data []byte) error {
-	var obSnapshot types.OrderBook
-	if err := json.Unmarshal(data, &obSnapshot); err != nil {
-		return err
+// Archive 归档下架交易对的最终快照，未配置存储时丢弃
+func (s *Snapshotter) Archive(snapshot *Snapshot) error {
+	if s.store == nil {
+		logx.Debugf("No snapshot store configured, discard final snapshot for %s", snapshot.Symbol)
+		return nil
 	}
+	return s.store.Archive(snapshot)
+}
 
-	// 这里可以实现从快照数据恢复订单簿状态
-	// 注意: 这需要根据具体的订单簿实现来编写恢复逻辑
+// ArchivedSnapshot 获取存储中下架交易对最新的归档快照
+func (s *Snapshotter) ArchivedSnapshot(symbol string) (*Snapshot, error) {
+	if s.store == nil {
+		return nil, ErrSnapshotNotFound
+	}
+	return s.store.Archived(symbol)
+}
 
-	logx.Infof("Restored orderbook for %s from snapshot", symbol)
-	return nil
+// StoredSymbols 获取存储中存有快照的交易对
+func (s *Snapshotter) StoredSymbols() ([]string, error) {
+	if s.store == nil {
+		return nil, nil
+	}
+	return s.store.Symbols()
+}
+
+// LatestSnapshot 获取存储中交易对最新的有效快照
+func (s *Snapshotter) LatestSnapshot(symbol string) (*Snapshot, error) {
+	if s.store == nil {
+		return nil, ErrSnapshotNotFound
+	}
+	return s.store.Latest(symbol)
+}
+
+// RestoreFromSnapshot 从快照恢复订单簿，返回解码后的快照供调用方恢复订单状态
+// 快照中的订单已挂入订单簿，调用方只能在撮合开始前读取
+func (s *Snapshotter) RestoreFromSnapshot(symbol string, data []byte) (*orderbook.BookSnapshot, error) {
+	orderBook, exists := s.orderBooks()[symbol]
+	if !exists {
+		return nil, ErrSymbolNotFound
+	}
+
+	obSnapshot := &orderbook.BookSnapshot{}
+	if err := obSnapshot.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	if obSnapshot.Symbol != symbol {
+		return nil, fmt.Errorf("%w: snapshot of %s", orderbook.ErrSnapshotMismatch, obSnapshot.Symbol)
+	}
+
+	if err := orderBook.Restore(obSnapshot); err != nil {
+		return nil, err
+	}
+
+	logx.Infof("Restored orderbook for %s from snapshot, seq: %d, version: %d, orders: %d",
+		symbol, obSnapshot.Seq, obSnapshot.Version, len(obSnapshot.Orders()))
+	return obSnapshot, nil
 }
 
 // Enable 启用快照服务
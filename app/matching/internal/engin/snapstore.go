@@ -0,0 +1,264 @@
+package engine
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tsfdsong/tradeengin/app/matching/internal/config"
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+const (
+	StoreTypeFile  = "file"
+	StoreTypeRedis = "redis"
+	StoreTypeNone  = "none"
+
+	// 快照记录格式: magic(4B) + CRC32C(4B) + seq/version/timestamp(varint) + symbol + 交易对规格(v2) + 快照数据
+	snapshotRecordMagicV1 = "SNR1"
+	snapshotRecordMagic   = "SNR2" // v2增加交易对规格
+)
+
+var (
+	ErrSnapshotNotFound = errors.New("no valid snapshot found")
+	ErrSnapshotChecksum = errors.New("snapshot checksum mismatch")
+
+	snapshotCRCTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// SnapshotStore 快照存储，同一交易对的快照按序号区分
+type SnapshotStore interface {
+	// Save 原子写入快照，写入成功后按保留策略清理旧快照
+	Save(snapshot *Snapshot) error
+	// Latest 获取交易对序号最大且校验通过的快照，没有时返回ErrSnapshotNotFound
+	Latest(symbol string) (*Snapshot, error)
+	// Symbols 列出存有快照的交易对，启动时据此恢复运行中上架的交易对
+	Symbols() ([]string, error)
+	// Archive 归档下架交易对的最终快照，并删除该交易对的全部快照
+	Archive(snapshot *Snapshot) error
+	// Archived 获取下架交易对序号最大且校验通过的归档快照，没有时返回ErrSnapshotNotFound
+	Archived(symbol string) (*Snapshot, error)
+}
+
+// RetentionPolicy 快照保留策略，交易对序号最大且校验通过的快照始终保留
+type RetentionPolicy struct {
+	Retain int           // 每个交易对最多保留的快照数，<=0表示不限制
+	MaxAge time.Duration // 快照最长保留时间，<=0表示不限制
+}
+
+// NewSnapshotStore 按配置创建快照存储，未启用时返回nil
+func NewSnapshotStore(c config.SnapshotStoreConfig, client *redis.Redis) (SnapshotStore, error) {
+	policy := RetentionPolicy{Retain: c.Retain}
+	if c.MaxAge != "" {
+		maxAge, err := time.ParseDuration(c.MaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot MaxAge %q: %w", c.MaxAge, err)
+		}
+		policy.MaxAge = maxAge
+	}
+
+	switch c.Type {
+	case StoreTypeFile:
+		return NewFileSnapshotStore(c.Dir, policy)
+	case StoreTypeRedis:
+		if client == nil {
+			return nil, errors.New("redis snapshot store requires RedisConf")
+		}
+		return NewRedisSnapshotStore(client, policy), nil
+	case StoreTypeNone, "":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown snapshot store type %q", c.Type)
+	}
+}
+
+// snapshotRef 存储中的一份快照，只含名称中的序号及时间，不读取快照内容
+type snapshotRef struct {
+	seq       uint64
+	timestamp int64 // 拍摄时间(秒)
+}
+
+// snapshotName 快照名称，序号补零保证按名称排序即按序号排序
+func snapshotName(seq uint64, timestamp int64) string {
+	return fmt.Sprintf("%020d-%d", seq, timestamp)
+}
+
+// parseSnapshotName 解析快照名称
+func parseSnapshotName(name string) (snapshotRef, bool) {
+	seqPart, tsPart, ok := strings.Cut(name, "-")
+	if !ok {
+		return snapshotRef{}, false
+	}
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return snapshotRef{}, false
+	}
+	timestamp, err := strconv.ParseInt(tsPart, 10, 64)
+	if err != nil {
+		return snapshotRef{}, false
+	}
+	return snapshotRef{seq: seq, timestamp: timestamp}, true
+}
+
+// sortSnapshotRefs 按序号从新到旧排序
+func sortSnapshotRefs(refs []snapshotRef) {
+	sort.Slice(refs, func(i, k int) bool {
+		return refs[i].seq > refs[k].seq
+	})
+}
+
+// expired 按保留策略挑出需要清理的快照，refs需按序号从新到旧排列
+// newest为序号最大且校验通过的快照，始终保留，序号更大但已损坏的快照不能代替它
+func (p RetentionPolicy) expired(refs []snapshotRef, newest snapshotRef, now time.Time) []snapshotRef {
+	var expired []snapshotRef
+	for i, ref := range refs {
+		if ref == newest {
+			continue
+		}
+		if (p.Retain > 0 && i >= p.Retain) ||
+			(p.MaxAge > 0 && now.Sub(time.Unix(ref.timestamp, 0)) > p.MaxAge) {
+			expired = append(expired, ref)
+		}
+	}
+	return expired
+}
+
+// newestValid 读取并校验快照，返回交易对序号最大且校验通过的快照，用于清理前确定必须保留的快照
+func newestValid(store SnapshotStore, symbol string) (snapshotRef, error) {
+	snapshot, err := store.Latest(symbol)
+	if err != nil {
+		return snapshotRef{}, err
+	}
+	return snapshotRef{seq: snapshot.Seq, timestamp: snapshot.Timestamp}, nil
+}
+
+// encodeSnapshotRecord 编码快照及其元数据，附带校验和
+func encodeSnapshotRecord(s *Snapshot) []byte {
+	buf := make([]byte, 0, len(snapshotRecordMagic)+4+32+len(s.Symbol)+len(s.Data))
+	buf = append(buf, snapshotRecordMagic...)
+	buf = append(buf, 0, 0, 0, 0) // 校验和占位
+
+	buf = binary.AppendUvarint(buf, s.Seq)
+	buf = binary.AppendUvarint(buf, s.Version)
+	buf = binary.AppendVarint(buf, s.Timestamp)
+	buf = appendRecordString(buf, s.Symbol)
+	buf = appendInstrument(buf, s.Instrument)
+	buf = append(buf, s.Data...)
+
+	body := len(snapshotRecordMagic) + 4
+	binary.BigEndian.PutUint32(buf[len(snapshotRecordMagic):body], crc32.Checksum(buf[body:], snapshotCRCTable))
+	return buf
+}
+
+// decodeSnapshotRecord 校验并解码快照记录，v1记录没有交易对规格
+func decodeSnapshotRecord(record []byte) (*Snapshot, error) {
+	body := len(snapshotRecordMagic) + 4
+	if len(record) < body {
+		return nil, fmt.Errorf("%w: bad record header", ErrSnapshotChecksum)
+	}
+	magic := string(record[:len(snapshotRecordMagic)])
+	if magic != snapshotRecordMagic && magic != snapshotRecordMagicV1 {
+		return nil, fmt.Errorf("%w: bad record header", ErrSnapshotChecksum)
+	}
+	if crc32.Checksum(record[body:], snapshotCRCTable) != binary.BigEndian.Uint32(record[len(snapshotRecordMagic):body]) {
+		return nil, ErrSnapshotChecksum
+	}
+
+	s := &Snapshot{}
+	data := record[body:]
+	s.Seq, data = readUvarint(data)
+	s.Version, data = readUvarint(data)
+	s.Timestamp, data = readVarint(data)
+	s.Symbol, data = readRecordString(data)
+	if magic == snapshotRecordMagic {
+		s.Instrument, data = readInstrument(data)
+	}
+	if data == nil {
+		return nil, fmt.Errorf("%w: truncated record", ErrSnapshotChecksum)
+	}
+
+	s.Data = append([]byte(nil), data...)
+	return s, nil
+}
+
+// appendInstrument 编码交易对规格，nil编码为0
+func appendInstrument(buf []byte, spec *types.InstrumentSpec) []byte {
+	if spec == nil {
+		return append(buf, 0)
+	}
+
+	buf = append(buf, 1)
+	buf = appendRecordString(buf, spec.Status)
+	buf = binary.AppendVarint(buf, int64(spec.Precision.PriceScale))
+	buf = binary.AppendVarint(buf, int64(spec.Precision.QuantityScale))
+	for _, v := range []int64{spec.TickSize, spec.LotSize, spec.MinQty, spec.MaxQty, spec.MinNotional} {
+		buf = binary.AppendVarint(buf, v)
+	}
+	return buf
+}
+
+// readInstrument 解码交易对规格，数据不完整时返回nil
+func readInstrument(data []byte) (*types.InstrumentSpec, []byte) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if data[0] == 0 {
+		return nil, data[1:]
+	}
+
+	spec := &types.InstrumentSpec{}
+	var priceScale, qtyScale int64
+	spec.Status, data = readRecordString(data[1:])
+	priceScale, data = readVarint(data)
+	qtyScale, data = readVarint(data)
+	spec.Precision = types.Precision{PriceScale: int32(priceScale), QuantityScale: int32(qtyScale)}
+	for _, v := range []*int64{&spec.TickSize, &spec.LotSize, &spec.MinQty, &spec.MaxQty, &spec.MinNotional} {
+		*v, data = readVarint(data)
+	}
+	return spec, data
+}
+
+// appendRecordString 编码长度前缀的字符串
+func appendRecordString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+// readRecordString 读取长度前缀的字符串，数据不完整时返回nil
+func readRecordString(data []byte) (string, []byte) {
+	n, data := readUvarint(data)
+	if data == nil || n > uint64(len(data)) {
+		return "", nil
+	}
+	return string(data[:n]), data[n:]
+}
+
+// readUvarint 读取无符号varint，数据不完整时返回nil，之后的读取也都返回nil
+func readUvarint(data []byte) (uint64, []byte) {
+	if data == nil {
+		return 0, nil
+	}
+	v, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, nil
+	}
+	return v, data[n:]
+}
+
+// readVarint 读取有符号varint，数据不完整时返回nil
+func readVarint(data []byte) (int64, []byte) {
+	if data == nil {
+		return 0, nil
+	}
+	v, n := binary.Varint(data)
+	if n <= 0 {
+		return 0, nil
+	}
+	return v, data[n:]
+}
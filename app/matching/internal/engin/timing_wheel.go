@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	wheelBits   = 6
+	wheelSlots  = 1 << wheelBits // 每层的格数
+	wheelMask   = wheelSlots - 1
+	wheelLevels = 5 // 默认100ms一格时可覆盖约3.4年，更远的定时器在最高层循环等待
+)
+
+// wheelTimer 定时器，到期时间已换算为格序号
+type wheelTimer struct {
+	tick   int64
+	symbol string
+}
+
+// timingWheel 分层时间轮，按交易对登记GTD订单的到期时间
+// 时间轮只决定何时提交过期清理命令，订单是否过期由命令中记录的时间戳判断，重放结果与时钟无关
+type timingWheel struct {
+	mu      sync.Mutex
+	tick    int64 // 每格时长(纳秒)
+	current int64 // 已推进到的格序号
+	levels  [wheelLevels][wheelSlots][]wheelTimer
+	due     []wheelTimer // 登记时已到期的定时器，下次推进时返回
+}
+
+// newTimingWheel 创建从now(纳秒)开始计时的时间轮
+func newTimingWheel(tick time.Duration, now int64) *timingWheel {
+	if tick <= 0 {
+		tick = 100 * time.Millisecond
+	}
+	return &timingWheel{
+		tick:    int64(tick),
+		current: now / int64(tick),
+	}
+}
+
+// schedule 登记交易对在at(纳秒)到期的定时器，到期时间向上取整到格，保证不会提前触发
+func (w *timingWheel) schedule(symbol string, at int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.add(wheelTimer{tick: (at + w.tick - 1) / w.tick, symbol: symbol})
+}
+
+// add 按距离当前格的远近放入对应层级(调用方需持有mu)
+func (w *timingWheel) add(timer wheelTimer) {
+	diff := timer.tick - w.current
+	if diff <= 0 {
+		w.due = append(w.due, timer)
+		return
+	}
+
+	level := 0
+	for level < wheelLevels-1 && diff >= int64(1)<<(wheelBits*(level+1)) {
+		level++
+	}
+	slot := (timer.tick >> (wheelBits * level)) & wheelMask
+	w.levels[level][slot] = append(w.levels[level][slot], timer)
+}
+
+// advance 推进到now(纳秒)，返回期间到期的交易对(去重，按到期先后排列)
+func (w *timingWheel) advance(now int64) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	expired := w.due
+	w.due = nil
+
+	target := now / w.tick
+	for w.current < target {
+		w.current++
+
+		// 进入高层的新格时先把该格的定时器下放到低层
+		for level := wheelLevels - 1; level > 0; level-- {
+			if w.current&(int64(1)<<(wheelBits*level)-1) != 0 {
+				continue
+			}
+			slot := (w.current >> (wheelBits * level)) & wheelMask
+			timers := w.levels[level][slot]
+			w.levels[level][slot] = nil
+			for _, timer := range timers {
+				w.add(timer)
+			}
+		}
+
+		slot := w.current & wheelMask
+		expired = append(expired, w.levels[0][slot]...)
+		w.levels[0][slot] = nil
+
+		// 下放时恰好到期的定时器
+		expired = append(expired, w.due...)
+		w.due = nil
+	}
+
+	if len(expired) == 0 {
+		return nil
+	}
+	symbols := make([]string, 0, len(expired))
+	seen := make(map[string]struct{}, len(expired))
+	for _, timer := range expired {
+		if _, ok := seen[timer.symbol]; ok {
+			continue
+		}
+		seen[timer.symbol] = struct{}{}
+		symbols = append(symbols, timer.symbol)
+	}
+	return symbols
+}
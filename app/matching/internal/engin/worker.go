@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"sync"
 	"time"
 	"unsafe"
 
@@ -16,18 +17,27 @@ import (
 type MatchingWorker struct {
 	id          int
 	config      *config.Config
-	orderBooks  map[string]*orderbook.HybridOrderBook
-	inputQueues map[string]*lockfree.RingBuffer
 	outputQueue *lockfree.RingBuffer
+	marketData  *marketHub
 	batchSize   int
+
+	mu    sync.Mutex   // 处理批次期间持有，交易对迁移时保证旧worker已处理完当前批次
+	slots []symbolSlot // 归属本worker的交易对，受mu保护
+}
+
+// symbolSlot worker独占的交易对输入队列及订单簿
+type symbolSlot struct {
+	symbol    string
+	queue     *lockfree.RingBuffer
+	orderBook *orderbook.HybridOrderBook
+	precision types.Precision // 交易对精度，用于监控指标换算
 }
 
 func NewMatchingWorker(
 	id int,
 	cfg *config.Config,
-	orderBooks map[string]*orderbook.HybridOrderBook,
-	inputQueues map[string]*lockfree.RingBuffer,
 	outputQueue *lockfree.RingBuffer,
+	marketData *marketHub,
 ) *MatchingWorker {
 
 	batchSize := cfg.Matching.BatchSize
@@ -38,9 +48,8 @@ func NewMatchingWorker(
 	return &MatchingWorker{
 		id:          id,
 		config:      cfg,
-		orderBooks:  orderBooks,
-		inputQueues: inputQueues,
 		outputQueue: outputQueue,
+		marketData:  marketData,
 		batchSize:   batchSize,
 	}
 }
@@ -59,34 +68,21 @@ func (w *MatchingWorker) Run(ctx context.Context) {
 	}
 }
 
-func (w *MatchingWorker) processBatch(ctx context.Context) {
-	processed := 0
-	startTime := time.Now()
+// Symbols 获取归属本worker的交易对
+func (w *MatchingWorker) Symbols() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 
-	// 轮询所有交易对的队列
-	for symbol, queue := range w.inputQueues {
-		if processed >= w.batchSize {
-			break
-		}
-
-		// 批量获取订单
-		orders := queue.BatchPop(w.batchSize - processed)
-		if len(orders) == 0 {
-			continue
-		}
-
-		// 处理订单
-		for _, orderPtr := range orders {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				order := (*types.Order)(orderPtr)
-				w.processOrder(symbol, order)
-				processed++
-			}
-		}
+	symbols := make([]string, 0, len(w.slots))
+	for _, slot := range w.slots {
+		symbols = append(symbols, slot.symbol)
 	}
+	return symbols
+}
+
+func (w *MatchingWorker) processBatch(ctx context.Context) {
+	startTime := time.Now()
+	processed := w.drain(ctx)
 
 	// 记录处理指标 - 修正这里
 	if processed > 0 {
@@ -102,19 +98,125 @@ func (w *MatchingWorker) processBatch(ctx context.Context) {
 	}
 }
 
-func (w *MatchingWorker) processOrder(symbol string, order *types.Order) {
-	startTime := time.Now()
+// drain 轮询归属本worker的交易对队列，只有本worker会消费这些队列
+func (w *MatchingWorker) drain(ctx context.Context) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	processed := 0
+	for _, slot := range w.slots {
+		if processed >= w.batchSize {
+			break
+		}
+
+		// 批量获取命令
+		commands := slot.queue.BatchPop(w.batchSize - processed)
+
+		// 处理命令，已出队的命令必须处理完，避免交易对迁移后乱序或丢单
+		for _, cmdPtr := range commands {
+			w.processCommand(slot, (*command)(cmdPtr))
+			processed++
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return processed
+}
+
+// processCommand 执行一条命令: 新订单撮合后结果进入输出队列，撤单/改单等直接执行并回复等待方
+func (w *MatchingWorker) processCommand(slot symbolSlot, cmd *command) {
+	if cmd.apply == nil {
+		w.processOrder(slot, cmd.Order, cmd.Timestamp)
+		w.publishDepth(slot, cmd)
+		w.publishOrders(slot, cmd)
+		w.publishIndicative(slot, cmd)
+		return
+	}
+
+	// 下架时撤销的挂单同样推送，订阅方据此清空该交易对
+	reply := cmd.apply(slot.orderBook)
+	w.publishDepth(slot, cmd)
+	w.publishOrders(slot, cmd)
+	if reply.retired {
+		w.removeSlot(slot.symbol)
+	} else {
+		w.publishIndicative(slot, cmd)
+	}
+	if cmd.done != nil {
+		cmd.done <- reply
+	}
+}
+
+// publishDepth 推送命令执行后的增量深度，没有订阅方时也需取出以清空变化记录
+func (w *MatchingWorker) publishDepth(slot symbolSlot, cmd *command) {
+	update, changed := slot.orderBook.DepthUpdate()
+	if w.marketData == nil || !changed {
+		return
+	}
+	w.marketData.publish(&MarketEvent{
+		Type:      MarketEventDepth,
+		Symbol:    slot.symbol,
+		Seq:       cmd.Seq,
+		Timestamp: cmd.Timestamp,
+		Depth:     update,
+	})
+}
+
+// publishOrders 推送命令执行产生的逐笔委托事件，没有订阅方时也需取出
+func (w *MatchingWorker) publishOrders(slot symbolSlot, cmd *command) {
+	events := slot.orderBook.OrderEvents()
+	if w.marketData == nil || len(events) == 0 {
+		return
+	}
+	w.marketData.publish(&MarketEvent{
+		Type:      MarketEventOrders,
+		Symbol:    slot.symbol,
+		Seq:       cmd.Seq,
+		Timestamp: cmd.Timestamp,
+		Orders:    events,
+	})
+}
+
+// publishIndicative 集合竞价期间参考成交价或成交量变化时推送给行情订阅方
+func (w *MatchingWorker) publishIndicative(slot symbolSlot, cmd *command) {
+	if w.marketData == nil || slot.orderBook.Phase() != types.PhaseAuction {
+		return
+	}
 
-	// 获取对应的订单簿
-	orderBook, exists := w.orderBooks[symbol]
-	if !exists {
-		logx.Errorf("Order book not found for symbol: %s", symbol)
-		monitor.RecordOrderRejected(symbol, "symbol_not_found")
+	quote, changed := slot.orderBook.IndicativeChanged()
+	if !changed {
 		return
 	}
+	w.marketData.publish(&MarketEvent{
+		Type:      MarketEventIndicative,
+		Symbol:    slot.symbol,
+		Seq:       cmd.Seq,
+		Timestamp: cmd.Timestamp,
+		Auction:   &quote,
+	})
+}
 
-	// 执行撮合
-	result := orderBook.Match(order)
+// removeSlot 移除已下架的交易对(调用方需持有mu)
+// drain正在遍历旧的slots，因此生成新的切片而不是原地删除
+func (w *MatchingWorker) removeSlot(symbol string) {
+	slots := make([]symbolSlot, 0, len(w.slots))
+	for _, slot := range w.slots {
+		if slot.symbol != symbol {
+			slots = append(slots, slot)
+		}
+	}
+	w.slots = slots
+}
+
+func (w *MatchingWorker) processOrder(slot symbolSlot, order *types.Order, now int64) {
+	startTime := time.Now()
+	symbol := slot.symbol
+
+	// 执行撮合，以命令接收时间作为撮合时间，保证重放结果一致
+	result := slot.orderBook.MatchAt(order, now)
 
 	// 记录撮合延迟
 	matchingLatency := time.Since(startTime)
@@ -122,25 +224,41 @@ func (w *MatchingWorker) processOrder(symbol string, order *types.Order) {
 
 	// 记录交易
 	for _, trade := range result.Trades {
-		monitor.RecordTrade(symbol, trade.Quantity, trade.Price)
+		monitor.RecordTrade(symbol, slot.precision.QtyFloat(trade.Quantity), slot.precision.PriceFloat(trade.Price))
 	}
 
-	// 发送结果到输出队列
-	if len(result.Trades) > 0 || result.Order.Quantity > 0 {
-		resultPtr := unsafe.Pointer(result)
-		if !w.outputQueue.Push(resultPtr) {
-			// 输出队列满，记录警告
-			monitor.RecordOrderRejected(symbol, "output_queue_full")
-			logx.Infof("Output queue full, dropped match result for symbol: %s", symbol)
+	detachResting(result)
 
-			// 归还结果对象到池
-			types.PutMatchResultToPool(result)
-		}
-	} else {
-		// 没有成交也没有剩余，归还结果对象
-		types.PutMatchResultToPool(result)
+	// 发送结果到输出队列，结果及订单对象由结果处理器负责回收
+	resultPtr := unsafe.Pointer(result)
+	if !w.outputQueue.Push(resultPtr) {
+		// 输出队列满，记录警告
+		monitor.RecordOrderRejected(symbol, "output_queue_full")
+		logx.Infof("Output queue full, dropped match result for symbol: %s", symbol)
+
+		releaseMatchResult(result)
+	}
+}
+
+// detachResting 将结果中仍挂在订单簿中的订单替换为副本
+// 结果处理器异步读取结果，挂单随后可能被撤销并归还对象池
+func detachResting(result *types.MatchResult) {
+	for _, triggered := range result.Triggered {
+		detachResting(triggered)
 	}
+	if result.RestingQty > 0 {
+		order := *result.Order
+		result.Order = &order
+	}
+}
 
-	// 归还订单对象到池
-	types.PutOrderToPool(order)
+// releaseMatchResult 回收撮合结果，仍挂在订单簿中的订单不能归还对象池
+func releaseMatchResult(result *types.MatchResult) {
+	for _, triggered := range result.Triggered {
+		releaseMatchResult(triggered)
+	}
+	if result.RestingQty == 0 {
+		types.PutOrderToPool(result.Order)
+	}
+	types.PutMatchResultToPool(result)
 }
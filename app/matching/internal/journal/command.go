@@ -0,0 +1,62 @@
+package journal
+
+import (
+	"encoding/json"
+
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+)
+
+// CommandType 引擎命令类型
+type CommandType uint8
+
+const (
+	CommandNewOrder   CommandType = 1  // 新订单
+	CommandCancel     CommandType = 2  // 撤单
+	CommandAmend      CommandType = 3  // 改单
+	CommandExpire     CommandType = 4  // 清理已过期的GTD订单
+	CommandAddSymbol  CommandType = 5  // 上架交易对
+	CommandHalt       CommandType = 6  // 暂停交易，拒绝新订单
+	CommandResume     CommandType = 7  // 恢复交易
+	CommandDelist     CommandType = 8  // 下架交易对: 撤销全部挂单后移除
+	CommandSetPhase   CommandType = 9  // 切换交易阶段
+	CommandMassCancel CommandType = 10 // 按方向/账户/ClientID批量撤单
+)
+
+// IsSymbolCommand 是否为变更交易对集合或交易状态的命令
+func (t CommandType) IsSymbolCommand() bool {
+	return t >= CommandAddSymbol && t <= CommandSetPhase
+}
+
+// Command 引擎接收的命令，按序号写入日志后才进入交易对输入队列
+// 撮合以Timestamp作为当前时间，重放时得到与首次执行完全一致的订单簿
+type Command struct {
+	Seq       uint64       `json:"seq"`
+	Type      CommandType  `json:"type"`
+	Symbol    string       `json:"symbol"`
+	Timestamp int64        `json:"timestamp"`          // 引擎接收命令的时间(纳秒)
+	Order     *types.Order `json:"order,omitempty"`    // 新订单
+	OrderID   uint64       `json:"orderId,omitempty"`  // 撤单/改单的订单ID
+	Price     int64        `json:"price,omitempty"`    // 改单新价格，0表示不修改
+	Quantity  int64        `json:"quantity,omitempty"` // 改单新的剩余数量，0表示不修改
+	Version   uint32       `json:"version,omitempty"`  // 改单时客户端持有的订单版本
+
+	Instrument *types.InstrumentSpec `json:"instrument,omitempty"` // 上架交易对的规格
+	TradeSeq   uint64                `json:"tradeSeq,omitempty"`   // 上架时成交ID的起始值，重新上架的交易对延续下架前的编号
+	Phase      types.TradingPhase    `json:"phase,omitempty"`      // 切换后的交易阶段
+	Operator   string                `json:"operator,omitempty"`   // 发起交易阶段切换的操作人，用于审计
+	Reason     string                `json:"reason,omitempty"`     // 切换原因
+
+	Side     int8   `json:"side,omitempty"`     // 批量撤单的方向，0表示双边
+	Account  string `json:"account,omitempty"`  // 批量撤单的账户
+	ClientID string `json:"clientId,omitempty"` // 批量撤单的ClientID
+}
+
+// Marshal 编码命令
+func (c *Command) Marshal() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// Unmarshal 解码命令
+func (c *Command) Unmarshal(data []byte) error {
+	return json.Unmarshal(data, c)
+}
@@ -0,0 +1,394 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+const (
+	SyncAlways = "always" // 每条命令写入后fsync
+	SyncBatch  = "batch"  // 按SyncInterval批量fsync
+	SyncNone   = "none"   // 由操作系统决定刷盘时机
+
+	segmentExt    = ".wal"
+	headerSize    = 8       // 4字节记录长度 + 4字节CRC32C
+	maxRecordSize = 1 << 24 // 单条记录上限，超过视为损坏
+)
+
+var (
+	ErrClosed       = errors.New("journal closed")
+	ErrSeqNotAfter  = errors.New("journal sequence must increase")
+	ErrSeqGap       = errors.New("journal sequence gap")
+	ErrCorruptEntry = errors.New("journal entry corrupted")
+
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// Options 日志配置
+type Options struct {
+	Dir          string        // 日志目录
+	SegmentSize  int64         // 单个日志段文件大小上限(字节)
+	SyncMode     string        // 刷盘策略: always/batch/none
+	SyncInterval time.Duration // batch模式的刷盘间隔
+}
+
+// Journal 追加写的分段命令日志
+// 每个日志段以其第一条命令的序号命名，记录格式为 长度(4B) + CRC32C(4B) + 命令编码
+type Journal struct {
+	opts Options
+
+	mu      sync.Mutex
+	file    *os.File // 当前写入的日志段
+	size    int64    // 当前日志段已写入的字节数
+	lastSeq uint64   // 最后一条已写入命令的序号
+	dirty   bool     // 是否有未fsync的写入
+	closed  bool
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// segment 日志段文件
+type segment struct {
+	firstSeq uint64
+	path     string
+}
+
+// Open 打开日志目录，截断最后一个日志段中未写完整的记录
+func Open(opts Options) (*Journal, error) {
+	if opts.SegmentSize <= 0 {
+		opts.SegmentSize = 64 << 20
+	}
+	if opts.SyncInterval <= 0 {
+		opts.SyncInterval = 10 * time.Millisecond
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	j := &Journal{
+		opts: opts,
+		done: make(chan struct{}),
+	}
+
+	segments, err := listSegments(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) > 0 {
+		if err := j.openTail(segments[len(segments)-1]); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.SyncMode == SyncBatch {
+		j.wg.Add(1)
+		threading.GoSafe(func() {
+			defer j.wg.Done()
+			j.runSyncer()
+		})
+	}
+
+	return j, nil
+}
+
+// openTail 打开最后一个日志段用于继续追加
+func (j *Journal) openTail(seg segment) error {
+	j.lastSeq = seg.firstSeq - 1
+	validSize, err := readSegment(seg.path, func(cmd *Command) error {
+		j.lastSeq = cmd.Seq
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(seg.path, os.O_RDWR, 0o644)
+	if err != nil {
+		return err
+	}
+	if info, err := file.Stat(); err == nil && info.Size() > validSize {
+		logx.Infof("Journal segment %s has a torn tail, truncating %d bytes", seg.path, info.Size()-validSize)
+	}
+	if err := file.Truncate(validSize); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Seek(validSize, io.SeekStart); err != nil {
+		file.Close()
+		return err
+	}
+
+	j.file = file
+	j.size = validSize
+	return nil
+}
+
+// Append 追加一条命令，命令序号必须大于已写入的最后序号
+func (j *Journal) Append(cmd *Command) error {
+	payload, err := cmd.Marshal()
+	if err != nil {
+		return err
+	}
+
+	record := make([]byte, headerSize+len(payload))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.Checksum(payload, crcTable))
+	copy(record[headerSize:], payload)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.closed {
+		return ErrClosed
+	}
+	if cmd.Seq <= j.lastSeq {
+		return ErrSeqNotAfter
+	}
+
+	if j.file == nil || (j.size > 0 && j.size+int64(len(record)) > j.opts.SegmentSize) {
+		if err := j.roll(cmd.Seq); err != nil {
+			return err
+		}
+	}
+
+	if _, err := j.file.Write(record); err != nil {
+		// 截掉写了一半的记录，保证后续追加的记录可读
+		_ = j.file.Truncate(j.size)
+		_, _ = j.file.Seek(j.size, io.SeekStart)
+		return err
+	}
+	j.size += int64(len(record))
+	j.lastSeq = cmd.Seq
+
+	switch j.opts.SyncMode {
+	case SyncAlways:
+		return j.file.Sync()
+	case SyncBatch:
+		j.dirty = true
+	}
+	return nil
+}
+
+// roll 关闭当前日志段并创建以firstSeq命名的新日志段
+func (j *Journal) roll(firstSeq uint64) error {
+	if j.file != nil {
+		if err := j.file.Sync(); err != nil {
+			return err
+		}
+		if err := j.file.Close(); err != nil {
+			return err
+		}
+		j.file = nil
+	}
+
+	file, err := os.OpenFile(segmentPath(j.opts.Dir, firstSeq), os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := syncDir(j.opts.Dir); err != nil {
+		file.Close()
+		return err
+	}
+
+	j.file = file
+	j.size = 0
+	j.dirty = false
+	return nil
+}
+
+// Replay 按序号顺序回放序号大于from的命令，序号必须连续
+func (j *Journal) Replay(from uint64, fn func(cmd *Command) error) error {
+	j.mu.Lock()
+	lastSeq := j.lastSeq
+	j.mu.Unlock()
+
+	segments, err := listSegments(j.opts.Dir)
+	if err != nil {
+		return err
+	}
+
+	expected := from + 1
+	for i, seg := range segments {
+		// 下一段的起始序号不大于期望序号时，本段命令都已应用
+		if i+1 < len(segments) && segments[i+1].firstSeq <= expected {
+			continue
+		}
+
+		_, err := readSegment(seg.path, func(cmd *Command) error {
+			if cmd.Seq < expected || cmd.Seq > lastSeq {
+				return nil
+			}
+			if cmd.Seq != expected {
+				return fmt.Errorf("%w: expect %d, got %d", ErrSeqGap, expected, cmd.Seq)
+			}
+			expected++
+			return fn(cmd)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if expected <= lastSeq {
+		return fmt.Errorf("%w: expect %d, journal ends at %d", ErrSeqGap, expected, lastSeq)
+	}
+	return nil
+}
+
+// LastSeq 最后一条已写入命令的序号
+func (j *Journal) LastSeq() uint64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.lastSeq
+}
+
+// Sync 将已写入的命令刷到磁盘
+func (j *Journal) Sync() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil || !j.dirty {
+		return nil
+	}
+	j.dirty = false
+	return j.file.Sync()
+}
+
+// Close 刷盘并关闭日志
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	if j.closed {
+		j.mu.Unlock()
+		return nil
+	}
+	j.closed = true
+
+	var err error
+	if j.file != nil {
+		err = j.file.Sync()
+		if closeErr := j.file.Close(); err == nil {
+			err = closeErr
+		}
+		j.file = nil
+	}
+	j.mu.Unlock()
+
+	close(j.done)
+	j.wg.Wait()
+	return err
+}
+
+// runSyncer batch模式下定期刷盘
+func (j *Journal) runSyncer() {
+	ticker := time.NewTicker(j.opts.SyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-j.done:
+			return
+		case <-ticker.C:
+			if err := j.Sync(); err != nil {
+				logx.Errorf("Journal sync failed: %v", err)
+			}
+		}
+	}
+}
+
+// readSegment 顺序读取日志段中的完整记录，返回最后一条完整记录的结束位置
+// 末尾不完整或校验失败的记录视为写入中断，不再继续读取
+func readSegment(path string, fn func(cmd *Command) error) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	header := make([]byte, headerSize)
+	var offset int64
+
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			return offset, nil
+		}
+
+		length := binary.BigEndian.Uint32(header[0:4])
+		if length == 0 || length > maxRecordSize {
+			return offset, nil
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return offset, nil
+		}
+		if crc32.Checksum(payload, crcTable) != binary.BigEndian.Uint32(header[4:8]) {
+			return offset, nil
+		}
+
+		cmd := &Command{}
+		if err := cmd.Unmarshal(payload); err != nil {
+			return offset, fmt.Errorf("%w: %s at offset %d: %v", ErrCorruptEntry, path, offset, err)
+		}
+		if err := fn(cmd); err != nil {
+			return offset, err
+		}
+		offset += headerSize + int64(length)
+	}
+}
+
+// listSegments 列出目录下的日志段，按起始序号升序
+func listSegments(dir string) ([]segment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []segment
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		firstSeq, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil || firstSeq == 0 {
+			continue
+		}
+		segments = append(segments, segment{firstSeq: firstSeq, path: filepath.Join(dir, name)})
+	}
+
+	sort.Slice(segments, func(i, k int) bool {
+		return segments[i].firstSeq < segments[k].firstSeq
+	})
+	return segments, nil
+}
+
+// segmentPath 日志段文件路径，序号补零保证按文件名排序即按序号排序
+func segmentPath(dir string, firstSeq uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%020d%s", firstSeq, segmentExt))
+}
+
+// syncDir 刷新目录项，保证新建的日志段在宕机后可见
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,112 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+)
+
+func appendCommands(t *testing.T, j *Journal, from, to uint64) {
+	t.Helper()
+	for seq := from; seq <= to; seq++ {
+		cmd := &Command{
+			Seq:    seq,
+			Type:   CommandNewOrder,
+			Symbol: "BTCUSDT",
+			Order:  &types.Order{ID: seq, Symbol: "BTCUSDT", Price: 50000, Quantity: 10},
+		}
+		if err := j.Append(cmd); err != nil {
+			t.Fatalf("Append %d: %v", seq, err)
+		}
+	}
+}
+
+func replaySeqs(t *testing.T, j *Journal, from uint64) []uint64 {
+	t.Helper()
+	var seqs []uint64
+	err := j.Replay(from, func(cmd *Command) error {
+		if cmd.Order == nil || cmd.Order.ID != cmd.Seq {
+			t.Errorf("Unexpected command %+v", cmd)
+		}
+		seqs = append(seqs, cmd.Seq)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	return seqs
+}
+
+func TestJournal_AppendReplay(t *testing.T) {
+	dir := t.TempDir()
+	j, err := Open(Options{Dir: dir, SegmentSize: 512, SyncMode: SyncAlways})
+	if err != nil {
+		t.Fatal(err)
+	}
+	appendCommands(t, j, 1, 20)
+
+	if err := j.Append(&Command{Seq: 20}); !errors.Is(err, ErrSeqNotAfter) {
+		t.Errorf("Expected ErrSeqNotAfter, got %v", err)
+	}
+
+	segments, _ := listSegments(dir)
+	if len(segments) < 2 {
+		t.Errorf("Expected journal to roll into several segments, got %d", len(segments))
+	}
+	if err := j.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 重新打开后从最后序号继续追加
+	j, err = Open(Options{Dir: dir, SegmentSize: 512, SyncMode: SyncBatch})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	if j.LastSeq() != 20 {
+		t.Fatalf("Expected last seq 20, got %d", j.LastSeq())
+	}
+	appendCommands(t, j, 21, 25)
+
+	if seqs := replaySeqs(t, j, 0); len(seqs) != 25 || seqs[0] != 1 || seqs[24] != 25 {
+		t.Errorf("Expected seqs 1..25, got %v", seqs)
+	}
+	if seqs := replaySeqs(t, j, 18); len(seqs) != 7 || seqs[0] != 19 {
+		t.Errorf("Expected seqs 19..25, got %v", seqs)
+	}
+}
+
+func TestJournal_TruncateTornTail(t *testing.T) {
+	dir := t.TempDir()
+	j, err := Open(Options{Dir: dir, SyncMode: SyncNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	appendCommands(t, j, 1, 3)
+	j.Close()
+
+	// 模拟写入最后一条记录时宕机
+	segments, _ := listSegments(dir)
+	path := segments[len(segments)-1].path
+	info, _ := os.Stat(path)
+	if err := os.Truncate(path, info.Size()-5); err != nil {
+		t.Fatal(err)
+	}
+
+	j, err = Open(Options{Dir: dir, SyncMode: SyncNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	if j.LastSeq() != 2 {
+		t.Fatalf("Expected torn record to be dropped, last seq %d", j.LastSeq())
+	}
+	appendCommands(t, j, 3, 4)
+	if seqs := replaySeqs(t, j, 0); len(seqs) != 4 {
+		t.Errorf("Expected seqs 1..4 after truncation, got %v", seqs)
+	}
+}
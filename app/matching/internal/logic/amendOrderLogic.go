@@ -0,0 +1,62 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/tsfdsong/tradeengin/app/matching/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/matching/match"
+)
+
+type AmendOrderLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewAmendOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *AmendOrderLogic {
+	return &AmendOrderLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *AmendOrderLogic) AmendOrder(in *match.AmendOrderRequest) (*match.AmendOrderResponse, error) {
+	fail := func(err error) (*match.AmendOrderResponse, error) {
+		return &match.AmendOrderResponse{
+			Success: false,
+			Message: err.Error(),
+			OrderId: in.OrderId,
+			Code:    toOrderErrCode(err),
+		}, nil
+	}
+
+	precision, err := l.svcCtx.Engine.GetPrecision(in.Symbol)
+	if err != nil {
+		return fail(err)
+	}
+	price, err := precision.ParsePrice(in.Price)
+	if err != nil {
+		return fail(err)
+	}
+	quantity, err := precision.ParseQty(in.Quantity)
+	if err != nil {
+		return fail(err)
+	}
+
+	result, err := l.svcCtx.Engine.AmendOrder(in.OrderId, in.Symbol, price, quantity, in.Version)
+	if err != nil {
+		return fail(err)
+	}
+
+	var trades []*match.Trade
+	for _, trade := range result.Trades {
+		trades = append(trades, toMatchTrade(&trade, precision))
+	}
+
+	return &match.AmendOrderResponse{
+		Success:         true,
+		OrderId:         result.OrderID,
+		Version:         result.Version,
+		RestingQuantity: precision.FormatQty(result.RestingQty),
+		Trades:          trades,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/tsfdsong/tradeengin/app/matching/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/matching/match"
+)
+
+type CancelOrderLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCancelOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelOrderLogic {
+	return &CancelOrderLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CancelOrderLogic) CancelOrder(in *match.CancelOrderRequest) (*match.CancelOrderResponse, error) {
+	success, err := l.svcCtx.Engine.CancelOrder(in.OrderId, in.Symbol)
+	if err != nil {
+		return &match.CancelOrderResponse{
+			Success: false,
+			Message: err.Error(),
+			OrderId: in.OrderId,
+			Code:    toOrderErrCode(err),
+		}, nil
+	}
+
+	return &match.CancelOrderResponse{
+		Success: success,
+		OrderId: in.OrderId,
+	}, nil
+}
@@ -0,0 +1,215 @@
+package logic
+
+import (
+	"github.com/pkg/errors"
+	engine "github.com/tsfdsong/tradeengin/app/matching/internal/engin"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/orderbook"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/matching/match"
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+	"github.com/tsfdsong/tradeengin/app/pkg/xerr"
+)
+
+// toOrder 将请求订单的十进制字符串按交易对精度转换为整数最小单位
+func toOrder(in *match.Order, precision types.Precision) (*types.Order, error) {
+	price, err := precision.ParsePrice(in.Price)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := precision.ParseQty(in.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	stopPrice, err := precision.ParsePrice(in.StopPrice)
+	if err != nil {
+		return nil, err
+	}
+	displayQty, err := precision.ParseQty(in.DisplayQty)
+	if err != nil {
+		return nil, err
+	}
+	quoteQty, err := precision.ParseQuote(in.QuoteQty)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Order{
+		ID:          in.Id,
+		Symbol:      in.Symbol,
+		Price:       price,
+		Quantity:    quantity,
+		Side:        int8(in.Side),
+		Type:        int8(in.Type),
+		Timestamp:   in.Timestamp,
+		ClientID:    in.ClientId,
+		Account:     in.Account,
+		TimeInForce: int8(in.TimeInForce),
+		ExpireTime:  in.ExpireTime,
+		PostOnly:    int8(in.PostOnly),
+		StopPrice:   stopPrice,
+		DisplayQty:  displayQty,
+		STPMode:     int8(in.StpMode),
+		QuoteQty:    quoteQty,
+	}, nil
+}
+
+// toMatchTrade 成交转换为十进制字符串表示
+func toMatchTrade(trade *types.Trade, precision types.Precision) *match.Trade {
+	return &match.Trade{
+		TradeId:      trade.TradeID,
+		TakerOrderId: trade.TakerOrderID,
+		MakerOrderId: trade.MakerOrderID,
+		Symbol:       trade.Symbol,
+		Price:        precision.FormatPrice(trade.Price),
+		Quantity:     precision.FormatQty(trade.Quantity),
+		Timestamp:    trade.Timestamp,
+		TakerSide:    int32(trade.TakerSide),
+	}
+}
+
+// toInstrumentInfo 将交易对规格转换为十进制字符串表示
+func toInstrumentInfo(spec types.InstrumentSpec) *match.InstrumentInfo {
+	p := spec.Precision
+	return &match.InstrumentInfo{
+		Symbol:        spec.Symbol,
+		Status:        spec.Status,
+		PriceScale:    p.PriceScale,
+		QuantityScale: p.QuantityScale,
+		TickSize:      p.FormatPrice(spec.TickSize),
+		LotSize:       p.FormatQty(spec.LotSize),
+		MinQty:        p.FormatQty(spec.MinQty),
+		MaxQty:        p.FormatQty(spec.MaxQty),
+		MinNotional:   p.FormatQuote(spec.MinNotional),
+	}
+}
+
+// toReplicationStatus 转换复制状态
+func toReplicationStatus(svcCtx *svc.ServiceContext) *match.ReplicationStatusResponse {
+	status := svcCtx.Engine.ReplicationStatus()
+
+	followers := make([]*match.FollowerStatus, 0, len(status.Followers))
+	for _, f := range status.Followers {
+		followers = append(followers, &match.FollowerStatus{
+			FollowerId:  f.ID,
+			AckedSeq:    f.AckedSeq,
+			Lag:         f.Lag,
+			ConnectedAt: f.ConnectedAt,
+		})
+	}
+
+	return &match.ReplicationStatusResponse{
+		Role:       status.Role,
+		LastSeq:    status.LastSeq,
+		Followers:  followers,
+		Connected:  status.Connected,
+		PrimarySeq: status.PrimarySeq,
+		Lag:        status.Lag,
+		LagMs:      status.LagTime.Milliseconds(),
+	}
+}
+
+// toPhaseChange 转换交易阶段切换记录，withOperator为false时不返回操作人
+func toPhaseChange(change *engine.PhaseChange, withOperator bool) *match.PhaseChange {
+	pc := &match.PhaseChange{
+		Symbol:    change.Symbol,
+		From:      change.From.Status(),
+		To:        change.To.Status(),
+		Seq:       change.Seq,
+		Timestamp: change.Timestamp,
+		Reason:    change.Reason,
+	}
+	if withOperator {
+		pc.Operator = change.Operator
+	}
+	return pc
+}
+
+// toMarketDataEvent 转换推送给行情订阅方的事件，集合竞价报价及成交按交易对精度转换
+func toMarketDataEvent(event *engine.MarketEvent, precision types.Precision) *match.MarketDataEvent {
+	ev := &match.MarketDataEvent{
+		Type:      int32(event.Type),
+		Symbol:    event.Symbol,
+		Seq:       event.Seq,
+		Timestamp: event.Timestamp,
+	}
+	if event.Phase != nil {
+		ev.Phase = toPhaseChange(event.Phase, false)
+	}
+	if event.Auction != nil {
+		ev.Auction = &match.AuctionQuote{
+			Price:     precision.FormatPrice(event.Auction.Price),
+			Volume:    precision.FormatQty(event.Auction.Volume),
+			Imbalance: precision.FormatQty(event.Auction.Imbalance),
+		}
+	}
+	for i := range event.Trades {
+		ev.Trades = append(ev.Trades, toMatchTrade(&event.Trades[i], precision))
+	}
+	if event.Depth != nil {
+		ev.Depth = &match.DepthUpdate{
+			FirstUpdateId: event.Depth.FirstUpdateID,
+			LastUpdateId:  event.Depth.LastUpdateID,
+			Bids:          toPriceLevels(event.Depth.Bids, precision),
+			Asks:          toPriceLevels(event.Depth.Asks, precision),
+		}
+	}
+	return ev
+}
+
+// toOrderFeedEvent 转换逐笔委托事件，订单簿事件本身不含账户信息
+func toOrderFeedEvent(event *engine.MarketEvent, precision types.Precision) *match.OrderFeedEvent {
+	ev := &match.OrderFeedEvent{
+		Symbol:    event.Symbol,
+		Seq:       event.Seq,
+		Timestamp: event.Timestamp,
+		Entries:   make([]*match.OrderFeedEntry, 0, len(event.Orders)),
+	}
+	for _, order := range event.Orders {
+		entry := &match.OrderFeedEntry{
+			Seq:      order.Seq,
+			Type:     int32(order.Type),
+			OrderId:  order.OrderID,
+			Side:     int32(order.Side),
+			Price:    precision.FormatPrice(order.Price),
+			Quantity: precision.FormatQty(order.Quantity),
+		}
+		if order.Type == orderbook.OrderEventExecute {
+			entry.ExecQty = precision.FormatQty(order.ExecQty)
+			entry.TradeId = order.TradeID
+		}
+		ev.Entries = append(ev.Entries, entry)
+	}
+	return ev
+}
+
+// toPriceLevels 按交易对精度格式化价格层级
+func toPriceLevels(levels []types.PriceLevel, precision types.Precision) []*match.PriceLevel {
+	result := make([]*match.PriceLevel, 0, len(levels))
+	for _, level := range levels {
+		result = append(result, &match.PriceLevel{
+			Price:      precision.FormatPrice(level.Price),
+			Quantity:   precision.FormatQty(level.Quantity),
+			OrderCount: int32(level.Count),
+		})
+	}
+	return result
+}
+
+// toOrderErrCode 撤单/改单失败的错误码
+func toOrderErrCode(err error) uint32 {
+	cause := errors.Cause(err)
+	if code, ok := instrumentErrCodes[cause]; ok {
+		return code
+	}
+	switch cause {
+	case engine.ErrPostOnlyWouldTake, engine.ErrAmendWouldTake:
+		return xerr.ORDER_POST_ONLY_REJECT
+	case engine.ErrNotPrimary:
+		return xerr.MATCH_NOT_PRIMARY
+	case engine.ErrStopOrderAmend:
+		return xerr.ORDER_STOP_AMEND
+	case engine.ErrOrderNotFound, engine.ErrVersionConflict, engine.ErrSymbolNotFound:
+		return xerr.REUQEST_PARAM_ERROR
+	}
+	return xerr.SERVER_COMMON_ERROR
+}
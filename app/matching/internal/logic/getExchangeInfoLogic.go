@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/matching/match"
+	"github.com/tsfdsong/tradeengin/app/pkg/xerr"
+)
+
+type GetExchangeInfoLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetExchangeInfoLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetExchangeInfoLogic {
+	return &GetExchangeInfoLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetExchangeInfoLogic) GetExchangeInfo(in *match.ExchangeInfoRequest) (*match.ExchangeInfoResponse, error) {
+	specs, err := l.svcCtx.Engine.GetInstruments(in.Symbol)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.REUQEST_PARAM_ERROR), "get exchange info failed: %+v, err: %v", in, err)
+	}
+
+	instruments := make([]*match.InstrumentInfo, 0, len(specs))
+	for _, spec := range specs {
+		instruments = append(instruments, toInstrumentInfo(spec))
+	}
+
+	return &match.ExchangeInfoResponse{
+		Instruments: instruments,
+		Timestamp:   time.Now().UnixNano(),
+	}, nil
+}
@@ -26,30 +26,16 @@ func (l *GetOrderBookLogic) GetOrderBook(in *match.OrderBookRequest) (*match.Ord
 	if err != nil {
 		return nil, errors.Wrapf(xerr.NewErrMsg("get orderbook failed"), "get orderbook failed: %+v, err: %v", in, err)
 	}
-
-	var bids []*match.PriceLevel
-	var asks []*match.PriceLevel
-
-	for _, bid := range orderBook.Bids {
-		bids = append(bids, &match.PriceLevel{
-			Price:      bid.Price,
-			Quantity:   bid.Quantity,
-			OrderCount: int32(bid.Count),
-		})
-	}
-
-	for _, ask := range orderBook.Asks {
-		asks = append(asks, &match.PriceLevel{
-			Price:      ask.Price,
-			Quantity:   ask.Quantity,
-			OrderCount: int32(ask.Count),
-		})
+	precision, err := l.svcCtx.Engine.GetPrecision(in.Symbol)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrMsg("get orderbook failed"), "get precision failed: %+v, err: %v", in, err)
 	}
 
 	return &match.OrderBookSnapshot{
 		Symbol:    orderBook.Symbol,
-		Bids:      bids,
-		Asks:      asks,
+		Bids:      toPriceLevels(orderBook.Bids, precision),
+		Asks:      toPriceLevels(orderBook.Asks, precision),
 		Timestamp: orderBook.Time,
+		UpdateId:  orderBook.UpdateID,
 	}, nil
 }
@@ -0,0 +1,24 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/tsfdsong/tradeengin/app/matching/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/matching/match"
+)
+
+type GetReplicationStatusLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetReplicationStatusLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetReplicationStatusLogic {
+	return &GetReplicationStatusLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetReplicationStatusLogic) GetReplicationStatus(in *match.ReplicationStatusRequest) (*match.ReplicationStatusResponse, error) {
+	return toReplicationStatus(l.svcCtx), nil
+}
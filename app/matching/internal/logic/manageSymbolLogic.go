@@ -0,0 +1,136 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/config"
+	engine "github.com/tsfdsong/tradeengin/app/matching/internal/engin"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/matching/match"
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+	"github.com/tsfdsong/tradeengin/app/pkg/xerr"
+)
+
+// 交易对管理操作
+const (
+	symbolActionList   = 0 // 查询全部交易对
+	symbolActionAdd    = 1 // 上架
+	symbolActionHalt   = 2 // 暂停交易
+	symbolActionResume = 3 // 恢复交易
+	symbolActionDelist = 4 // 下架
+	symbolActionPhase  = 5 // 切换交易阶段
+	symbolActionPhases = 6 // 查询交易阶段切换记录
+)
+
+type ManageSymbolLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewManageSymbolLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ManageSymbolLogic {
+	return &ManageSymbolLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ManageSymbol 运行中上架/暂停/恢复/下架交易对及切换交易阶段，操作写入命令日志并复制给follower
+func (l *ManageSymbolLogic) ManageSymbol(in *match.ManageSymbolRequest) (*match.ManageSymbolResponse, error) {
+	resp := &match.ManageSymbolResponse{}
+
+	var err error
+	switch in.Action {
+	case symbolActionList:
+		for _, info := range l.svcCtx.Engine.ListSymbols() {
+			resp.Symbols = append(resp.Symbols, toSymbolInfo(info))
+		}
+		return resp, nil
+	case symbolActionAdd:
+		spec, parseErr := toInstrumentSpec(in)
+		if parseErr != nil {
+			return nil, errors.Wrapf(xerr.NewErrCode(xerr.REUQEST_PARAM_ERROR), "invalid instrument: %+v, err: %v", in, parseErr)
+		}
+		err = l.svcCtx.Engine.AddSymbol(spec)
+	case symbolActionHalt:
+		err = l.svcCtx.Engine.HaltSymbol(in.Symbol, in.Operator, in.Reason)
+	case symbolActionResume:
+		err = l.svcCtx.Engine.ResumeSymbol(in.Symbol, in.Operator, in.Reason)
+	case symbolActionPhase:
+		phase, ok := types.PhaseOf(in.Phase)
+		if !ok {
+			return nil, errors.Wrapf(xerr.NewErrCode(xerr.REUQEST_PARAM_ERROR), "invalid trading phase: %+v", in)
+		}
+		err = l.svcCtx.Engine.SetTradingPhase(in.Symbol, phase, in.Operator, in.Reason)
+	case symbolActionPhases:
+		for _, change := range l.svcCtx.Engine.PhaseChanges(in.Symbol) {
+			resp.PhaseChanges = append(resp.PhaseChanges, toPhaseChange(&change, true))
+		}
+		return resp, nil
+	case symbolActionDelist:
+		var result *engine.DelistResult
+		if result, err = l.svcCtx.Engine.DelistSymbol(in.Symbol); err == nil {
+			resp.CancelledOrders = int32(result.Cancelled)
+			resp.FinalSeq = result.Seq
+			resp.Archived = result.Archived
+		}
+	default:
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.REUQEST_PARAM_ERROR), "unknown symbol action: %+v", in)
+	}
+	if err != nil {
+		return nil, toManageSymbolErr(in, err)
+	}
+
+	for _, info := range l.svcCtx.Engine.ListSymbols() {
+		if info.Spec.Symbol == in.Symbol {
+			resp.Symbols = append(resp.Symbols, toSymbolInfo(info))
+		}
+	}
+	return resp, nil
+}
+
+// toInstrumentSpec 按请求中的精度将上架规格换算为整数最小单位
+func toInstrumentSpec(in *match.ManageSymbolRequest) (types.InstrumentSpec, error) {
+	inst := in.Instrument
+	if inst == nil {
+		return types.InstrumentSpec{}, errors.New("missing instrument")
+	}
+	if inst.Symbol != "" && inst.Symbol != in.Symbol {
+		return types.InstrumentSpec{}, errors.Errorf("instrument symbol %s does not match %s", inst.Symbol, in.Symbol)
+	}
+
+	return config.InstrumentConfig{
+		PriceScale:    inst.PriceScale,
+		QuantityScale: inst.QuantityScale,
+		TickSize:      inst.TickSize,
+		LotSize:       inst.LotSize,
+		MinQty:        inst.MinQty,
+		MaxQty:        inst.MaxQty,
+		MinNotional:   inst.MinNotional,
+		Status:        inst.Status,
+	}.Spec(in.Symbol)
+}
+
+// toSymbolInfo 转换交易对运行状态
+func toSymbolInfo(info engine.SymbolInfo) *match.SymbolInfo {
+	return &match.SymbolInfo{
+		Instrument: toInstrumentInfo(info.Spec),
+		Worker:     int32(info.Worker),
+		QueueSize:  info.QueueSize,
+		OrderCount: int32(info.OrderCount),
+	}
+}
+
+// toManageSymbolErr 将引擎错误转换为错误码
+func toManageSymbolErr(in *match.ManageSymbolRequest, err error) error {
+	switch {
+	case errors.Is(err, engine.ErrNotPrimary):
+		return errors.Wrapf(xerr.NewErrCode(xerr.MATCH_NOT_PRIMARY), "engine is not primary: %+v", in)
+	case errors.Is(err, engine.ErrSymbolExists):
+		return errors.Wrapf(xerr.NewErrCode(xerr.MATCH_SYMBOL_EXISTS), "symbol exists: %+v", in)
+	case errors.Is(err, engine.ErrSymbolNotFound), errors.Is(err, engine.ErrInvalidSymbol),
+		errors.Is(err, engine.ErrInvalidPhase):
+		return errors.Wrapf(xerr.NewErrCode(xerr.REUQEST_PARAM_ERROR), "manage symbol failed: %+v, err: %v", in, err)
+	}
+	return errors.Wrapf(xerr.NewErrMsg("server internal error"), "manage symbol failed: %+v, err: %v", in, err)
+}
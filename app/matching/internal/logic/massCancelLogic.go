@@ -0,0 +1,55 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	engine "github.com/tsfdsong/tradeengin/app/matching/internal/engin"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/orderbook"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/matching/match"
+	"github.com/tsfdsong/tradeengin/app/pkg/xerr"
+)
+
+type MassCancelLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewMassCancelLogic(ctx context.Context, svcCtx *svc.ServiceContext) *MassCancelLogic {
+	return &MassCancelLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// MassCancel 按交易对/方向/账户/ClientID批量撤单，做市商报价失效或风控平仓时使用
+func (l *MassCancelLogic) MassCancel(in *match.MassCancelRequest) (*match.MassCancelResponse, error) {
+	result, err := l.svcCtx.Engine.MassCancel(in.Symbol, orderbook.CancelFilter{
+		Side:     int8(in.Side),
+		Account:  in.Account,
+		ClientID: in.ClientId,
+	})
+	if err != nil {
+		return nil, toMassCancelErr(in, err)
+	}
+
+	return &match.MassCancelResponse{
+		OrderIds:      result.OrderIDs,
+		FailedSymbols: result.FailedSymbols,
+	}, nil
+}
+
+// toMassCancelErr 将引擎错误转换为错误码
+func toMassCancelErr(in *match.MassCancelRequest, err error) error {
+	switch {
+	case errors.Is(err, engine.ErrNotPrimary):
+		return errors.Wrapf(xerr.NewErrCode(xerr.MATCH_NOT_PRIMARY), "engine is not primary: %+v", in)
+	case errors.Is(err, engine.ErrInvalidCancelFilter), errors.Is(err, engine.ErrSymbolNotFound):
+		return errors.Wrapf(xerr.NewErrCode(xerr.REUQEST_PARAM_ERROR), "mass cancel failed: %+v, err: %v", in, err)
+	}
+	if code := toOrderErrCode(err); code != xerr.SERVER_COMMON_ERROR {
+		return errors.Wrapf(xerr.NewErrCode(code), "mass cancel rejected: %+v, err: %v", in, err)
+	}
+	return errors.Wrapf(xerr.NewErrMsg("server internal error"), "mass cancel failed: %+v, err: %v", in, err)
+}
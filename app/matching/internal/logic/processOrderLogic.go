@@ -2,14 +2,29 @@ package logic
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
+	engine "github.com/tsfdsong/tradeengin/app/matching/internal/engin"
 	"github.com/tsfdsong/tradeengin/app/matching/internal/svc"
 	"github.com/tsfdsong/tradeengin/app/matching/match"
-	"github.com/tsfdsong/tradeengin/app/pkg/types"
 	"github.com/tsfdsong/tradeengin/app/pkg/xerr"
 )
 
+// instrumentErrCodes 交易对规格校验错误 -> 业务错误码
+var instrumentErrCodes = map[error]uint32{
+	engine.ErrSymbolHalted:     xerr.ORDER_SYMBOL_HALTED,
+	engine.ErrSymbolCancelOnly: xerr.ORDER_SYMBOL_CANCEL_ONLY,
+	engine.ErrSymbolPostOnly:   xerr.ORDER_SYMBOL_POST_ONLY,
+	engine.ErrSymbolAuction:    xerr.ORDER_SYMBOL_AUCTION,
+	engine.ErrInvalidTickSize:  xerr.ORDER_INVALID_TICK_SIZE,
+	engine.ErrInvalidLotSize:   xerr.ORDER_INVALID_LOT_SIZE,
+	engine.ErrQtyBelowMin:      xerr.ORDER_QTY_BELOW_MIN,
+	engine.ErrQtyAboveMax:      xerr.ORDER_QTY_ABOVE_MAX,
+	engine.ErrNotionalBelowMin: xerr.ORDER_NOTIONAL_BELOW_MIN,
+	engine.ErrNotionalOverflow: xerr.ORDER_NOTIONAL_OVERFLOW,
+}
+
 type ProcessOrderLogic struct {
 	ctx    context.Context
 	svcCtx *svc.ServiceContext
@@ -23,41 +38,85 @@ func NewProcessOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Proc
 }
 
 func (l *ProcessOrderLogic) ProcessOrder(in *match.Order) (*match.MatchResult, error) {
-	// 转换订单类型
-	order := &types.Order{
-		ID:        in.Id,
-		Symbol:    in.Symbol,
-		Price:     in.Price,
-		Quantity:  in.Quantity,
-		Side:      int8(in.Side),
-		Type:      int8(in.Type),
-		Timestamp: in.Timestamp,
-		ClientID:  in.ClientId,
-	}
-
-	// 处理订单
-	result, err := l.svcCtx.Engine.ProcessOrder(order)
+	precision, err := l.svcCtx.Engine.GetPrecision(in.Symbol)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.REUQEST_PARAM_ERROR), "unknown symbol: %+v, err: %v", in, err)
+	}
+
+	// 转换订单类型，价格数量按交易对精度转换为整数
+	order, err := toOrder(in, precision)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.REUQEST_PARAM_ERROR), "invalid decimal in order: %+v, err: %v", in, err)
+	}
+
+	// 异步下单入队即返回，撮合结果通过查询订单获取
+	if in.Async {
+		if err := l.svcCtx.Engine.SubmitOrder(order); err != nil {
+			return nil, toProcessErr(in, err)
+		}
+		return &match.MatchResult{
+			Order:     in,
+			Timestamp: time.Now().UnixNano(),
+			Status:    int32(engine.OrderStatusPending),
+		}, nil
+	}
+
+	// 同步下单，等待撮合完成
+	processResult, err := l.svcCtx.Engine.ProcessOrder(order)
 	if err != nil {
-		return nil, errors.Wrapf(xerr.NewErrMsg("server internal error"), "engin process order failed: %+v, err: %v", in, err)
+		return nil, toProcessErr(in, err)
 	}
+	result := processResult.Result
 
 	// 转换结果类型
 	var trades []*match.Trade
 	for _, trade := range result.Trades {
-		trades = append(trades, &match.Trade{
-			TradeId:      trade.TradeID,
-			TakerOrderId: trade.TakerOrderID,
-			MakerOrderId: trade.MakerOrderID,
-			Symbol:       trade.Symbol,
-			Price:        trade.Price,
-			Quantity:     trade.Quantity,
-			Timestamp:    trade.Timestamp,
+		trades = append(trades, toMatchTrade(trade, precision))
+	}
+
+	var selfTrades []*match.SelfTradeEvent
+	for _, event := range result.SelfTrades {
+		selfTrades = append(selfTrades, &match.SelfTradeEvent{
+			TakerOrderId:      event.TakerOrderID,
+			MakerOrderId:      event.MakerOrderID,
+			Symbol:            event.Symbol,
+			ClientId:          event.ClientID,
+			Price:             precision.FormatPrice(event.Price),
+			Mode:              int32(event.Mode),
+			TakerCancelledQty: precision.FormatQty(event.TakerCancelledQty),
+			MakerCancelledQty: precision.FormatQty(event.MakerCancelledQty),
+			MakerRemoved:      event.MakerRemoved,
+			Timestamp:         event.Timestamp,
 		})
 	}
 
 	return &match.MatchResult{
-		Trades:    trades,
-		Order:     in,
-		Timestamp: result.Timestamp,
+		Trades:         trades,
+		Order:          in,
+		Timestamp:      result.Timestamp,
+		SelfTrades:     selfTrades,
+		RemainingQuote: precision.FormatQuote(result.RemainingQuote),
+		Status:         int32(processResult.Status),
+		FilledQuantity: precision.FormatQty(processResult.FilledQty),
 	}, nil
 }
+
+// toProcessErr 撮合引擎下单错误转换为业务错误码
+func toProcessErr(in *match.Order, err error) error {
+	if errors.Is(err, engine.ErrPostOnlyWouldTake) {
+		return errors.Wrapf(xerr.NewErrCode(xerr.ORDER_POST_ONLY_REJECT), "post-only order rejected: %+v", in)
+	}
+	if errors.Is(err, engine.ErrProcessTimeout) {
+		return errors.Wrapf(xerr.NewErrCode(xerr.ORDER_MATCH_TIMEOUT), "wait match result timeout: %+v", in)
+	}
+	if errors.Is(err, engine.ErrReplicationTimeout) {
+		return errors.Wrapf(xerr.NewErrCode(xerr.ORDER_REPLICATION_TIMEOUT), "wait follower ack timeout: %+v", in)
+	}
+	if errors.Is(err, engine.ErrNotPrimary) {
+		return errors.Wrapf(xerr.NewErrCode(xerr.MATCH_NOT_PRIMARY), "engine is not primary: %+v", in)
+	}
+	if code, ok := instrumentErrCodes[errors.Cause(err)]; ok {
+		return errors.Wrapf(xerr.NewErrCode(code), "order rejected by instrument spec: %+v, err: %v", in, err)
+	}
+	return errors.Wrapf(xerr.NewErrMsg("server internal error"), "engin process order failed: %+v, err: %v", in, err)
+}
@@ -0,0 +1,30 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/matching/match"
+	"github.com/tsfdsong/tradeengin/app/pkg/xerr"
+)
+
+type PromoteLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPromoteLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PromoteLogic {
+	return &PromoteLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// Promote follower接管为primary，停止复制并开始接收下单请求
+func (l *PromoteLogic) Promote(in *match.PromoteRequest) (*match.ReplicationStatusResponse, error) {
+	if err := l.svcCtx.Engine.Promote(); err != nil {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.REUQEST_PARAM_ERROR), "promote failed: %v", err)
+	}
+	return toReplicationStatus(l.svcCtx), nil
+}
@@ -0,0 +1,45 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/matching/match"
+	"github.com/tsfdsong/tradeengin/app/pkg/xerr"
+)
+
+type QueryOrderLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewQueryOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *QueryOrderLogic {
+	return &QueryOrderLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *QueryOrderLogic) QueryOrder(in *match.QueryOrderRequest) (*match.QueryOrderResponse, error) {
+	state, err := l.svcCtx.Engine.GetOrderState(in.OrderId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrMsg("order not found"), "query order failed: %+v, err: %v", in, err)
+	}
+	precision, err := l.svcCtx.Engine.GetPrecision(state.Symbol)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewErrMsg("order not found"), "get precision failed: %+v, err: %v", in, err)
+	}
+
+	return &match.QueryOrderResponse{
+		Order: &match.Order{
+			Id:        state.OrderID,
+			Symbol:    state.Symbol,
+			Quantity:  precision.FormatQty(state.OriginalQty),
+			Timestamp: state.CreateTime,
+		},
+		Status:         int32(state.Status),
+		FilledQuantity: precision.FormatQty(state.FilledQuantity),
+		Version:        state.Version,
+	}, nil
+}
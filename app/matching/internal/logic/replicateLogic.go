@@ -0,0 +1,68 @@
+package logic
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/matching/match"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ReplicateLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewReplicateLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ReplicateLogic {
+	return &ReplicateLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// Replicate 向follower推送命令日志，同时接收follower的确认
+func (l *ReplicateLogic) Replicate(stream match.MatchService_ReplicateServer) error {
+	hello, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	replica, err := l.svcCtx.Engine.Subscribe(hello.FollowerId, hello.FromSeq)
+	if err != nil {
+		// 流式接口没有错误转换拦截器，直接返回gRPC状态
+		return status.Errorf(codes.FailedPrecondition, "subscribe follower %s from seq %d: %v", hello.FollowerId, hello.FromSeq, err)
+	}
+	defer l.svcCtx.Engine.Unsubscribe(replica)
+
+	// 单独协程接收follower确认，连接断开时结束推送
+	threading.GoSafe(func() {
+		for {
+			ack, err := stream.Recv()
+			if err != nil {
+				replica.Close(err)
+				return
+			}
+			replica.Ack(ack.AppliedSeq)
+		}
+	})
+
+	err = replica.Stream(l.ctx, func(seq uint64, data []byte) error {
+		return stream.Send(&match.ReplicationEntry{
+			Seq:        seq,
+			Command:    data,
+			PrimarySeq: l.svcCtx.Engine.LastSeq(),
+		})
+	})
+	if errors.Is(err, io.EOF) || errors.Is(err, context.Canceled) {
+		return nil
+	}
+	if err != nil {
+		logx.WithContext(l.ctx).Errorf("replicate to follower %s failed: %v", hello.FollowerId, err)
+	}
+	return err
+}
@@ -0,0 +1,38 @@
+package logic
+
+import (
+	"context"
+
+	engine "github.com/tsfdsong/tradeengin/app/matching/internal/engin"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/matching/match"
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+)
+
+type SubscribeMarketDataLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSubscribeMarketDataLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SubscribeMarketDataLogic {
+	return &SubscribeMarketDataLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// SubscribeMarketData 按执行顺序推送交易对的行情事件，订阅方跟不上时断开
+func (l *SubscribeMarketDataLogic) SubscribeMarketData(in *match.MarketDataRequest, stream match.MatchService_SubscribeMarketDataServer) error {
+	sub := l.svcCtx.Engine.SubscribeMarketData(in.Symbols, in.Depth)
+	defer sub.Close()
+
+	precisions := make(map[string]types.Precision)
+	return sub.Stream(l.ctx, func(event *engine.MarketEvent) error {
+		precision, ok := precisions[event.Symbol]
+		if !ok {
+			precision, _ = l.svcCtx.Engine.GetPrecision(event.Symbol)
+			precisions[event.Symbol] = precision
+		}
+		return stream.Send(toMarketDataEvent(event, precision))
+	})
+}
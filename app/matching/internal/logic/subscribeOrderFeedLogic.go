@@ -0,0 +1,38 @@
+package logic
+
+import (
+	"context"
+
+	engine "github.com/tsfdsong/tradeengin/app/matching/internal/engin"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/matching/match"
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+)
+
+type SubscribeOrderFeedLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSubscribeOrderFeedLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SubscribeOrderFeedLogic {
+	return &SubscribeOrderFeedLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// SubscribeOrderFeed 按执行顺序推送交易对的逐笔委托事件，订阅方跟不上时断开
+func (l *SubscribeOrderFeedLogic) SubscribeOrderFeed(in *match.OrderFeedRequest, stream match.MatchService_SubscribeOrderFeedServer) error {
+	sub := l.svcCtx.Engine.SubscribeOrderFeed(in.Symbols)
+	defer sub.Close()
+
+	precisions := make(map[string]types.Precision)
+	return sub.Stream(l.ctx, func(event *engine.MarketEvent) error {
+		precision, ok := precisions[event.Symbol]
+		if !ok {
+			precision, _ = l.svcCtx.Engine.GetPrecision(event.Symbol)
+			precisions[event.Symbol] = precision
+		}
+		return stream.Send(toOrderFeedEvent(event, precision))
+	})
+}
@@ -104,6 +104,22 @@ var (
 		Name: "trading_trade_volume_total",
 		Help: "Total trade volume",
 	}, []string{"symbol"})
+
+	// 主备复制指标
+	replicationLagCommands = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "trading_replication_lag_commands",
+		Help: "Number of commands the follower is behind the primary",
+	})
+
+	replicationLagSeconds = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "trading_replication_lag_seconds",
+		Help: "Delay between the primary accepting a command and the follower applying it",
+	})
+
+	replicationFollowers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "trading_replication_followers",
+		Help: "Number of followers connected to the primary",
+	})
 )
 
 // MetricsCollector 指标收集器
@@ -177,9 +193,9 @@ func (m *MetricsCollector) SetOrderBookSpread(symbol string, spread float64) {
 }
 
 // RecordTrade 记录交易
-func (m *MetricsCollector) RecordTrade(symbol string, quantity int64, price float64) {
+func (m *MetricsCollector) RecordTrade(symbol string, quantity, price float64) {
 	tradesExecuted.WithLabelValues(symbol).Inc()
-	tradeVolume.WithLabelValues(symbol).Add(float64(quantity) * price)
+	tradeVolume.WithLabelValues(symbol).Add(quantity * price)
 }
 
 // UpdateMemoryMetrics 更新内存指标
@@ -243,7 +259,7 @@ func RecordOrderRejected(symbol string, reason string) {
 	collector.RecordOrderRejected(symbol, reason)
 }
 
-func RecordTrade(symbol string, quantity int64, price float64) {
+func RecordTrade(symbol string, quantity, price float64) {
 	collector := NewMetricsCollector()
 	collector.RecordTrade(symbol, quantity, price)
 }
@@ -252,3 +268,14 @@ func SetOrderBookSpread(symbol string, spread float64) {
 	collector := NewMetricsCollector()
 	collector.SetOrderBookSpread(symbol, spread)
 }
+
+// SetReplicationLag 记录follower落后primary的命令数及延迟
+func SetReplicationLag(commands uint64, lag time.Duration) {
+	replicationLagCommands.Set(float64(commands))
+	replicationLagSeconds.Set(lag.Seconds())
+}
+
+// SetReplicationFollowers 记录primary已连接的follower数量
+func SetReplicationFollowers(count int) {
+	replicationFollowers.Set(float64(count))
+}
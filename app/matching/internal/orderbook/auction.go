@@ -0,0 +1,217 @@
+package orderbook
+
+import (
+	"time"
+
+	"github.com/tsfdsong/tradeengin/app/matching/internal/monitor"
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+)
+
+// AuctionQuote 集合竞价的参考成交价及成交量
+type AuctionQuote struct {
+	Price     int64 // 参考成交价，0表示买卖盘没有交叉，无法成交
+	Volume    int64 // 按参考成交价可成交的数量
+	Imbalance int64 // 参考成交价上未成交的数量，买方剩余为正，卖方剩余为负
+}
+
+// UncrossResult 集合竞价撮合结果
+type UncrossResult struct {
+	AuctionQuote
+	Trades     []*types.Trade          // 按价格时间优先顺序生成的成交，成交价均为参考成交价
+	Cancelled  []uint64                // 自成交预防移出订单簿的订单
+	SelfTrades []*types.SelfTradeEvent // 自成交预防事件
+	Expired    []uint64                // 撮合前撤销的已过期GTD挂单
+}
+
+// auctionLevel 参与集合竞价的价格层级
+type auctionLevel struct {
+	price int64
+	qty   int64
+}
+
+// Indicative 当前订单簿的集合竞价参考成交价及成交量
+func (h *HybridOrderBook) Indicative() AuctionQuote {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.indicative()
+}
+
+// IndicativeChanged 参考成交价或成交量与上次取出时不同时返回新的报价，用于推送行情
+func (h *HybridOrderBook) IndicativeChanged() (AuctionQuote, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	quote := h.indicative()
+	if quote == h.quote {
+		return quote, false
+	}
+	h.quote = quote
+	return quote, true
+}
+
+// indicative 计算集合竞价参考成交价(调用方需持有锁)
+// 在买卖盘交叉区间内的挂单价格中依次选择: 成交量最大、未成交数量最小、最接近参考价(最新成交价)
+// 没有最新成交价时以剩余候选价格的中间价为参考价，距离相同时取较低价格
+func (h *HybridOrderBook) indicative() AuctionQuote {
+	bestBid := h.buys.MaxPriceNode()
+	bestAsk := h.sells.MinPriceNode()
+	if bestBid == nil || bestAsk == nil || bestBid.Price < bestAsk.Price {
+		return AuctionQuote{}
+	}
+
+	// 只有交叉区间内的层级参与计算，买盘按树的顺序价格降序，卖盘价格升序
+	var bids, asks []auctionLevel
+	var buyVol int64
+	h.buys.RangeBetween(bestBid.Price, bestAsk.Price, func(price int64, level *PriceLevel) bool {
+		bids = append(bids, auctionLevel{price: price, qty: level.TotalQty})
+		buyVol += level.TotalQty
+		return true
+	})
+	h.sells.RangeBetween(bestAsk.Price, bestBid.Price, func(price int64, level *PriceLevel) bool {
+		asks = append(asks, auctionLevel{price: price, qty: level.TotalQty})
+		return true
+	})
+
+	// 候选价格按升序合并，价格升高时买方可成交数量减少、卖方增加
+	var best []AuctionQuote
+	var sellVol int64
+	bi, ai := len(bids)-1, 0
+	for bi >= 0 || ai < len(asks) {
+		var price int64
+		if ai >= len(asks) || (bi >= 0 && bids[bi].price < asks[ai].price) {
+			price = bids[bi].price
+		} else {
+			price = asks[ai].price
+		}
+
+		// buyVol: 价格不低于price的买单，sellVol: 价格不高于price的卖单
+		for ai < len(asks) && asks[ai].price <= price {
+			sellVol += asks[ai].qty
+			ai++
+		}
+		quote := AuctionQuote{Price: price, Volume: min(buyVol, sellVol), Imbalance: buyVol - sellVol}
+		for bi >= 0 && bids[bi].price <= price {
+			buyVol -= bids[bi].qty
+			bi--
+		}
+
+		switch {
+		case len(best) == 0 || quote.Volume > best[0].Volume:
+			best = append(best[:0], quote)
+		case quote.Volume == best[0].Volume:
+			if d, bd := absInt64(quote.Imbalance), absInt64(best[0].Imbalance); d < bd {
+				best = append(best[:0], quote)
+			} else if d == bd {
+				best = append(best, quote)
+			}
+		}
+	}
+
+	reference := h.lastPrice
+	if reference <= 0 {
+		reference = (best[0].Price + best[len(best)-1].Price) / 2
+	}
+	chosen := best[0]
+	for _, quote := range best[1:] {
+		if absInt64(quote.Price-reference) < absInt64(chosen.Price-reference) {
+			chosen = quote
+		}
+	}
+	return chosen
+}
+
+// Uncross 按参考成交价撮合集合竞价期间累积的订单，订单簿随后不再交叉
+func (h *HybridOrderBook) Uncross() *UncrossResult {
+	return h.UncrossAt(time.Now().UnixNano())
+}
+
+// UncrossAt 以now(纳秒)作为当前时间执行集合竞价撮合
+// 价格不低于参考成交价的买单与价格不高于参考成交价的卖单按价格时间优先逐笔成交，
+// 买单记为Taker；同一账户的买卖单相遇时，较晚进入价格层级队列的一方视为吃单，按其自成交预防模式处理
+// 竞价撮合产生的最新成交价不会立即触发止损单，止损单在之后的连续撮合中按最新成交价触发
+func (h *HybridOrderBook) UncrossAt(now int64) *UncrossResult {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	expired := h.expireOrders(now)
+	h.quote = AuctionQuote{}
+
+	result := &UncrossResult{AuctionQuote: h.indicative(), Expired: expired}
+	if result.Volume == 0 {
+		return result
+	}
+
+	price := result.Price
+	for {
+		bid := h.buys.MaxPriceNode()
+		ask := h.sells.MinPriceNode()
+		if bid == nil || ask == nil || bid.Price < price || ask.Price > price {
+			break
+		}
+
+		buy, sell := bid.Orders[0], ask.Orders[0]
+		if isSelfTrade(buy, sell) {
+			h.preventAuctionSelfTrade(bid, buy, ask, sell, now, result)
+			continue
+		}
+
+		qty := min(buy.VisibleQty+buy.HiddenQty, sell.VisibleQty+sell.HiddenQty)
+		trade := types.GetTradeFromPool()
+		trade.TradeID = h.nextTradeID()
+		trade.TakerOrderID = buy.ID
+		trade.MakerOrderID = sell.ID
+		trade.Symbol = h.symbol
+		trade.Price = price
+		trade.Quantity = qty
+		trade.Timestamp = now
+		result.Trades = append(result.Trades, trade)
+		h.recordTrade(trade)
+
+		h.fillAuctionOrder(bid, buy, trade)
+		h.fillAuctionOrder(ask, sell, trade)
+	}
+
+	h.lastPrice = price
+	h.version++
+	return result
+}
+
+// preventAuctionSelfTrade 集合竞价中同一账户的买卖单相遇，按排队序号区分吃单与挂单后执行自成交预防
+// 每次处理至少有一方数量归零移出订单簿，撮合循环因此可以继续
+func (h *HybridOrderBook) preventAuctionSelfTrade(bid *PriceLevel, buy *types.Order, ask *PriceLevel, sell *types.Order, now int64, result *UncrossResult) {
+	takerLevel, taker, makerLevel, maker := bid, buy, ask, sell
+	if sell.Arrival > buy.Arrival {
+		takerLevel, taker, makerLevel, maker = ask, sell, bid, buy
+	}
+
+	event := h.newSelfTradeEvent(taker, maker, result.Price, taker.VisibleQty+taker.HiddenQty, now)
+	if event.MakerCancelledQty > 0 {
+		event.MakerRemoved = h.reduceOrder(makerLevel, maker, event.MakerCancelledQty)
+		if event.MakerRemoved {
+			result.Cancelled = append(result.Cancelled, event.MakerOrderID)
+		}
+	}
+	if event.TakerCancelledQty > 0 && h.reduceOrder(takerLevel, taker, event.TakerCancelledQty) {
+		result.Cancelled = append(result.Cancelled, event.TakerOrderID)
+	}
+
+	result.SelfTrades = append(result.SelfTrades, event)
+	monitor.RecordOrderRejected(h.symbol, types.RejectReasonSelfTrade)
+}
+
+// fillAuctionOrder 扣减集合竞价成交数量，完全成交的订单移出订单簿
+func (h *HybridOrderBook) fillAuctionOrder(level *PriceLevel, order *types.Order, trade *types.Trade) {
+	h.decrementOrder(level, order, trade.Quantity)
+	h.recordExecute(order, trade)
+	if order.VisibleQty+order.HiddenQty == 0 {
+		h.removeOrder(order)
+	}
+}
+
+func absInt64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
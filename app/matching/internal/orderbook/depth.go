@@ -0,0 +1,77 @@
+package orderbook
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+)
+
+// DepthUpdate 增量深度，价格层级为更新后的展示数量及订单数，数量为0表示该价格层级已移除
+// 更新ID即订单簿版本，相邻两次更新的ID连续: 下一次的FirstUpdateID = 上一次的LastUpdateID + 1
+type DepthUpdate struct {
+	Symbol        string
+	FirstUpdateID uint64             // 本次更新包含的第一个订单簿版本
+	LastUpdateID  uint64             // 更新后的订单簿版本，与深度快照的UpdateID对应
+	Bids          []types.PriceLevel // 价格降序
+	Asks          []types.PriceLevel // 价格升序
+}
+
+// depthKey 发生变化的价格层级
+type depthKey struct {
+	side  int8
+	price int64
+}
+
+// touchLevel 记录发生变化的价格层级(调用方需持有写锁)
+func (h *HybridOrderBook) touchLevel(side int8, price int64) {
+	h.dirty = append(h.dirty, depthKey{side: side, price: price})
+}
+
+// DepthUpdate 取出上次取出之后的深度变化，订单簿版本未变化时返回false
+// 版本变化但没有价格层级变化(如止损单进出触发簿)时也返回空的更新，保证更新ID连续
+func (h *HybridOrderBook) DepthUpdate() (*DepthUpdate, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.version == h.depthID {
+		return nil, false
+	}
+
+	update := &DepthUpdate{
+		Symbol:        h.symbol,
+		FirstUpdateID: h.depthID + 1,
+		LastUpdateID:  h.version,
+	}
+
+	slices.SortFunc(h.dirty, func(a, b depthKey) int {
+		if a.side != b.side {
+			return cmp.Compare(a.side, b.side)
+		}
+		if a.side == types.SideBuy {
+			return cmp.Compare(b.price, a.price)
+		}
+		return cmp.Compare(a.price, b.price)
+	})
+	for _, key := range slices.Compact(h.dirty) {
+		level := types.PriceLevel{Price: key.price}
+		tree := h.sells
+		if key.side == types.SideBuy {
+			tree = h.buys
+		}
+		if node := tree.Get(key.price); node != nil && len(node.Orders) > 0 {
+			level.Quantity = node.VisibleQty
+			level.Count = len(node.Orders)
+		}
+
+		if key.side == types.SideBuy {
+			update.Bids = append(update.Bids, level)
+		} else {
+			update.Asks = append(update.Asks, level)
+		}
+	}
+
+	h.dirty = h.dirty[:0]
+	h.depthID = h.version
+	return update, true
+}
@@ -0,0 +1,53 @@
+package orderbook
+
+import (
+	"container/heap"
+
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+)
+
+// expiryEntry 过期队列元素，保存订单ID和过期时间的副本，订单对象回收复用后不会误判
+type expiryEntry struct {
+	orderID    uint64
+	expireTime int64
+}
+
+// expiryQueue GTD订单过期队列，按过期时间升序的小顶堆
+type expiryQueue []expiryEntry
+
+func (q expiryQueue) Len() int { return len(q) }
+
+func (q expiryQueue) Less(i, j int) bool {
+	if q[i].expireTime == q[j].expireTime {
+		return q[i].orderID < q[j].orderID
+	}
+	return q[i].expireTime < q[j].expireTime
+}
+
+func (q expiryQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *expiryQueue) Push(x interface{}) {
+	*q = append(*q, x.(expiryEntry))
+}
+
+func (q *expiryQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	entry := old[n-1]
+	*q = old[:n-1]
+	return entry
+}
+
+// push 加入一个GTD订单
+func (q *expiryQueue) push(order *types.Order) {
+	heap.Push(q, expiryEntry{orderID: order.ID, expireTime: order.ExpireTime})
+}
+
+// popExpired 弹出所有在now(纳秒)之前过期的订单ID
+func (q *expiryQueue) popExpired(now int64) []uint64 {
+	var expired []uint64
+	for q.Len() > 0 && (*q)[0].expireTime <= now {
+		expired = append(expired, heap.Pop(q).(expiryEntry).orderID)
+	}
+	return expired
+}
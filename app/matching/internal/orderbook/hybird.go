@@ -1,27 +1,60 @@
 package orderbook
 
 import (
+	"errors"
+	"math"
+	"slices"
 	"sync"
 	"time"
-	"unsafe"
 
 	"github.com/tsfdsong/tradeengin/app/matching/internal/monitor"
-	"github.com/tsfdsong/tradeengin/app/pkg/lockfree"
+	"github.com/tsfdsong/tradeengin/app/pkg/fixed"
 	"github.com/tsfdsong/tradeengin/app/pkg/types"
 	"github.com/zeromicro/go-zero/core/logx"
 )
 
+// defaultTickSize 默认最小价格变动单位，以价格最小单位计
+const defaultTickSize = 1
+
+var (
+	ErrOrderNotFound   = errors.New("order not found in order book")
+	ErrVersionConflict = errors.New("order version conflict")
+	ErrInvalidAmend    = errors.New("invalid amend: price and quantity must be non-negative")
+	ErrStopOrderAmend  = errors.New("untriggered stop order cannot be amended")
+	ErrAmendWouldTake  = errors.New("amend would take liquidity of a post-only order")
+	ErrPhaseRejected   = errors.New("operation not allowed in current trading phase")
+)
+
 // HybridOrderBook 高性能混合订单簿（使用跳表）
 type HybridOrderBook struct {
-	symbol   string
-	buys     *SkipTree                        // 买盘 - 价格降序
-	sells    *SkipTree                        // 卖盘 - 价格升序
-	orderMap *sync.Map                        // orderID -> *Order
-	seqMaps  map[float64]*lockfree.RingBuffer // 同价位订单队列
-	mu       sync.RWMutex
-	version  uint64
-	depth    int
-	stats    *OrderBookStats
+	symbol    string
+	buys      *SkipTree // 买盘 - 价格降序
+	sells     *SkipTree // 卖盘 - 价格升序
+	orderMap  *sync.Map // orderID -> *Order
+	mu        sync.RWMutex
+	version   uint64
+	depth     int
+	stats     *OrderBookStats
+	expiries  *expiryQueue // GTD订单过期队列
+	tickSize  int64        // 最小价格变动单位
+	triggers  *TriggerBook // 未触发的止损单
+	lastPrice int64        // 最新成交价，止损单触发依据
+	stpMode   int8         // 交易对默认自成交预防模式
+	phase     types.TradingPhase
+	quote     AuctionQuote // 最近一次推送的集合竞价参考报价
+	accounts  ownerIndex   // 账户 -> 挂单及未触发的止损单
+	clients   ownerIndex   // ClientID -> 挂单及未触发的止损单
+	policy    MatchPolicy  // 价格层级内的成交分配策略
+	dirty     []depthKey   // 上次取出增量深度后发生变化的价格层级
+	depthID   uint64       // 已取出的增量深度对应的订单簿版本
+
+	orderEvents []OrderEvent // 尚未取出的逐笔委托事件
+	tradeSeq    uint64       // 最后一笔成交的ID，成交ID在交易对内按执行顺序递增，重放及follower上保持一致
+	feedSeq     uint64       // 最后一个逐笔委托事件的序号
+	arrivalSeq  uint64       // 最后分配的挂单排队序号
+
+	maxSlippageBps int64           // 市价单最大滑点(基点)，0表示不限制
+	precision      types.Precision // 价格及数量精度，仅用于监控指标换算
 }
 
 // OrderBookStats 订单簿统计
@@ -39,9 +72,16 @@ func NewHybridOrderBook(symbol string) *HybridOrderBook {
 		buys:     NewSkipTree(16, true),  // 买盘降序，最大16层
 		sells:    NewSkipTree(16, false), // 卖盘升序，最大16层
 		orderMap: &sync.Map{},
-		seqMaps:  make(map[float64]*lockfree.RingBuffer),
 		stats:    &OrderBookStats{},
 		depth:    1000, // 默认深度
+		expiries: &expiryQueue{},
+		tickSize: defaultTickSize,
+		triggers: NewTriggerBook(),
+		stpMode:  types.STPCancelNewest,
+		phase:    types.PhaseContinuous,
+		accounts: make(ownerIndex),
+		clients:  make(ownerIndex),
+		policy:   FIFOPolicy{},
 	}
 
 	return ob
@@ -49,31 +89,126 @@ func NewHybridOrderBook(symbol string) *HybridOrderBook {
 
 // Match 订单撮合
 func (h *HybridOrderBook) Match(order *types.Order) *types.MatchResult {
-	startTime := time.Now()
+	return h.MatchAt(order, time.Now().UnixNano())
+}
 
+// MatchAt 以now(纳秒)作为当前时间撮合，相同的命令序列总是得到相同的订单簿
+func (h *HybridOrderBook) MatchAt(order *types.Order, now int64) *types.MatchResult {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
+	// 先清理已过期的GTD挂单，保证过期订单不会参与撮合，撤销的订单随结果返回
+	expired := h.expireOrders(now)
+
+	result := h.match(order, now)
+	result.Expired = expired
+
+	// 最新成交价变化后，按确定顺序释放被触发的止损单
+	h.fireTriggers(result, now)
+
+	return result
+}
+
+// fireTriggers 撮合被最新成交价触发的止损单，触发单产生的成交可能继续触发其他止损单
+func (h *HybridOrderBook) fireTriggers(result *types.MatchResult, now int64) {
+	for {
+		order := h.triggers.PopTriggered(h.lastPrice)
+		if order == nil {
+			return
+		}
+		h.unindexOrder(order)
+
+		order.Trigger()
+		result.Triggered = append(result.Triggered, h.match(order, now))
+	}
+}
+
+// match 撮合单个订单(调用方需持有写锁)
+func (h *HybridOrderBook) match(order *types.Order, now int64) *types.MatchResult {
+	startTime := time.Now()
+
 	result := types.GetMatchResultFromPool()
 	result.Order = order
-	result.Timestamp = time.Now().UnixNano()
+	result.Timestamp = now
+
+	// GTD订单到达时已过期，直接撤销
+	if order.IsExpired(now) {
+		return h.rejectOrder(result, types.RejectReasonGTDExpired, startTime)
+	}
+
+	// 交易阶段由引擎在接收订单时校验，这里按执行顺序再次确认
+	if reason := h.phase.CheckOrder(order); reason != "" {
+		return h.rejectOrder(result, reason, startTime)
+	}
+
+	// 集合竞价期间订单只累积不撮合，订单簿可以交叉，退出竞价时统一撮合
+	if h.phase == types.PhaseAuction {
+		h.addOrderToBook(order, order.Quantity)
+		result.RestingQty = order.Quantity
+		h.updateStats(0, time.Since(startTime))
+		h.version++
+		return result
+	}
+
+	// 未满足触发条件的止损单进入触发簿，满足条件的立即转换为市价/限价单撮合
+	if order.IsStop() {
+		if !IsTriggered(order, h.lastPrice) {
+			h.addOrderToTriggers(order)
+			result.RestingQty = order.Quantity
+			h.updateStats(0, time.Since(startTime))
+			h.version++
+			return result
+		}
+		order.Trigger()
+	}
+
+	tif := order.GetTimeInForce()
+
+	// FOK订单必须能全部成交，否则不触碰订单簿直接撤销
+	if tif == types.TimeInForceFOK && h.availableQty(order, order.Quantity) < order.Quantity {
+		return h.rejectOrder(result, types.RejectReasonFOKUnfilled, startTime)
+	}
+
+	// Post-Only订单及只挂单阶段的订单不允许吃单: 拒绝或调整到对手最优价内一个tick
+	if (order.IsPostOnly() || h.phase == types.PhasePostOnly) && h.wouldCross(order) {
+		if order.PostOnly != types.PostOnlySlide || !h.slideInsideSpread(order) {
+			return h.rejectOrder(result, types.RejectReasonPostOnly, startTime)
+		}
+	}
 
 	var trades []*types.Trade
 	remainingQty := order.Quantity
 
+	// 按金额下单的市价单数量由剩余金额决定
+	if order.IsQuoteOrder() {
+		remainingQty = math.MaxInt64
+	}
+
 	if order.Side == types.SideBuy {
 		// 买单匹配卖盘
-		trades, remainingQty = h.matchBuyOrder(order, remainingQty)
+		trades, remainingQty = h.matchBuyOrder(order, remainingQty, result)
 	} else {
 		// 卖单匹配卖盘
-		trades, remainingQty = h.matchSellOrder(order, remainingQty)
+		trades, remainingQty = h.matchSellOrder(order, remainingQty, result)
 	}
 
 	result.Trades = trades
 
-	// 剩余数量放入订单簿（限价单）
-	if remainingQty > 0 && order.Type == types.TypeLimit {
-		h.addOrderToBook(order, remainingQty)
+	// 按金额下单的市价单不按数量撤销，未用完的金额记入RemainingQuote
+	if order.IsQuoteOrder() {
+		result.CancelledQty = 0
+		result.RemainingQuote = order.QuoteQty - result.TotalNotional()
+		remainingQty = 0
+	}
+
+	// 剩余数量处理: GTC/GTD限价单挂入订单簿，其余(IOC/FOK/市价单)撤销
+	if remainingQty > 0 {
+		if order.Type == types.TypeLimit && (tif == types.TimeInForceGTC || tif == types.TimeInForceGTD) {
+			h.addOrderToBook(order, remainingQty)
+			result.RestingQty = remainingQty
+		} else {
+			result.CancelledQty = remainingQty
+		}
 	}
 
 	// 更新统计
@@ -83,7 +218,7 @@ func (h *HybridOrderBook) Match(order *types.Order) *types.MatchResult {
 	monitor.RecordOrderMatched(h.symbol, len(trades))
 	if len(trades) > 0 {
 		for _, trade := range trades {
-			monitor.RecordTrade(h.symbol, trade.Quantity, trade.Price)
+			monitor.RecordTrade(h.symbol, h.precision.QtyFloat(trade.Quantity), h.precision.PriceFloat(trade.Price))
 		}
 	}
 
@@ -91,9 +226,176 @@ func (h *HybridOrderBook) Match(order *types.Order) *types.MatchResult {
 	return result
 }
 
+// addOrderToTriggers 添加未触发的止损单到触发簿
+func (h *HybridOrderBook) addOrderToTriggers(order *types.Order) {
+	h.triggers.Add(order)
+	h.indexOrder(order)
+
+	// GTD止损单在触发前也可能过期
+	if order.GetTimeInForce() == types.TimeInForceGTD {
+		h.expiries.push(order)
+	}
+}
+
+// GetLastPrice 获取最新成交价
+func (h *HybridOrderBook) GetLastPrice() int64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.lastPrice
+}
+
+// GetPendingStopCount 获取未触发的止损单数量
+func (h *HybridOrderBook) GetPendingStopCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.triggers.Len()
+}
+
+// rejectOrder 整单拒绝，不触碰订单簿
+func (h *HybridOrderBook) rejectOrder(result *types.MatchResult, reason string, startTime time.Time) *types.MatchResult {
+	result.CancelledQty = result.Order.Quantity
+	result.RejectReason = reason
+	monitor.RecordOrderRejected(h.symbol, reason)
+	h.updateStats(0, time.Since(startTime))
+	return result
+}
+
+// WouldCross 限价单在当前订单簿上是否会立即成交(吃单)
+func (h *HybridOrderBook) WouldCross(order *types.Order) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.wouldCross(order)
+}
+
+// wouldCross 判断订单是否会与对手盘最优价成交(调用方需持有锁)
+func (h *HybridOrderBook) wouldCross(order *types.Order) bool {
+	if order.Type != types.TypeLimit {
+		return true
+	}
+
+	if order.Side == types.SideBuy {
+		bestAsk := h.sells.MinPriceNode()
+		return bestAsk != nil && order.Price >= bestAsk.Price
+	}
+
+	bestBid := h.buys.MaxPriceNode()
+	return bestBid != nil && order.Price <= bestBid.Price
+}
+
+// slideInsideSpread 将会吃单的Post-Only订单价格调整到对手最优价内一个tick，调整失败返回false
+func (h *HybridOrderBook) slideInsideSpread(order *types.Order) bool {
+	if order.Side == types.SideBuy {
+		bestAsk := h.sells.MinPriceNode()
+		price := bestAsk.Price - h.tickSize
+		if price <= 0 {
+			return false
+		}
+		order.Price = price
+		return true
+	}
+
+	bestBid := h.buys.MaxPriceNode()
+	order.Price = bestBid.Price + h.tickSize
+	return true
+}
+
+// SetTickSize 设置最小价格变动单位，以价格最小单位计
+func (h *HybridOrderBook) SetTickSize(tickSize int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if tickSize > 0 {
+		h.tickSize = tickSize
+	}
+}
+
+// SetPrecision 设置交易对精度
+func (h *HybridOrderBook) SetPrecision(precision types.Precision) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.precision = precision
+}
+
+// availableQty 统计订单在其价格限制内可成交的对手盘数量，最多统计到need为止
+func (h *HybridOrderBook) availableQty(order *types.Order, need int64) int64 {
+	var available int64
+
+	if order.Side == types.SideBuy {
+		// 卖盘升序，从最优卖价开始遍历
+		h.sells.Range(func(price int64, level *PriceLevel) bool {
+			if order.Type == types.TypeLimit && order.Price < price {
+				return false
+			}
+			// 同一账户的挂单不计入可成交数量
+			qty := matchableQty(order, level)
+			available += qty
+			return qty == level.TotalQty && available < need
+		})
+	} else {
+		// 买盘降序，从最优买价开始遍历
+		h.buys.Range(func(price int64, level *PriceLevel) bool {
+			if order.Type == types.TypeLimit && order.Price > price {
+				return false
+			}
+			// 同一账户的挂单不计入可成交数量
+			qty := matchableQty(order, level)
+			available += qty
+			return qty == level.TotalQty && available < need
+		})
+	}
+
+	return available
+}
+
+// SetMaxSlippage 设置市价单最大滑点(相对扫单开始时对手最优价的基点)，0表示不限制
+func (h *HybridOrderBook) SetMaxSlippage(bps int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if bps >= 0 {
+		h.maxSlippageBps = bps
+	}
+}
+
+// slippageLimit 市价单允许成交的最差价格，0表示不限制
+func (h *HybridOrderBook) slippageLimit(order *types.Order) int64 {
+	if order.Type != types.TypeMarket || h.maxSlippageBps <= 0 {
+		return 0
+	}
+
+	// 偏移量向下取整，限价只会更严格而不会放宽
+	if order.Side == types.SideBuy {
+		bestAsk := h.sells.MinPriceNode()
+		if bestAsk == nil {
+			return 0
+		}
+		return bestAsk.Price + bestAsk.Price*h.maxSlippageBps/10000
+	}
+
+	bestBid := h.buys.MaxPriceNode()
+	if bestBid == nil {
+		return 0
+	}
+	return bestBid.Price - bestBid.Price*h.maxSlippageBps/10000
+}
+
+// affordableQty 剩余金额在给定价格下可成交的最大数量，金额精度为价格与数量精度之和
+func affordableQty(quote, price int64) int64 {
+	if quote <= 0 || price <= 0 {
+		return 0
+	}
+	return quote / price
+}
+
 // matchBuyOrder 买单撮合逻辑
-func (h *HybridOrderBook) matchBuyOrder(order *types.Order, remainingQty int64) ([]*types.Trade, int64) {
+func (h *HybridOrderBook) matchBuyOrder(order *types.Order, remainingQty int64, result *types.MatchResult) ([]*types.Trade, int64) {
 	var trades []*types.Trade
+	var spent int64
+	worstPrice := h.slippageLimit(order)
 
 	for remainingQty > 0 && h.sells.Len() > 0 {
 		bestAsk := h.sells.MinPriceNode()
@@ -106,39 +408,65 @@ func (h *HybridOrderBook) matchBuyOrder(order *types.Order, remainingQty int64)
 			break // 限价单价格不匹配
 		}
 
-		// 计算匹配数量
-		matchedQty := min(remainingQty, bestAsk.TotalQty)
+		// 市价单超出最大滑点范围，停止扫单
+		if worstPrice > 0 && bestAsk.Price > worstPrice {
+			monitor.RecordOrderRejected(h.symbol, types.RejectReasonSlippage)
+			break
+		}
+
+		// 自成交预防: 队首挂单与吃单属于同一账户
+		if maker := bestAsk.Orders[0]; isSelfTrade(order, maker) {
+			remainingQty = h.preventSelfTrade(order, bestAsk, maker, remainingQty, result)
+			continue
+		}
+
+		// 按比例分配时同账户挂单可能位于层级任意位置，分配前逐个执行自成交预防
+		if qty, prevented := h.preventLevelSelfTrades(order, bestAsk, remainingQty, result); prevented {
+			remainingQty = qty
+			continue
+		}
+
+		// 计算匹配数量，不越过同一账户的挂单
+		matchedQty := min(remainingQty, h.policy.Matchable(order, bestAsk))
+
+		// 按金额下单的市价单，成交数量受剩余金额限制
+		if order.IsQuoteOrder() {
+			matchedQty = min(matchedQty, affordableQty(order.QuoteQty-spent, bestAsk.Price))
+		}
 		if matchedQty <= 0 {
 			break
 		}
 
-		// 执行交易
-		trade := h.executeTrade(order, bestAsk, matchedQty, bestAsk.Price)
-		trades = append(trades, trade)
+		// 成交金额超出int64时停止扫单，剩余数量按未成交处理
+		notional, ok := fixed.Mul(matchedQty, bestAsk.Price)
+		if !ok {
+			break
+		}
+
+		// 按撮合策略消耗层级内订单，每个被成交的挂单生成一笔成交
+		levelTrades := h.fillLevel(order, bestAsk, matchedQty, result.Timestamp)
+		trades = append(trades, levelTrades...)
+		if order.IsQuoteOrder() {
+			spent += notional
+		}
 
 		// 更新数量
 		remainingQty -= matchedQty
-		bestAsk.TotalQty -= matchedQty
 
 		// 移除已完全成交的价格层级
 		if bestAsk.TotalQty == 0 {
 			h.sells.Remove(bestAsk.Price)
-			delete(h.seqMaps, bestAsk.Price)
-		} else {
-			// 部分成交，更新价格层级
-			h.updatePriceLevel(bestAsk, -matchedQty)
 		}
-
-		// 记录交易
-		h.recordTrade(trade)
 	}
 
 	return trades, remainingQty
 }
 
 // matchSellOrder 卖单撮合逻辑
-func (h *HybridOrderBook) matchSellOrder(order *types.Order, remainingQty int64) ([]*types.Trade, int64) {
+func (h *HybridOrderBook) matchSellOrder(order *types.Order, remainingQty int64, result *types.MatchResult) ([]*types.Trade, int64) {
 	var trades []*types.Trade
+	var spent int64
+	worstPrice := h.slippageLimit(order)
 
 	for remainingQty > 0 && h.buys.Len() > 0 {
 		bestBid := h.buys.MaxPriceNode()
@@ -151,63 +479,250 @@ func (h *HybridOrderBook) matchSellOrder(order *types.Order, remainingQty int64)
 			break // 限价单价格不匹配
 		}
 
-		// 计算匹配数量
-		matchedQty := min(remainingQty, bestBid.TotalQty)
+		// 市价单超出最大滑点范围，停止扫单
+		if worstPrice > 0 && bestBid.Price < worstPrice {
+			monitor.RecordOrderRejected(h.symbol, types.RejectReasonSlippage)
+			break
+		}
+
+		// 自成交预防: 队首挂单与吃单属于同一账户
+		if maker := bestBid.Orders[0]; isSelfTrade(order, maker) {
+			remainingQty = h.preventSelfTrade(order, bestBid, maker, remainingQty, result)
+			continue
+		}
+
+		// 按比例分配时同账户挂单可能位于层级任意位置，分配前逐个执行自成交预防
+		if qty, prevented := h.preventLevelSelfTrades(order, bestBid, remainingQty, result); prevented {
+			remainingQty = qty
+			continue
+		}
+
+		// 计算匹配数量，不越过同一账户的挂单
+		matchedQty := min(remainingQty, h.policy.Matchable(order, bestBid))
+
+		// 按金额下单的市价单，成交数量受剩余金额限制
+		if order.IsQuoteOrder() {
+			matchedQty = min(matchedQty, affordableQty(order.QuoteQty-spent, bestBid.Price))
+		}
 		if matchedQty <= 0 {
 			break
 		}
 
-		// 执行交易
-		trade := h.executeTrade(order, bestBid, matchedQty, bestBid.Price)
-		trades = append(trades, trade)
+		// 成交金额超出int64时停止扫单，剩余数量按未成交处理
+		notional, ok := fixed.Mul(matchedQty, bestBid.Price)
+		if !ok {
+			break
+		}
+
+		// 按撮合策略消耗层级内订单，每个被成交的挂单生成一笔成交
+		levelTrades := h.fillLevel(order, bestBid, matchedQty, result.Timestamp)
+		trades = append(trades, levelTrades...)
+		if order.IsQuoteOrder() {
+			spent += notional
+		}
 
 		// 更新数量
 		remainingQty -= matchedQty
-		bestBid.TotalQty -= matchedQty
 
 		// 移除已完全成交的价格层级
 		if bestBid.TotalQty == 0 {
 			h.buys.Remove(bestBid.Price)
-			delete(h.seqMaps, bestBid.Price)
-		} else {
-			// 部分成交，更新价格层级
-			h.updatePriceLevel(bestBid, -matchedQty)
 		}
-
-		// 记录交易
-		h.recordTrade(trade)
 	}
 
 	return trades, remainingQty
 }
 
-// executeTrade 执行交易
-func (h *HybridOrderBook) executeTrade(taker *types.Order, makerLevel *PriceLevel, qty int64, price float64) *types.Trade {
-	// 从价格层级中获取最早的同价位订单
-	makerOrder := h.getEarliestOrderFromLevel(makerLevel.Price)
-	if makerOrder == nil {
-		// 如果没有找到具体订单，创建一个虚拟的maker订单
-		makerOrder = &types.Order{
-			ID:       generateTradeID(),
-			Symbol:   h.symbol,
-			Price:    price,
-			Quantity: qty,
-			Side:     1 - taker.Side, // 相反方向
+// SetPhase 设置交易阶段，之后执行的命令按新阶段校验
+func (h *HybridOrderBook) SetPhase(phase types.TradingPhase) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.phase = phase
+}
+
+// Phase 当前交易阶段
+func (h *HybridOrderBook) Phase() types.TradingPhase {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.phase
+}
+
+// SetMatchPolicy 设置价格层级内的成交分配策略，集合竞价撮合始终按价格时间优先
+func (h *HybridOrderBook) SetMatchPolicy(policy MatchPolicy) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if policy != nil {
+		h.policy = policy
+	}
+}
+
+// MatchPolicy 当前的成交分配策略
+func (h *HybridOrderBook) MatchPolicy() MatchPolicy {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.policy
+}
+
+// SetSTPMode 设置交易对默认自成交预防模式
+func (h *HybridOrderBook) SetSTPMode(mode int8) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if types.IsValidSTPMode(mode) {
+		h.stpMode = mode
+	}
+}
+
+// isSelfTrade 吃单与挂单是否属于同一账户
+func isSelfTrade(taker, maker *types.Order) bool {
+	return taker.ClientID != "" && taker.ClientID == maker.ClientID
+}
+
+// matchableQty 层级内在遇到同一账户挂单之前可成交的数量
+func matchableQty(taker *types.Order, level *PriceLevel) int64 {
+	if taker.ClientID == "" {
+		return level.TotalQty
+	}
+
+	var qty int64
+	for _, maker := range level.Orders {
+		if isSelfTrade(taker, maker) {
+			return qty
+		}
+		qty += maker.VisibleQty
+	}
+
+	return level.TotalQty
+}
+
+// preventSelfTrade 按自成交预防模式处理同一账户的吃单与挂单，返回吃单剩余数量
+func (h *HybridOrderBook) preventSelfTrade(taker *types.Order, level *PriceLevel, maker *types.Order, remainingQty int64, result *types.MatchResult) int64 {
+	event := h.newSelfTradeEvent(taker, maker, level.Price, remainingQty, result.Timestamp)
+
+	// 挂单方数量归零时撤销，否则只扣减重叠数量
+	if event.MakerCancelledQty > 0 {
+		event.MakerRemoved = h.reduceOrder(level, maker, event.MakerCancelledQty)
+	}
+
+	result.CancelledQty += event.TakerCancelledQty
+	result.SelfTrades = append(result.SelfTrades, event)
+	monitor.RecordOrderRejected(h.symbol, types.RejectReasonSelfTrade)
+
+	return remainingQty - event.TakerCancelledQty
+}
+
+// newSelfTradeEvent 按吃单的自成交预防模式(未指定时使用交易对默认模式)计算双方被撤销的数量
+func (h *HybridOrderBook) newSelfTradeEvent(taker, maker *types.Order, price, remainingQty, now int64) *types.SelfTradeEvent {
+	mode := taker.STPMode
+	if mode == 0 {
+		mode = h.stpMode
+	}
+
+	makerQty := maker.VisibleQty + maker.HiddenQty
+	event := &types.SelfTradeEvent{
+		Symbol:       h.symbol,
+		TakerOrderID: taker.ID,
+		MakerOrderID: maker.ID,
+		ClientID:     taker.ClientID,
+		Price:        price,
+		Mode:         mode,
+		Timestamp:    now,
+	}
+
+	switch mode {
+	case types.STPCancelOldest:
+		event.MakerCancelledQty = makerQty
+	case types.STPCancelBoth:
+		event.MakerCancelledQty = makerQty
+		event.TakerCancelledQty = remainingQty
+	case types.STPDecrementCancel:
+		overlap := min(remainingQty, makerQty)
+		event.MakerCancelledQty = overlap
+		event.TakerCancelledQty = overlap
+	default:
+		event.TakerCancelledQty = remainingQty
+	}
+	return event
+}
+
+// reduceOrder 撤销挂单qty数量，剩余数量归零时移出订单簿并返回true，此后订单对象已归还对象池
+func (h *HybridOrderBook) reduceOrder(level *PriceLevel, order *types.Order, qty int64) bool {
+	if qty == order.VisibleQty+order.HiddenQty {
+		return h.cancelOrder(order.ID)
+	}
+
+	visibleQty := order.VisibleQty
+	h.decrementOrder(level, order, qty)
+	if order.VisibleQty != visibleQty {
+		h.recordOrderEvent(OrderEventModify, order, order.VisibleQty)
+	}
+	return false
+}
+
+// preventLevelSelfTrades 非价格时间优先的撮合策略下，对层级内全部同账户挂单执行自成交预防
+// 价格时间优先时同账户挂单到达队首才会相遇，由撮合循环按队首处理；返回吃单剩余数量及是否存在同账户挂单
+func (h *HybridOrderBook) preventLevelSelfTrades(taker *types.Order, level *PriceLevel, remainingQty int64, result *types.MatchResult) (int64, bool) {
+	if _, fifo := h.policy.(FIFOPolicy); fifo || taker.ClientID == "" {
+		return remainingQty, false
+	}
+
+	var makers []*types.Order
+	for _, maker := range level.Orders {
+		if isSelfTrade(taker, maker) {
+			makers = append(makers, maker)
+		}
+	}
+	// 撤销挂单会修改层级内订单列表，按拷贝依次处理
+	for _, maker := range makers {
+		if remainingQty == 0 {
+			break
 		}
+		remainingQty = h.preventSelfTrade(taker, level, maker, remainingQty, result)
 	}
+	return remainingQty, len(makers) > 0
+}
+
+// decrementOrder 扣减挂单剩余数量，优先扣减冰山单隐藏部分以保持展示数量
+func (h *HybridOrderBook) decrementOrder(level *PriceLevel, order *types.Order, qty int64) {
+	fromHidden := min(qty, order.HiddenQty)
+	order.HiddenQty -= fromHidden
+
+	fromVisible := qty - fromHidden
+	order.VisibleQty -= fromVisible
+	level.VisibleQty -= fromVisible
+	h.touchLevel(order.Side, level.Price)
+
+	h.updatePriceLevel(level, -qty)
+}
 
+// executeTrade 生成吃单与单个挂单之间的成交，成交价为挂单价格，成交时间为命令时间now(纳秒)
+func (h *HybridOrderBook) executeTrade(taker, maker *types.Order, qty int64, now int64) *types.Trade {
 	trade := types.GetTradeFromPool()
-	trade.TradeID = generateTradeID()
+	trade.TradeID = h.nextTradeID()
 	trade.TakerOrderID = taker.ID
-	trade.MakerOrderID = makerOrder.ID
+	trade.MakerOrderID = maker.ID
 	trade.Symbol = h.symbol
-	trade.Price = price
+	trade.Price = maker.Price
 	trade.Quantity = qty
-	trade.Timestamp = time.Now().UnixNano()
+	trade.Timestamp = now
+	trade.TakerSide = taker.Side
+
+	// 更新最新成交价
+	h.lastPrice = maker.Price
 
 	return trade
 }
 
+// enqueue 为进入价格层级队尾的挂单分配排队序号，序号越大越晚进入订单簿(调用方需持有写锁)
+func (h *HybridOrderBook) enqueue(order *types.Order) {
+	h.arrivalSeq++
+	order.Arrival = h.arrivalSeq
+}
+
 // addOrderToBook 添加订单到订单簿
 func (h *HybridOrderBook) addOrderToBook(order *types.Order, qty int64) {
 	var tree *SkipTree
@@ -228,36 +743,95 @@ func (h *HybridOrderBook) addOrderToBook(order *types.Order, qty int64) {
 		tree.Insert(order.Price, level)
 	}
 
+	// 冰山单只展示峰值数量，其余作为隐藏数量
+	order.VisibleQty = qty
+	order.HiddenQty = 0
+	if order.IsIceberg() && order.DisplayQty < qty {
+		order.VisibleQty = order.DisplayQty
+		order.HiddenQty = qty - order.DisplayQty
+	}
+
 	// 添加订单到层级
+	h.enqueue(order)
 	level.Orders = append(level.Orders, order)
 	level.TotalQty += qty
+	level.VisibleQty += order.VisibleQty
+	h.touchLevel(order.Side, order.Price)
+	h.recordOrderEvent(OrderEventAdd, order, order.VisibleQty)
 
 	// 存储订单映射
 	h.orderMap.Store(order.ID, order)
+	h.indexOrder(order)
 
-	// 初始化同价位订单队列
-	if _, exists := h.seqMaps[order.Price]; !exists {
-		h.seqMaps[order.Price] = lockfree.NewRingBuffer(1000)
+	// GTD订单加入过期队列
+	if order.GetTimeInForce() == types.TimeInForceGTD {
+		h.expiries.push(order)
 	}
-
-	// 将订单添加到顺序队列
-	orderPtr := unsafe.Pointer(order)
-	h.seqMaps[order.Price].Push(orderPtr)
 }
 
-// getEarliestOrderFromLevel 获取同价位最早的订单
-func (h *HybridOrderBook) getEarliestOrderFromLevel(price float64) *types.Order {
-	queue, exists := h.seqMaps[price]
-	if !exists || queue == nil {
-		return nil
+// fillLevel 按撮合策略从层级内订单扣减成交数量，每个被成交的挂单按其实际成交数量生成一笔成交
+// 每轮按挂单的展示数量分配，冰山单展示数量成交完后从隐藏数量补充新的峰值，并排到同价位队尾失去时间优先级
+func (h *HybridOrderBook) fillLevel(taker *types.Order, level *PriceLevel, qty int64, now int64) []*types.Trade {
+	var trades []*types.Trade
+	if len(level.Orders) > 0 {
+		h.touchLevel(level.Orders[0].Side, level.Price)
 	}
 
-	item := queue.Pop()
-	if item == nil {
-		return nil
+	for qty > 0 && len(level.Orders) > 0 {
+		allocs := h.policy.Allocate(taker, level, qty)
+
+		// 原地压缩层级内订单，写入位置不会超过读取位置
+		var filled int64
+		orders := level.Orders[:0]
+		var refilled []*types.Order
+		for i, maker := range level.Orders {
+			fillQty := allocs[i]
+			if fillQty <= 0 {
+				orders = append(orders, maker)
+				continue
+			}
+
+			maker.VisibleQty -= fillQty
+			level.VisibleQty -= fillQty
+			h.updatePriceLevel(level, -fillQty)
+			filled += fillQty
+
+			trade := h.executeTrade(taker, maker, fillQty, now)
+			trades = append(trades, trade)
+			h.recordTrade(trade)
+			h.recordExecute(maker, trade)
+
+			// 展示数量未成交完，保持原位置和剩余数量
+			if maker.VisibleQty > 0 {
+				orders = append(orders, maker)
+				continue
+			}
+
+			// 冰山单补充峰值后重新排队
+			if maker.HiddenQty > 0 {
+				peak := min(maker.DisplayQty, maker.HiddenQty)
+				maker.HiddenQty -= peak
+				maker.VisibleQty = peak
+				level.VisibleQty += peak
+				h.enqueue(maker)
+				refilled = append(refilled, maker)
+				h.recordOrderEvent(OrderEventAdd, maker, peak)
+				continue
+			}
+
+			// 完全成交的订单移出订单簿
+			h.orderMap.Delete(maker.ID)
+			h.unindexOrder(maker)
+		}
+		level.Orders = append(orders, refilled...)
+
+		if filled == 0 {
+			break
+		}
+		qty -= filled
 	}
 
-	return (*types.Order)(item)
+	return trades
 }
 
 // updatePriceLevel 更新价格层级
@@ -268,16 +842,29 @@ func (h *HybridOrderBook) updatePriceLevel(level *PriceLevel, delta int64) {
 	}
 }
 
+// RestingOrder 返回订单簿中挂单的副本，不包含未触发的止损单
+func (h *HybridOrderBook) RestingOrder(orderID uint64) (types.Order, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	value, exists := h.orderMap.Load(orderID)
+	if !exists {
+		return types.Order{}, false
+	}
+	return *value.(*types.Order), true
+}
+
 // GetSnapshot 获取订单簿快照
 func (h *HybridOrderBook) GetSnapshot(depth int) *types.OrderBook {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
 	snapshot := &types.OrderBook{
-		Symbol: h.symbol,
-		Bids:   h.getTopLevels(h.buys, depth),
-		Asks:   h.getTopLevels(h.sells, depth),
-		Time:   time.Now().UnixMilli(),
+		Symbol:   h.symbol,
+		UpdateID: h.version,
+		Bids:     h.getTopLevels(h.buys, depth),
+		Asks:     h.getTopLevels(h.sells, depth),
+		Time:     time.Now().UnixMilli(),
 	}
 
 	return snapshot
@@ -291,7 +878,7 @@ func (h *HybridOrderBook) getTopLevels(tree *SkipTree, depth int) []types.PriceL
 	for _, level := range levels {
 		priceLevel := types.PriceLevel{
 			Price:    level.Price,
-			Quantity: level.TotalQty,
+			Quantity: level.VisibleQty,
 			Count:    len(level.Orders),
 		}
 		result = append(result, priceLevel)
@@ -308,11 +895,38 @@ func (h *HybridOrderBook) GetDepth(depth int) int {
 	return h.buys.Len() + h.sells.Len()
 }
 
+// OrderCount 挂单及未触发的止损单数量
+func (h *HybridOrderBook) OrderCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	count := h.triggers.Len()
+	h.orderMap.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+	return count
+}
+
 // CancelOrder 取消订单
 func (h *HybridOrderBook) CancelOrder(orderID uint64) bool {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
+	return h.cancelOrder(orderID)
+}
+
+// cancelOrder 取消订单(调用方需持有写锁)
+func (h *HybridOrderBook) cancelOrder(orderID uint64) bool {
+	// 未触发的止损单直接从触发簿移除
+	if stopOrder := h.triggers.Get(orderID); stopOrder != nil {
+		h.triggers.Remove(orderID)
+		h.unindexOrder(stopOrder)
+		types.PutOrderToPool(stopOrder)
+		h.version++
+		return true
+	}
+
 	// 从订单映射中查找订单
 	orderInterface, exists := h.orderMap.Load(orderID)
 	if !exists {
@@ -320,14 +934,38 @@ func (h *HybridOrderBook) CancelOrder(orderID uint64) bool {
 	}
 
 	order := orderInterface.(*types.Order)
+	if !h.removeOrder(order) {
+		return false
+	}
+	h.recordOrderEvent(OrderEventDelete, order, 0)
 
-	// 从对应的价格树中移除
-	var tree *SkipTree
-	if order.Side == types.SideBuy {
-		tree = h.buys
-	} else {
-		tree = h.sells
+	// 归还订单对象到池
+	types.PutOrderToPool(order)
+
+	h.version++
+	return true
+}
+
+// CancelAll 撤销全部挂单及未触发的止损单，返回被撤销的订单ID，用于交易对下架
+func (h *HybridOrderBook) CancelAll() []uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	orders := slices.Concat(collectOrders(h.buys), collectOrders(h.sells), h.triggers.Orders())
+	cancelled := make([]uint64, 0, len(orders))
+	for _, order := range orders {
+		orderID := order.ID // 撤销后订单对象归还对象池，先取出ID
+		if h.cancelOrder(orderID) {
+			cancelled = append(cancelled, orderID)
+		}
 	}
+	return cancelled
+}
+
+// removeOrder 将挂单从价格层级和订单映射中移除，不回收订单对象
+func (h *HybridOrderBook) removeOrder(order *types.Order) bool {
+	// 从对应的价格树中移除
+	tree := h.treeOf(order)
 
 	level := tree.Get(order.Price)
 	if level == nil {
@@ -336,24 +974,21 @@ func (h *HybridOrderBook) CancelOrder(orderID uint64) bool {
 
 	// 从层级中移除订单
 	for i, o := range level.Orders {
-		if o.ID == orderID {
-			// 移除订单
+		if o.ID == order.ID {
+			// 撤销剩余数量，包括冰山单的隐藏数量
 			level.Orders = append(level.Orders[:i], level.Orders[i+1:]...)
-			level.TotalQty -= order.Quantity
+			level.TotalQty -= order.VisibleQty + order.HiddenQty
+			level.VisibleQty -= order.VisibleQty
+			h.touchLevel(order.Side, order.Price)
 
 			// 如果层级为空，移除整个层级
 			if level.TotalQty == 0 {
 				tree.Remove(order.Price)
-				delete(h.seqMaps, order.Price)
 			}
 
 			// 从订单映射中移除
-			h.orderMap.Delete(orderID)
-
-			// 归还订单对象到池
-			types.PutOrderToPool(order)
-
-			h.version++
+			h.orderMap.Delete(order.ID)
+			h.unindexOrder(order)
 			return true
 		}
 	}
@@ -361,8 +996,178 @@ func (h *HybridOrderBook) CancelOrder(orderID uint64) bool {
 	return false
 }
 
+// treeOf 获取挂单所在的价格树
+func (h *HybridOrderBook) treeOf(order *types.Order) *SkipTree {
+	if order.Side == types.SideBuy {
+		return h.buys
+	}
+	return h.sells
+}
+
+// AmendOrder 修改挂单价格/数量，version必须与订单当前版本一致，否则视为过期的改单请求
+// price/qty为0表示不修改，qty为修改后的剩余数量
+// 价格不变且只减少数量时原地修改保持时间优先级；修改价格或增加数量时重新排队，新价格可能立即成交
+// 重新排队的订单会被拒绝(如Post-Only订单改价后吃单)时返回错误并保留原挂单；
+// 价格及数量均未变化时不修改订单也不增加版本；未触发的止损单不支持改单，返回ErrStopOrderAmend，需撤单后重新下单
+func (h *HybridOrderBook) AmendOrder(orderID uint64, price int64, qty int64, version uint32) (*types.MatchResult, error) {
+	return h.AmendOrderAt(orderID, price, qty, version, time.Now().UnixNano())
+}
+
+// AmendOrderAt 以now(纳秒)作为当前时间改单
+func (h *HybridOrderBook) AmendOrderAt(orderID uint64, price int64, qty int64, version uint32, now int64) (*types.MatchResult, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	value, exists := h.orderMap.Load(orderID)
+	if !exists {
+		if h.triggers.Get(orderID) != nil {
+			return nil, ErrStopOrderAmend
+		}
+		return nil, ErrOrderNotFound
+	}
+	order := value.(*types.Order)
+
+	if order.Version != version {
+		return nil, ErrVersionConflict
+	}
+	if price < 0 || qty < 0 {
+		return nil, ErrInvalidAmend
+	}
+
+	if !h.phase.AcceptsAmend() {
+		return nil, ErrPhaseRejected
+	}
+
+	leavesQty := order.VisibleQty + order.HiddenQty
+	if price == 0 {
+		price = order.Price
+	}
+	if qty == 0 {
+		qty = leavesQty
+	}
+
+	// 价格及数量均未变化，不修改订单
+	if price == order.Price && qty == leavesQty {
+		result := types.GetMatchResultFromPool()
+		result.Order = order
+		result.Timestamp = now
+		result.RestingQty = qty
+		return result, nil
+	}
+
+	// 重新排队前确认按新价格/数量撮合不会被整体拒绝，被拒绝时保留原挂单
+	if price != order.Price || qty > leavesQty {
+		if err := h.checkRequeue(order, price, qty, now); err != nil {
+			return nil, err
+		}
+	}
+
+	order.Version++
+
+	// 原地减量，保持时间优先级
+	if price == order.Price && qty < leavesQty {
+		level := h.treeOf(order).Get(order.Price)
+		visibleQty := order.VisibleQty
+		h.decrementOrder(level, order, leavesQty-qty)
+		if order.VisibleQty != visibleQty {
+			h.recordOrderEvent(OrderEventModify, order, order.VisibleQty)
+		}
+
+		result := types.GetMatchResultFromPool()
+		result.Order = order
+		result.Timestamp = now
+		result.RestingQty = qty
+		h.version++
+		return result, nil
+	}
+
+	// 撤出原位置后按新价格/数量重新撮合，失去时间优先级
+	h.removeOrder(order)
+	h.recordOrderEvent(OrderEventDelete, order, 0)
+	order.Price = price
+	order.Quantity = qty
+
+	result := h.match(order, now)
+	h.fireTriggers(result, now)
+
+	return result, nil
+}
+
+// checkRequeue 按match的拒绝条件检查改单后重新撮合的订单(调用方需持有写锁)
+// 只挂单阶段及Post-Only订单改价后不能吃单，Slide模式的订单能调整到对手最优价内时允许改价
+func (h *HybridOrderBook) checkRequeue(order *types.Order, price, qty, now int64) error {
+	// 已过期的GTD挂单等待过期清理，视为不存在
+	if order.IsExpired(now) {
+		return ErrOrderNotFound
+	}
+
+	amended := *order
+	amended.Price = price
+	amended.Quantity = qty
+	if h.phase.CheckOrder(&amended) != "" {
+		return ErrPhaseRejected
+	}
+
+	if (order.IsPostOnly() || h.phase == types.PhasePostOnly) && h.wouldCross(&amended) {
+		if h.phase == types.PhasePostOnly || order.PostOnly != types.PostOnlySlide || !h.slideInsideSpread(&amended) {
+			return ErrAmendWouldTake
+		}
+	}
+	return nil
+}
+
+// ExpireOrders 撤销在now(纳秒)之前过期的GTD挂单，返回被撤销的订单ID
+func (h *HybridOrderBook) ExpireOrders(now int64) []uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.expireOrders(now)
+}
+
+// HasExpired 是否有在now(纳秒)之前到期的GTD订单待清理
+func (h *HybridOrderBook) HasExpired(now int64) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.expiries.Len() > 0 && (*h.expiries)[0].expireTime <= now
+}
+
+// NextExpiry 过期队列中最早的到期时间(纳秒)，队列中可能包含已成交或已撤销的订单
+func (h *HybridOrderBook) NextExpiry() (int64, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.expiries.Len() == 0 {
+		return 0, false
+	}
+	return (*h.expiries)[0].expireTime, true
+}
+
+// expireOrders 撤销过期GTD挂单(调用方需持有写锁)
+func (h *HybridOrderBook) expireOrders(now int64) []uint64 {
+	var expiredIDs []uint64
+
+	for _, orderID := range h.expiries.popExpired(now) {
+		// 订单可能已成交、已撤销或已触发，只处理仍在订单簿或触发簿中且确已过期的订单
+		order := h.triggers.Get(orderID)
+		if value, exists := h.orderMap.Load(orderID); exists {
+			order = value.(*types.Order)
+		}
+		if order == nil || !order.IsExpired(now) {
+			continue
+		}
+
+		if h.cancelOrder(orderID) {
+			expiredIDs = append(expiredIDs, orderID)
+			monitor.RecordOrderRejected(h.symbol, "gtd_expired")
+		}
+	}
+
+	return expiredIDs
+}
+
 // GetBestBid 获取最优买价
-func (h *HybridOrderBook) GetBestBid() (float64, int64) {
+func (h *HybridOrderBook) GetBestBid() (int64, int64) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
@@ -375,11 +1180,11 @@ func (h *HybridOrderBook) GetBestBid() (float64, int64) {
 		return 0, 0
 	}
 
-	return bestBid.Price, bestBid.TotalQty
+	return bestBid.Price, bestBid.VisibleQty
 }
 
 // GetBestAsk 获取最优卖价
-func (h *HybridOrderBook) GetBestAsk() (float64, int64) {
+func (h *HybridOrderBook) GetBestAsk() (int64, int64) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
@@ -392,11 +1197,11 @@ func (h *HybridOrderBook) GetBestAsk() (float64, int64) {
 		return 0, 0
 	}
 
-	return bestAsk.Price, bestAsk.TotalQty
+	return bestAsk.Price, bestAsk.VisibleQty
 }
 
 // GetSpread 获取买卖价差
-func (h *HybridOrderBook) GetSpread() float64 {
+func (h *HybridOrderBook) GetSpread() int64 {
 	bid, _ := h.GetBestBid()
 	ask, _ := h.GetBestAsk()
 
@@ -408,11 +1213,11 @@ func (h *HybridOrderBook) GetSpread() float64 {
 }
 
 // GetBestBidAndAsk 获取最优买卖价
-func (h *HybridOrderBook) GetBestBidAndAsk() (float64, float64) {
+func (h *HybridOrderBook) GetBestBidAndAsk() (int64, int64) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
-	var bestBid, bestAsk float64
+	var bestBid, bestAsk int64
 
 	if h.buys.Len() > 0 {
 		if bestBidNode := h.buys.MaxPriceNode(); bestBidNode != nil {
@@ -434,7 +1239,7 @@ func (h *HybridOrderBook) UpdateSpreadMetrics() {
 	bid, ask := h.GetBestBidAndAsk()
 	if bid > 0 && ask > 0 {
 		spread := ask - bid
-		monitor.SetOrderBookSpread(h.symbol, spread)
+		monitor.SetOrderBookSpread(h.symbol, h.precision.PriceFloat(spread))
 	}
 }
 
@@ -498,9 +1303,18 @@ func (h *HybridOrderBook) recordTrade(trade *types.Trade) {
 	// 例如: h.tradeLogger.Log(trade)
 }
 
-// generateTradeID 生成交易ID
-func generateTradeID() uint64 {
-	return uint64(time.Now().UnixNano())
+// nextTradeID 分配成交ID(调用方需持有写锁)
+func (h *HybridOrderBook) nextTradeID() uint64 {
+	h.tradeSeq++
+	return h.tradeSeq
+}
+
+// SetTradeSeq 设置成交ID的起始值，之后的成交ID从seq+1开始，用于重新上架的交易对延续下架前的编号
+func (h *HybridOrderBook) SetTradeSeq(seq uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.tradeSeq = seq
 }
 
 func min(a, b int64) int64 {
@@ -0,0 +1,163 @@
+package orderbook
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+)
+
+// 撮合算法
+const (
+	MatchFIFO            = "fifo"        // 价格时间优先
+	MatchProRata         = "prorata"     // 同价位按挂单数量比例分配
+	MatchProRataTopOrder = "prorata_top" // 同价位队首订单优先成交，剩余数量按比例分配
+)
+
+var ErrInvalidMatchPolicy = errors.New("invalid match policy")
+
+// MatchPolicy 价格层级内成交数量的分配策略，相同的订单簿与吃单总是得到相同的分配结果
+type MatchPolicy interface {
+	// Name 撮合算法名称
+	Name() string
+	// Matchable 层级内可与吃单成交的数量，含冰山单隐藏数量
+	Matchable(taker *types.Order, level *PriceLevel) int64
+	// Allocate 按挂单的展示数量分配qty，返回与level.Orders按下标对应的成交数量，总和不超过qty
+	Allocate(taker *types.Order, level *PriceLevel, qty int64) []int64
+}
+
+// NewMatchPolicy 按算法名称创建撮合策略
+// minAllocation: 按比例分配时单个挂单的最小成交数量，分配不足的挂单本轮不成交
+// lotSize: 按比例分配的数量向下取整到lotSize的整数倍
+// 取整及最小数量产生的余量按时间优先依次分配
+func NewMatchPolicy(name string, minAllocation, lotSize int64) (MatchPolicy, error) {
+	if minAllocation < 0 {
+		return nil, ErrInvalidMatchPolicy
+	}
+	if lotSize <= 0 {
+		lotSize = 1
+	}
+
+	switch name {
+	case "", MatchFIFO:
+		return FIFOPolicy{}, nil
+	case MatchProRata:
+		return &ProRataPolicy{MinAllocation: minAllocation, LotSize: lotSize}, nil
+	case MatchProRataTopOrder:
+		return &ProRataPolicy{MinAllocation: minAllocation, LotSize: lotSize, TopOrder: true}, nil
+	default:
+		return nil, ErrInvalidMatchPolicy
+	}
+}
+
+// FIFOPolicy 价格时间优先，按进入层级的先后顺序依次成交
+type FIFOPolicy struct{}
+
+func (FIFOPolicy) Name() string { return MatchFIFO }
+
+// Matchable 层级内在遇到同一账户挂单之前可成交的数量
+func (FIFOPolicy) Matchable(taker *types.Order, level *PriceLevel) int64 {
+	return matchableQty(taker, level)
+}
+
+func (FIFOPolicy) Allocate(taker *types.Order, level *PriceLevel, qty int64) []int64 {
+	allocs := make([]int64, len(level.Orders))
+	for i, maker := range level.Orders {
+		if qty == 0 || isSelfTrade(taker, maker) {
+			break
+		}
+		allocs[i] = min(qty, maker.VisibleQty)
+		qty -= allocs[i]
+	}
+	return allocs
+}
+
+// ProRataPolicy 同价位按挂单展示数量比例分配
+// 订单簿在分配前已对层级内的同账户挂单执行自成交预防，分配时仍跳过同账户挂单作为保护
+// TopOrder为true时层级队首订单先按其展示数量成交，剩余数量再按比例分配
+type ProRataPolicy struct {
+	MinAllocation int64
+	LotSize       int64
+	TopOrder      bool
+}
+
+func (p *ProRataPolicy) Name() string {
+	if p.TopOrder {
+		return MatchProRataTopOrder
+	}
+	return MatchProRata
+}
+
+// Matchable 层级内除同一账户挂单外的全部数量
+func (p *ProRataPolicy) Matchable(taker *types.Order, level *PriceLevel) int64 {
+	if taker.ClientID == "" {
+		return level.TotalQty
+	}
+
+	qty := level.TotalQty
+	for _, maker := range level.Orders {
+		if isSelfTrade(taker, maker) {
+			qty -= maker.VisibleQty + maker.HiddenQty
+		}
+	}
+	return qty
+}
+
+func (p *ProRataPolicy) Allocate(taker *types.Order, level *PriceLevel, qty int64) []int64 {
+	allocs := make([]int64, len(level.Orders))
+
+	var total int64
+	for _, maker := range level.Orders {
+		if !isSelfTrade(taker, maker) {
+			total += maker.VisibleQty
+		}
+	}
+	qty = min(qty, total)
+
+	// 队首订单优先
+	start := 0
+	if p.TopOrder && len(level.Orders) > 0 && !isSelfTrade(taker, level.Orders[0]) {
+		allocs[0] = min(qty, level.Orders[0].VisibleQty)
+		qty -= allocs[0]
+		total -= level.Orders[0].VisibleQty
+		start = 1
+	}
+	if qty == 0 {
+		return allocs
+	}
+
+	// 按比例向下取整，不足最小成交数量的挂单本轮不分配
+	remaining := qty
+	for i := start; i < len(level.Orders); i++ {
+		maker := level.Orders[i]
+		if isSelfTrade(taker, maker) || maker.VisibleQty == 0 {
+			continue
+		}
+		share := mulDiv(qty, maker.VisibleQty, total)
+		share -= share % p.LotSize
+		if share < p.MinAllocation {
+			share = 0
+		}
+		allocs[i] = share
+		remaining -= share
+	}
+
+	// 余量按时间优先分配
+	for i := start; i < len(level.Orders) && remaining > 0; i++ {
+		maker := level.Orders[i]
+		if isSelfTrade(taker, maker) {
+			continue
+		}
+		extra := min(remaining, maker.VisibleQty-allocs[i])
+		allocs[i] += extra
+		remaining -= extra
+	}
+	return allocs
+}
+
+// mulDiv 计算a*b/c并向下取整，中间结果按128位计算避免溢出，要求a<=c
+func mulDiv(a, b, c int64) int64 {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	quo, _ := bits.Div64(hi, lo, uint64(c))
+	return int64(quo)
+}
@@ -0,0 +1,67 @@
+package orderbook
+
+import "github.com/tsfdsong/tradeengin/app/pkg/types"
+
+// OrderEventType 逐笔委托事件类型
+type OrderEventType uint8
+
+const (
+	OrderEventAdd     OrderEventType = 1 // 挂单进入价格层级队尾，冰山单补充峰值后重新排队也记为新增
+	OrderEventModify  OrderEventType = 2 // 原地减少展示数量，保持时间优先级
+	OrderEventExecute OrderEventType = 3 // 挂单成交
+	OrderEventDelete  OrderEventType = 4 // 撤单、过期、自成交撤销或改单撤出原位置
+)
+
+// OrderEvent 逐笔委托事件，只包含公开信息，不含账户及ClientID
+// Quantity为事件后的展示数量，为0时订单已移出订单簿(成交完或补充峰值前)
+type OrderEvent struct {
+	Seq      uint64 // 同一交易对内连续递增
+	Type     OrderEventType
+	OrderID  uint64
+	Side     int8
+	Price    int64
+	Quantity int64
+	ExecQty  int64  // 本次成交数量，仅Execute有效
+	TradeID  uint64 // 仅Execute有效
+}
+
+// recordOrderEvent 记录逐笔委托事件，qty为事件后的展示数量(调用方需持有写锁)
+func (h *HybridOrderBook) recordOrderEvent(eventType OrderEventType, order *types.Order, qty int64) {
+	h.feedSeq++
+	h.orderEvents = append(h.orderEvents, OrderEvent{
+		Seq:      h.feedSeq,
+		Type:     eventType,
+		OrderID:  order.ID,
+		Side:     order.Side,
+		Price:    order.Price,
+		Quantity: qty,
+	})
+}
+
+// recordExecute 记录挂单成交，order为扣减后的挂单(调用方需持有写锁)
+func (h *HybridOrderBook) recordExecute(order *types.Order, trade *types.Trade) {
+	h.recordOrderEvent(OrderEventExecute, order, order.VisibleQty)
+	event := &h.orderEvents[len(h.orderEvents)-1]
+	event.ExecQty = trade.Quantity
+	event.TradeID = trade.TradeID
+}
+
+// OrderEvents 取出上次取出之后的逐笔委托事件
+func (h *HybridOrderBook) OrderEvents() []OrderEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	events := h.orderEvents
+	h.orderEvents = nil
+	return events
+}
+
+// DiscardFeeds 丢弃尚未取出的增量深度及逐笔委托事件，用于日志重放等无需推送的场景
+func (h *HybridOrderBook) DiscardFeeds() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.dirty = h.dirty[:0]
+	h.depthID = h.version
+	h.orderEvents = nil
+}
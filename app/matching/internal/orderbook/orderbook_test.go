@@ -1,8 +1,12 @@
 package orderbook
 
 import (
+	"errors"
+	"reflect"
+	"slices"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/tsfdsong/tradeengin/app/pkg/types"
 )
@@ -110,7 +114,7 @@ func TestSkipTree_GetTopLevels(t *testing.T) {
 	tree := NewSkipTree(16, false)
 
 	for i := 1; i <= 10; i++ {
-		tree.Insert(float64(i*100), &PriceLevel{Price: float64(i * 100), TotalQty: int64(i * 1000)})
+		tree.Insert(int64(i*100), &PriceLevel{Price: int64(i * 100), TotalQty: int64(i * 1000)})
 	}
 
 	levels := tree.GetTopLevels(5)
@@ -120,7 +124,7 @@ func TestSkipTree_GetTopLevels(t *testing.T) {
 
 	// 升序应该从100开始
 	if levels[0].Price != 100.0 {
-		t.Errorf("Expected first level price 100.0, got %f", levels[0].Price)
+		t.Errorf("Expected first level price 100.0, got %d", levels[0].Price)
 	}
 }
 
@@ -128,11 +132,11 @@ func TestSkipTree_Range(t *testing.T) {
 	tree := NewSkipTree(16, false)
 
 	for i := 1; i <= 5; i++ {
-		tree.Insert(float64(i*100), &PriceLevel{Price: float64(i * 100)})
+		tree.Insert(int64(i*100), &PriceLevel{Price: int64(i * 100)})
 	}
 
-	var prices []float64
-	tree.Range(func(price float64, level *PriceLevel) bool {
+	var prices []int64
+	tree.Range(func(price int64, level *PriceLevel) bool {
 		prices = append(prices, price)
 		return true
 	})
@@ -146,7 +150,7 @@ func TestSkipTree_Validate(t *testing.T) {
 	tree := NewSkipTree(16, false)
 
 	for i := 1; i <= 100; i++ {
-		tree.Insert(float64(i), &PriceLevel{Price: float64(i)})
+		tree.Insert(int64(i), &PriceLevel{Price: int64(i)})
 	}
 
 	if !tree.Validate() {
@@ -158,7 +162,7 @@ func TestSkipTree_Clear(t *testing.T) {
 	tree := NewSkipTree(16, false)
 
 	for i := 1; i <= 10; i++ {
-		tree.Insert(float64(i), &PriceLevel{Price: float64(i)})
+		tree.Insert(int64(i), &PriceLevel{Price: int64(i)})
 	}
 
 	tree.Clear()
@@ -177,7 +181,7 @@ func TestSkipTree_ConcurrentInsert(t *testing.T) {
 		go func(id int) {
 			defer wg.Done()
 			for j := 0; j < 100; j++ {
-				price := float64(id*100 + j)
+				price := int64(id*100 + j)
 				tree.Insert(price, &PriceLevel{Price: price})
 			}
 		}(i)
@@ -311,10 +315,861 @@ func TestHybridOrderBook_GetBestBidAsk(t *testing.T) {
 	ask, _ := ob.GetBestAsk()
 
 	if bid != 49900.0 {
-		t.Errorf("Expected best bid 49900.0, got %f", bid)
+		t.Errorf("Expected best bid 49900.0, got %d", bid)
 	}
 	if ask != 50100.0 {
-		t.Errorf("Expected best ask 50100.0, got %f", ask)
+		t.Errorf("Expected best ask 50100.0, got %d", ask)
+	}
+}
+
+func TestHybridOrderBook_Match_IOC(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 50000.0, Quantity: 30, Side: types.SideSell, Type: types.TypeLimit}, 30)
+
+	// IOC买单成交30，剩余20撤销，不挂入订单簿
+	result := ob.Match(&types.Order{
+		ID:          2,
+		Symbol:      "BTCUSDT",
+		Price:       50000.0,
+		Quantity:    50,
+		Side:        types.SideBuy,
+		Type:        types.TypeLimit,
+		TimeInForce: types.TimeInForceIOC,
+	})
+
+	if result.TotalFilledQty() != 30 {
+		t.Errorf("Expected filled quantity 30, got %d", result.TotalFilledQty())
+	}
+	if result.CancelledQty != 20 || result.RestingQty != 0 {
+		t.Errorf("Expected cancelled 20 and resting 0, got %d and %d", result.CancelledQty, result.RestingQty)
+	}
+	if bid, _ := ob.GetBestBid(); bid != 0 {
+		t.Errorf("IOC remainder should not rest in book, best bid %d", bid)
+	}
+}
+
+func TestHybridOrderBook_Match_FOK(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 50000.0, Quantity: 30, Side: types.SideSell, Type: types.TypeLimit}, 30)
+	ob.addOrderToBook(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 50100.0, Quantity: 30, Side: types.SideSell, Type: types.TypeLimit}, 30)
+
+	// 价格范围内只有30，FOK买单50无法全部成交，订单簿不变
+	result := ob.Match(&types.Order{
+		ID:          3,
+		Symbol:      "BTCUSDT",
+		Price:       50000.0,
+		Quantity:    50,
+		Side:        types.SideBuy,
+		Type:        types.TypeLimit,
+		TimeInForce: types.TimeInForceFOK,
+	})
+
+	if len(result.Trades) != 0 || result.CancelledQty != 50 {
+		t.Errorf("FOK should be killed entirely, got %d trades, cancelled %d", len(result.Trades), result.CancelledQty)
+	}
+	if _, qty := ob.GetBestAsk(); qty != 30 {
+		t.Errorf("FOK kill should not touch the book, best ask qty %d", qty)
+	}
+
+	// 价格放宽到50100后可以全部成交
+	result = ob.Match(&types.Order{
+		ID:          4,
+		Symbol:      "BTCUSDT",
+		Price:       50100.0,
+		Quantity:    50,
+		Side:        types.SideBuy,
+		Type:        types.TypeLimit,
+		TimeInForce: types.TimeInForceFOK,
+	})
+
+	if result.TotalFilledQty() != 50 || result.CancelledQty != 0 {
+		t.Errorf("FOK should fill entirely, filled %d, cancelled %d", result.TotalFilledQty(), result.CancelledQty)
+	}
+}
+
+func TestHybridOrderBook_Match_MarketRemainderCancelled(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 50000.0, Quantity: 10, Side: types.SideBuy, Type: types.TypeLimit}, 10)
+
+	result := ob.Match(&types.Order{ID: 2, Symbol: "BTCUSDT", Quantity: 25, Side: types.SideSell, Type: types.TypeMarket})
+
+	if result.TotalFilledQty() != 10 || result.CancelledQty != 15 {
+		t.Errorf("Expected filled 10 and cancelled 15, got %d and %d", result.TotalFilledQty(), result.CancelledQty)
+	}
+}
+
+func TestHybridOrderBook_ExpireOrders_GTD(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	now := time.Now().UnixNano()
+
+	gtd := &types.Order{
+		ID:          1,
+		Symbol:      "BTCUSDT",
+		Price:       49900.0,
+		Quantity:    100,
+		Side:        types.SideBuy,
+		Type:        types.TypeLimit,
+		TimeInForce: types.TimeInForceGTD,
+		ExpireTime:  now + int64(time.Hour),
+	}
+	result := ob.Match(gtd)
+	if result.RestingQty != 100 {
+		t.Fatalf("GTD order should rest in book, resting %d", result.RestingQty)
+	}
+
+	// 未到过期时间不撤销
+	if expired := ob.ExpireOrders(now); len(expired) != 0 {
+		t.Errorf("Expected no expired orders, got %v", expired)
+	}
+
+	// 到达过期时间后撤销
+	expired := ob.ExpireOrders(now + int64(time.Hour))
+	if len(expired) != 1 || expired[0] != 1 {
+		t.Errorf("Expected order 1 expired, got %v", expired)
+	}
+	if bid, _ := ob.GetBestBid(); bid != 0 {
+		t.Errorf("Expired GTD order should be removed, best bid %d", bid)
+	}
+
+	// 到达时已过期的GTD订单直接撤销
+	result = ob.Match(&types.Order{
+		ID:          2,
+		Symbol:      "BTCUSDT",
+		Price:       49900.0,
+		Quantity:    100,
+		Side:        types.SideBuy,
+		Type:        types.TypeLimit,
+		TimeInForce: types.TimeInForceGTD,
+		ExpireTime:  now - 1,
+	})
+	if result.CancelledQty != 100 || result.RestingQty != 0 {
+		t.Errorf("Expired GTD order should be cancelled, cancelled %d", result.CancelledQty)
+	}
+}
+
+func TestHybridOrderBook_Match_PostOnly(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 50000.0, Quantity: 100, Side: types.SideSell, Type: types.TypeLimit}, 100)
+
+	// 拒绝模式: 会吃单的订单整单拒绝
+	result := ob.Match(&types.Order{
+		ID:       2,
+		Symbol:   "BTCUSDT",
+		Price:    50000.0,
+		Quantity: 10,
+		Side:     types.SideBuy,
+		Type:     types.TypeLimit,
+		PostOnly: types.PostOnlyReject,
+	})
+	if len(result.Trades) != 0 || result.RejectReason != types.RejectReasonPostOnly {
+		t.Errorf("Post-only reject should not trade, got %d trades, reason %q", len(result.Trades), result.RejectReason)
+	}
+
+	// 滑价模式: 调整到最优卖价内一个tick挂单
+	ob.SetTickSize(5)
+	order := &types.Order{
+		ID:       3,
+		Symbol:   "BTCUSDT",
+		Price:    50010.0,
+		Quantity: 10,
+		Side:     types.SideBuy,
+		Type:     types.TypeLimit,
+		PostOnly: types.PostOnlySlide,
+	}
+	result = ob.Match(order)
+	if len(result.Trades) != 0 || result.RestingQty != 10 {
+		t.Errorf("Post-only slide should rest without trading, got %d trades, resting %d", len(result.Trades), result.RestingQty)
+	}
+	if bid, _ := ob.GetBestBid(); bid != 49995 {
+		t.Errorf("Expected slid price 49995, got %d", bid)
+	}
+
+	// 不会吃单时按原价挂单
+	result = ob.Match(&types.Order{
+		ID:       4,
+		Symbol:   "BTCUSDT",
+		Price:    50001.0,
+		Quantity: 10,
+		Side:     types.SideSell,
+		Type:     types.TypeLimit,
+		PostOnly: types.PostOnlyReject,
+	})
+	if result.RestingQty != 10 || result.RejectReason != "" {
+		t.Errorf("Non-crossing post-only order should rest, resting %d, reason %q", result.RestingQty, result.RejectReason)
+	}
+}
+
+func TestHybridOrderBook_Match_StopOrders(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 100.0, Quantity: 1, Side: types.SideSell, Type: types.TypeLimit}, 1)
+	ob.addOrderToBook(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 101.0, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit}, 5)
+	ob.addOrderToBook(&types.Order{ID: 3, Symbol: "BTCUSDT", Price: 102.0, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit}, 5)
+
+	// 尚无成交价，止损单进入触发簿
+	stops := []*types.Order{
+		{ID: 10, Symbol: "BTCUSDT", Quantity: 5, Side: types.SideBuy, Type: types.TypeStopMarket, StopPrice: 101.0},
+		{ID: 11, Symbol: "BTCUSDT", Price: 101.0, Quantity: 5, Side: types.SideBuy, Type: types.TypeStopLimit, StopPrice: 100.0},
+		{ID: 12, Symbol: "BTCUSDT", Quantity: 5, Side: types.SideBuy, Type: types.TypeStopMarket, StopPrice: 100.0},
+	}
+	for _, stop := range stops {
+		result := ob.Match(stop)
+		if len(result.Trades) != 0 || result.RestingQty != 5 {
+			t.Fatalf("Untriggered stop %d should rest in trigger book, got %d trades, resting %d", stop.ID, len(result.Trades), result.RestingQty)
+		}
+	}
+	if ob.GetPendingStopCount() != 3 {
+		t.Fatalf("Expected 3 pending stops, got %d", ob.GetPendingStopCount())
+	}
+
+	// 成交价100触发触发价100的止损单(同价按提交顺序)，其成交继续抬高成交价并触发触发价101的止损单
+	result := ob.Match(&types.Order{ID: 20, Symbol: "BTCUSDT", Price: 100.0, Quantity: 1, Side: types.SideBuy, Type: types.TypeLimit})
+	if len(result.Triggered) != 3 {
+		t.Fatalf("Expected 3 triggered stops, got %d", len(result.Triggered))
+	}
+	for i, id := range []uint64{11, 12, 10} {
+		if got := result.Triggered[i].Order.ID; got != id {
+			t.Errorf("Triggered[%d]: expected order %d, got %d", i, id, got)
+		}
+	}
+	if result.Triggered[0].Order.Type != types.TypeLimit || result.Triggered[1].Order.Type != types.TypeMarket {
+		t.Errorf("Triggered stops should convert to limit/market orders")
+	}
+	if ob.GetLastPrice() != 102.0 {
+		t.Errorf("Expected last price 102, got %d", ob.GetLastPrice())
+	}
+	if ob.GetPendingStopCount() != 0 {
+		t.Errorf("Expected no pending stops, got %d", ob.GetPendingStopCount())
+	}
+}
+
+func TestHybridOrderBook_CancelStopOrder(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.Match(&types.Order{ID: 1, Symbol: "BTCUSDT", Quantity: 5, Side: types.SideSell, Type: types.TypeStopMarket, StopPrice: 90.0})
+
+	if !ob.CancelOrder(1) {
+		t.Fatal("Cancel untriggered stop order failed")
+	}
+	if ob.GetPendingStopCount() != 0 {
+		t.Errorf("Expected no pending stops after cancel, got %d", ob.GetPendingStopCount())
+	}
+	if ob.CancelOrder(1) {
+		t.Error("Cancel should fail for already cancelled stop order")
+	}
+}
+
+func TestHybridOrderBook_CancelAll(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.Match(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 100, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit})
+	ob.Match(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 99, Quantity: 30, DisplayQty: 10, Side: types.SideBuy, Type: types.TypeLimit})
+	ob.Match(&types.Order{ID: 3, Symbol: "BTCUSDT", Quantity: 5, Side: types.SideSell, Type: types.TypeStopMarket, StopPrice: 90})
+
+	cancelled := ob.CancelAll()
+	slices.Sort(cancelled)
+	if !slices.Equal(cancelled, []uint64{1, 2, 3}) {
+		t.Fatalf("Expected orders 1,2,3 cancelled, got %v", cancelled)
+	}
+	if ob.buys.Len() != 0 || ob.sells.Len() != 0 || ob.GetPendingStopCount() != 0 {
+		t.Errorf("Expected empty book, bid levels %d, ask levels %d, stops %d", ob.buys.Len(), ob.sells.Len(), ob.GetPendingStopCount())
+	}
+	if ob.CancelOrder(1) || len(ob.CancelAll()) != 0 {
+		t.Error("Orders should not be cancelled twice")
+	}
+}
+
+func TestHybridOrderBook_Match_Iceberg(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 100.0, Quantity: 30, Side: types.SideSell, Type: types.TypeLimit, DisplayQty: 10}, 30)
+	ob.addOrderToBook(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 100.0, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit}, 5)
+
+	// 只展示冰山单的峰值数量
+	if _, qty := ob.GetBestAsk(); qty != 15 {
+		t.Errorf("Expected visible ask quantity 15, got %d", qty)
+	}
+
+	// 峰值成交完后补充，补充部分排到同价位队尾
+	ob.Match(&types.Order{ID: 3, Symbol: "BTCUSDT", Price: 100.0, Quantity: 12, Side: types.SideBuy, Type: types.TypeLimit})
+	level := ob.sells.Get(100.0)
+	if level == nil || level.TotalQty != 23 || level.VisibleQty != 13 {
+		t.Fatalf("Expected total 23 visible 13 after refill, got %+v", level)
+	}
+	if level.Orders[0].ID != 2 || level.Orders[1].ID != 1 {
+		t.Errorf("Refilled iceberg should lose time priority, got order %d first", level.Orders[0].ID)
+	}
+	if snapshot := ob.GetSnapshot(1); snapshot.Asks[0].Quantity != 13 {
+		t.Errorf("Snapshot should only show visible quantity 13, got %d", snapshot.Asks[0].Quantity)
+	}
+
+	// 隐藏数量同样参与撮合
+	result := ob.Match(&types.Order{ID: 4, Symbol: "BTCUSDT", Quantity: 23, Side: types.SideBuy, Type: types.TypeMarket})
+	if result.TotalFilledQty() != 23 || ob.sells.Len() != 0 {
+		t.Errorf("Expected hidden quantity to fill, filled %d, ask levels %d", result.TotalFilledQty(), ob.sells.Len())
+	}
+}
+
+func TestHybridOrderBook_CancelIcebergOrder(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 100.0, Quantity: 30, Side: types.SideBuy, Type: types.TypeLimit, DisplayQty: 10}, 30)
+
+	if !ob.CancelOrder(1) {
+		t.Fatal("Cancel iceberg order failed")
+	}
+	if ob.buys.Len() != 0 {
+		t.Errorf("Cancel should remove hidden quantity with the level, bid levels %d", ob.buys.Len())
+	}
+}
+
+func TestHybridOrderBook_Match_SelfTradePrevention(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          int8
+		trades        int
+		filledQty     int64
+		cancelledQty  int64
+		makerRemoved  bool
+		restingAskQty int64
+	}{
+		{name: "cancel newest", mode: types.STPCancelNewest, trades: 0, filledQty: 0, cancelledQty: 15, makerRemoved: false, restingAskQty: 20},
+		{name: "cancel oldest", mode: types.STPCancelOldest, trades: 1, filledQty: 10, cancelledQty: 0, makerRemoved: true, restingAskQty: 0},
+		{name: "cancel both", mode: types.STPCancelBoth, trades: 0, filledQty: 0, cancelledQty: 15, makerRemoved: true, restingAskQty: 10},
+		{name: "decrement and cancel", mode: types.STPDecrementCancel, trades: 1, filledQty: 5, cancelledQty: 10, makerRemoved: true, restingAskQty: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ob := NewHybridOrderBook("BTCUSDT")
+			ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 100.0, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit, ClientID: "alice"}, 10)
+			ob.addOrderToBook(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 100.0, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit, ClientID: "bob"}, 10)
+
+			result := ob.Match(&types.Order{ID: 3, Symbol: "BTCUSDT", Price: 100.0, Quantity: 15, Side: types.SideBuy, Type: types.TypeLimit, ClientID: "alice", STPMode: tt.mode})
+
+			if len(result.Trades) != tt.trades || result.TotalFilledQty() != tt.filledQty {
+				t.Errorf("Expected %d trades filling %d, got %d trades filling %d", tt.trades, tt.filledQty, len(result.Trades), result.TotalFilledQty())
+			}
+			for _, trade := range result.Trades {
+				if trade.MakerOrderID == 1 {
+					t.Errorf("Self trade against own maker order executed")
+				}
+			}
+			if result.CancelledQty != tt.cancelledQty {
+				t.Errorf("Expected cancelled %d, got %d", tt.cancelledQty, result.CancelledQty)
+			}
+			if len(result.SelfTrades) != 1 || result.SelfTrades[0].MakerOrderID != 1 || result.SelfTrades[0].MakerRemoved != tt.makerRemoved {
+				t.Fatalf("Expected one self-trade event against order 1, got %+v", result.SelfTrades)
+			}
+			if _, qty := ob.GetBestAsk(); qty != tt.restingAskQty {
+				t.Errorf("Expected resting ask quantity %d, got %d", tt.restingAskQty, qty)
+			}
+		})
+	}
+}
+
+func TestHybridOrderBook_Match_SelfTradeSymbolDefault(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.SetSTPMode(types.STPCancelOldest)
+	ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 100.0, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit, ClientID: "bob"}, 10)
+	ob.addOrderToBook(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 100.0, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit, ClientID: "alice"}, 10)
+
+	// 先与他人挂单成交，遇到自己的挂单时按交易对默认模式撤销旧订单
+	result := ob.Match(&types.Order{ID: 3, Symbol: "BTCUSDT", Quantity: 15, Side: types.SideBuy, Type: types.TypeMarket, ClientID: "alice"})
+	if result.TotalFilledQty() != 10 || len(result.SelfTrades) != 1 {
+		t.Fatalf("Expected 10 filled and one self-trade event, got %d filled, %d events", result.TotalFilledQty(), len(result.SelfTrades))
+	}
+	if result.SelfTrades[0].Mode != types.STPCancelOldest || !result.SelfTrades[0].MakerRemoved {
+		t.Errorf("Expected symbol default cancel oldest, got %+v", result.SelfTrades[0])
+	}
+	if result.CancelledQty != 5 {
+		t.Errorf("Expected market remainder 5 cancelled, got %d", result.CancelledQty)
+	}
+}
+
+func TestHybridOrderBook_AmendOrder(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 100.0, Quantity: 10, Side: types.SideBuy, Type: types.TypeLimit}, 10)
+	ob.addOrderToBook(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 100.0, Quantity: 10, Side: types.SideBuy, Type: types.TypeLimit}, 10)
+	ob.addOrderToBook(&types.Order{ID: 3, Symbol: "BTCUSDT", Price: 101.0, Quantity: 3, Side: types.SideSell, Type: types.TypeLimit}, 3)
+
+	// 减量保持时间优先级
+	result, err := ob.AmendOrder(1, 0, 5, 0)
+	if err != nil {
+		t.Fatalf("Amend failed: %v", err)
+	}
+	if result.Order.Version != 1 || result.RestingQty != 5 {
+		t.Errorf("Expected version 1 resting 5, got version %d resting %d", result.Order.Version, result.RestingQty)
+	}
+	level := ob.buys.Get(100.0)
+	if level.TotalQty != 15 || level.Orders[0].ID != 1 {
+		t.Errorf("Reduce should keep priority, total %d, first order %d", level.TotalQty, level.Orders[0].ID)
+	}
+
+	// 过期版本拒绝
+	if _, err := ob.AmendOrder(1, 0, 4, 0); err != ErrVersionConflict {
+		t.Errorf("Expected version conflict, got %v", err)
+	}
+
+	// 价格及数量均未变化时不增加版本
+	bookVersion := ob.version
+	result, err = ob.AmendOrder(1, 100.0, 5, 1)
+	if err != nil || result.Order.Version != 1 || result.RestingQty != 5 || ob.version != bookVersion {
+		t.Errorf("Expected no-op amend to keep versions, got version %d resting %d book version %d, %v",
+			result.Order.Version, result.RestingQty, ob.version, err)
+	}
+
+	// 增量失去时间优先级
+	if _, err := ob.AmendOrder(1, 0, 8, 1); err != nil {
+		t.Fatalf("Amend failed: %v", err)
+	}
+	level = ob.buys.Get(100.0)
+	if level.TotalQty != 18 || level.Orders[0].ID != 2 {
+		t.Errorf("Increase should lose priority, total %d, first order %d", level.TotalQty, level.Orders[0].ID)
+	}
+
+	// 改价后可能立即成交
+	result, err = ob.AmendOrder(2, 101.0, 0, 0)
+	if err != nil {
+		t.Fatalf("Amend failed: %v", err)
+	}
+	if result.TotalFilledQty() != 3 || result.RestingQty != 7 {
+		t.Errorf("Expected 3 filled 7 resting after reprice, got %d filled %d resting", result.TotalFilledQty(), result.RestingQty)
+	}
+	if bid, qty := ob.GetBestBid(); bid != 101.0 || qty != 7 {
+		t.Errorf("Expected best bid 101 x 7, got %d x %d", bid, qty)
+	}
+
+	if _, err := ob.AmendOrder(99, 0, 1, 0); err != ErrOrderNotFound {
+		t.Errorf("Expected order not found, got %v", err)
+	}
+
+	// 未触发的止损单不支持改单
+	ob.addOrderToTriggers(&types.Order{ID: 4, Symbol: "BTCUSDT", StopPrice: 120, Quantity: 1, Side: types.SideBuy, Type: types.TypeStopMarket})
+	if _, err := ob.AmendOrder(4, 0, 2, 0); err != ErrStopOrderAmend {
+		t.Errorf("Expected ErrStopOrderAmend, got %v", err)
+	}
+
+	// Post-Only订单改价后会吃单时拒绝改单，原挂单保持不变
+	ob = NewHybridOrderBook("BTCUSDT")
+	ob.Match(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 101, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit})
+	ob.Match(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 99, Quantity: 1, Side: types.SideBuy, Type: types.TypeLimit, PostOnly: types.PostOnlyReject})
+	if _, err := ob.AmendOrder(2, 101, 0, 0); err != ErrAmendWouldTake {
+		t.Errorf("Expected ErrAmendWouldTake, got %v", err)
+	}
+	if order, ok := ob.RestingOrder(2); !ok || order.Price != 99 || order.Version != 0 || order.VisibleQty != 1 {
+		t.Errorf("Expected order 2 to rest unchanged at 99, got %+v (exists=%v)", order, ok)
+	}
+
+	// Slide模式调整到对手最优价内一个tick
+	ob.Match(&types.Order{ID: 3, Symbol: "BTCUSDT", Price: 98, Quantity: 1, Side: types.SideBuy, Type: types.TypeLimit, PostOnly: types.PostOnlySlide})
+	if result, err := ob.AmendOrder(3, 102, 0, 0); err != nil || len(result.Trades) != 0 || result.Order.Price != 100 {
+		t.Errorf("Expected slide amend to rest at 100, got %+v, %v", result, err)
+	}
+}
+
+func TestHybridOrderBook_Match_QuoteQty(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 100.0, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit}, 5)
+	ob.addOrderToBook(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 101.0, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit}, 10)
+
+	// 花费1000: 100x5 + 101x4，剩余96不足再买一个单位
+	result := ob.Match(&types.Order{ID: 3, Symbol: "BTCUSDT", QuoteQty: 1000.0, Side: types.SideBuy, Type: types.TypeMarket})
+	if result.TotalFilledQty() != 9 {
+		t.Errorf("Expected 9 filled, got %d", result.TotalFilledQty())
+	}
+	if result.RemainingQuote != 96 || result.CancelledQty != 0 {
+		t.Errorf("Expected remaining quote 96, cancelled 0, got %d, %d", result.RemainingQuote, result.CancelledQty)
+	}
+	if _, qty := ob.GetBestAsk(); qty != 6 {
+		t.Errorf("Expected 6 left at best ask, got %d", qty)
+	}
+}
+
+func TestHybridOrderBook_Match_MaxSlippage(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.SetMaxSlippage(50)
+	ob.SetPrecision(types.Precision{PriceScale: 2})
+	ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 10000, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit}, 5)
+	ob.addOrderToBook(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 10040, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit}, 5)
+	ob.addOrderToBook(&types.Order{ID: 3, Symbol: "BTCUSDT", Price: 10100, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit}, 5)
+
+	// 价格精度2位，50基点滑点范围为100.50，超出部分撤销
+	result := ob.Match(&types.Order{ID: 4, Symbol: "BTCUSDT", Quantity: 15, Side: types.SideBuy, Type: types.TypeMarket})
+	if result.TotalFilledQty() != 10 || result.CancelledQty != 5 {
+		t.Errorf("Expected 10 filled 5 cancelled, got %d filled %d cancelled", result.TotalFilledQty(), result.CancelledQty)
+	}
+	if ask, _ := ob.GetBestAsk(); ask != 10100 {
+		t.Errorf("Expected 101.00 to remain on the book, got %d", ask)
+	}
+}
+
+func TestHybridOrderBook_Match_NotionalOverflow(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 4_000_000_000_000_000_000, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit}, 5)
+
+	// 3x4e18超出int64，停止扫单，剩余数量撤销
+	result := ob.Match(&types.Order{ID: 2, Symbol: "BTCUSDT", Quantity: 3, Side: types.SideBuy, Type: types.TypeMarket})
+	if result.TotalFilledQty() != 0 || result.CancelledQty != 3 {
+		t.Errorf("Expected 0 filled 3 cancelled, got %d filled %d cancelled", result.TotalFilledQty(), result.CancelledQty)
+	}
+	if _, qty := ob.GetBestAsk(); qty != 5 {
+		t.Errorf("Expected ask to stay untouched, got %d", qty)
+	}
+}
+
+func TestHybridOrderBook_Match_PerMakerFills(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.addOrderToBook(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 100, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit}, 10)
+	ob.addOrderToBook(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 100, Quantity: 20, Side: types.SideSell, Type: types.TypeLimit}, 20)
+	ob.addOrderToBook(&types.Order{ID: 3, Symbol: "BTCUSDT", Price: 100, Quantity: 30, Side: types.SideSell, Type: types.TypeLimit}, 30)
+	ob.addOrderToBook(&types.Order{ID: 4, Symbol: "BTCUSDT", Price: 101, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit}, 5)
+
+	// 同价位按时间优先逐个成交，每个挂单一笔成交，成交ID按执行顺序递增，成交时间取命令时间
+	const now = int64(1700000000000000000)
+	result := ob.MatchAt(&types.Order{ID: 10, Symbol: "BTCUSDT", Price: 100, Quantity: 35, Side: types.SideBuy, Type: types.TypeLimit}, now)
+	expected := []struct {
+		makerID uint64
+		qty     int64
+	}{{1, 10}, {2, 20}, {3, 5}}
+	if len(result.Trades) != len(expected) {
+		t.Fatalf("Expected %d trades, got %d", len(expected), len(result.Trades))
+	}
+	for i, want := range expected {
+		trade := result.Trades[i]
+		if trade.MakerOrderID != want.makerID || trade.Quantity != want.qty || trade.Price != 100 {
+			t.Errorf("Trade %d: expected maker %d qty %d @100, got maker %d qty %d @%d",
+				i, want.makerID, want.qty, trade.MakerOrderID, trade.Quantity, trade.Price)
+		}
+		if trade.TakerOrderID != 10 || trade.TakerSide != types.SideBuy {
+			t.Errorf("Trade %d: unexpected taker %d side %d", i, trade.TakerOrderID, trade.TakerSide)
+		}
+		if trade.TradeID != uint64(i+1) || trade.Timestamp != now {
+			t.Errorf("Trade %d: expected id %d at %d, got %d at %d", i, i+1, now, trade.TradeID, trade.Timestamp)
+		}
+	}
+
+	// 部分成交的挂单保持队首位置和剩余数量
+	if _, ok := ob.orderMap.Load(uint64(1)); ok {
+		t.Error("Fully filled maker should leave the book")
+	}
+	value, ok := ob.orderMap.Load(uint64(3))
+	if !ok || value.(*types.Order).VisibleQty != 25 {
+		t.Fatalf("Expected maker 3 to keep 25 resting")
+	}
+
+	// 跨价位成交按各自挂单价格生成成交
+	result = ob.Match(&types.Order{ID: 11, Symbol: "BTCUSDT", Quantity: 27, Side: types.SideBuy, Type: types.TypeMarket})
+	if len(result.Trades) != 2 ||
+		result.Trades[0].MakerOrderID != 3 || result.Trades[0].Quantity != 25 || result.Trades[0].Price != 100 ||
+		result.Trades[1].MakerOrderID != 4 || result.Trades[1].Quantity != 2 || result.Trades[1].Price != 101 {
+		t.Errorf("Unexpected cross-level fills, got %d trades", len(result.Trades))
+	}
+	if price, qty := ob.GetBestAsk(); price != 101 || qty != 3 {
+		t.Errorf("Expected best ask 101 x 3, got %d x %d", price, qty)
+	}
+}
+
+func TestHybridOrderBook_SnapshotRestore(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	setup := []*types.Order{
+		{ID: 1, Price: 100, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit, ClientID: "alice"},
+		{ID: 2, Price: 100, Quantity: 20, DisplayQty: 5, Side: types.SideSell, Type: types.TypeLimit, ClientID: "bob"},
+		{ID: 3, Price: 101, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit},
+		{ID: 4, Price: 98, Quantity: 7, Side: types.SideBuy, Type: types.TypeLimit,
+			TimeInForce: types.TimeInForceGTD, ExpireTime: time.Now().Add(time.Hour).UnixNano()},
+		{ID: 5, StopPrice: 97, Quantity: 3, Side: types.SideSell, Type: types.TypeStopMarket},
+		{ID: 6, Price: 100, Quantity: 12, Side: types.SideBuy, Type: types.TypeLimit},
+	}
+	for _, order := range setup {
+		order.Symbol = "BTCUSDT"
+		ob.Match(order)
+	}
+
+	snapshot := ob.Snapshot()
+	snapshot.Seq = 42
+	data, err := snapshot.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &BookSnapshot{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Seq != 42 || decoded.FeedSeq != ob.feedSeq || decoded.TradeSeq != ob.tradeSeq || len(decoded.Bids) != 1 || len(decoded.Asks) != 2 || len(decoded.Stops) != 1 {
+		t.Fatalf("Unexpected decoded snapshot: %+v", decoded)
+	}
+	if decoded.Asks[0].ID != 2 || decoded.Asks[0].ClientID != "bob" || decoded.Asks[0].VisibleQty+decoded.Asks[0].HiddenQty != 18 {
+		t.Errorf("Expected partially filled iceberg at head of queue, got %+v", decoded.Asks[0])
+	}
+	if decoded.Asks[1].Arrival == 0 || decoded.Bids[0].Arrival <= decoded.Asks[1].Arrival {
+		t.Errorf("Expected arrival order to survive encoding, got bid %d ask %d", decoded.Bids[0].Arrival, decoded.Asks[1].Arrival)
+	}
+
+	restored := NewHybridOrderBook("BTCUSDT")
+	if err := restored.Restore(decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	// 相同的后续订单在两个订单簿上产生相同的成交
+	follow := func() []*types.Order {
+		return []*types.Order{
+			{ID: 10, Symbol: "BTCUSDT", Quantity: 16, Side: types.SideBuy, Type: types.TypeMarket},
+			{ID: 11, Symbol: "BTCUSDT", Quantity: 8, Side: types.SideSell, Type: types.TypeMarket},
+		}
+	}
+	for i, order := range follow() {
+		want := ob.Match(order)
+		got := restored.Match(follow()[i])
+		if len(got.Trades) != len(want.Trades) || len(got.Triggered) != len(want.Triggered) {
+			t.Fatalf("Order %d: expected %d trades/%d triggered, got %d/%d",
+				order.ID, len(want.Trades), len(want.Triggered), len(got.Trades), len(got.Triggered))
+		}
+		for j := range want.Trades {
+			if got.Trades[j].TradeID != want.Trades[j].TradeID || got.Trades[j].MakerOrderID != want.Trades[j].MakerOrderID ||
+				got.Trades[j].Quantity != want.Trades[j].Quantity || got.Trades[j].Price != want.Trades[j].Price {
+				t.Errorf("Order %d trade %d differs: got %+v, want %+v", order.ID, j, got.Trades[j], want.Trades[j])
+			}
+		}
+	}
+	if got, want := restored.GetSnapshot(10), ob.GetSnapshot(10); !reflect.DeepEqual(got.Bids, want.Bids) || !reflect.DeepEqual(got.Asks, want.Asks) {
+		t.Errorf("Restored book differs: got %+v/%+v, want %+v/%+v", got.Bids, got.Asks, want.Bids, want.Asks)
+	}
+
+	// 逐笔委托序号从快照继续编号
+	got, want := restored.OrderEvents(), ob.OrderEvents()
+	if len(got) == 0 || got[0].Seq != decoded.FeedSeq+1 || got[len(got)-1].Seq != want[len(want)-1].Seq {
+		t.Errorf("Order feed sequence not continued after restore: got %+v, want last %+v", got, want[len(want)-1])
+	}
+
+	// 未知格式版本及截断的数据
+	data[len(snapshotMagic)+1] = 99
+	if err := (&BookSnapshot{}).UnmarshalBinary(data); !errors.Is(err, ErrSnapshotFormat) {
+		t.Errorf("Expected ErrSnapshotFormat, got %v", err)
+	}
+	data[len(snapshotMagic)+1] = byte(snapshotFormatV6)
+	if err := (&BookSnapshot{}).UnmarshalBinary(data[:len(data)-3]); !errors.Is(err, ErrSnapshotCorrupt) {
+		t.Errorf("Expected ErrSnapshotCorrupt, got %v", err)
+	}
+}
+
+func TestHybridOrderBook_TradingPhase(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.Match(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 100, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit})
+	ob.Match(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 98, Quantity: 10, Side: types.SideBuy, Type: types.TypeLimit})
+
+	// 只挂单阶段: 会吃单的限价单被拒绝，Slide模式调整价格后挂单，市价单被拒绝
+	ob.SetPhase(types.PhasePostOnly)
+	result := ob.Match(&types.Order{ID: 3, Symbol: "BTCUSDT", Price: 100, Quantity: 5, Side: types.SideBuy, Type: types.TypeLimit})
+	if result.RejectReason != types.RejectReasonPostOnly || len(result.Trades) != 0 {
+		t.Errorf("Expected crossing order rejected in post-only phase, got %+v", result)
+	}
+	result = ob.Match(&types.Order{ID: 4, Symbol: "BTCUSDT", Price: 100, Quantity: 5, Side: types.SideBuy, Type: types.TypeLimit, PostOnly: types.PostOnlySlide})
+	if result.RestingQty != 5 || result.Order.Price != 99 {
+		t.Errorf("Expected slid order resting at 99, got %+v", result)
+	}
+	result = ob.Match(&types.Order{ID: 5, Symbol: "BTCUSDT", Quantity: 5, Side: types.SideBuy, Type: types.TypeMarket})
+	if result.RejectReason != types.RejectReasonPostOnlyPhase {
+		t.Errorf("Expected market order rejected in post-only phase, got %+v", result)
+	}
+	if _, err := ob.AmendOrder(2, 100, 0, 0); !errors.Is(err, ErrAmendWouldTake) {
+		t.Errorf("Expected crossing amend rejected, got %v", err)
+	}
+
+	// 只撤单阶段: 拒绝下单及改单，撤单照常执行
+	ob.SetPhase(types.PhaseCancelOnly)
+	result = ob.Match(&types.Order{ID: 6, Symbol: "BTCUSDT", Price: 90, Quantity: 5, Side: types.SideBuy, Type: types.TypeLimit})
+	if result.RejectReason != types.RejectReasonCancelOnly {
+		t.Errorf("Expected order rejected in cancel-only phase, got %+v", result)
+	}
+	if _, err := ob.AmendOrder(2, 0, 5, 0); !errors.Is(err, ErrPhaseRejected) {
+		t.Errorf("Expected amend rejected in cancel-only phase, got %v", err)
+	}
+	if !ob.CancelOrder(4) {
+		t.Error("Expected cancel to succeed in cancel-only phase")
+	}
+
+	// 交易阶段随快照恢复
+	data, err := ob.Snapshot().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &BookSnapshot{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	restored := NewHybridOrderBook("BTCUSDT")
+	if err := restored.Restore(decoded); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Phase() != types.PhaseCancelOnly || restored.OrderCount() != 2 {
+		t.Errorf("Expected cancel-only book with 2 orders, got %v/%d", restored.Phase(), restored.OrderCount())
+	}
+}
+
+func TestHybridOrderBook_Auction(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.SetPhase(types.PhaseAuction)
+
+	// 集合竞价期间交叉的订单只挂单不成交，市价单被拒绝
+	orders := []*types.Order{
+		{ID: 1, Price: 100, Quantity: 10, Side: types.SideSell},
+		{ID: 2, Price: 101, Quantity: 5, Side: types.SideSell},
+		{ID: 3, Price: 103, Quantity: 10, Side: types.SideSell},
+		{ID: 4, Price: 103, Quantity: 8, Side: types.SideBuy},
+		{ID: 5, Price: 101, Quantity: 6, Side: types.SideBuy},
+		{ID: 6, Price: 99, Quantity: 10, Side: types.SideBuy},
+	}
+	for _, order := range orders {
+		order.Symbol = "BTCUSDT"
+		order.Type = types.TypeLimit
+		if result := ob.Match(order); len(result.Trades) != 0 || result.RestingQty != order.Quantity {
+			t.Fatalf("Expected order %d to rest during auction, got %+v", order.ID, result)
+		}
+	}
+	if result := ob.Match(&types.Order{ID: 7, Symbol: "BTCUSDT", Quantity: 5, Side: types.SideBuy, Type: types.TypeMarket}); result.RejectReason != types.RejectReasonAuctionPhase {
+		t.Errorf("Expected market order rejected during auction, got %+v", result)
+	}
+
+	// 100: 可成交10，101: 可成交14，103: 可成交8
+	want := AuctionQuote{Price: 101, Volume: 14, Imbalance: -1}
+	if quote, changed := ob.IndicativeChanged(); !changed || quote != want {
+		t.Errorf("Expected indicative %+v, got %+v (changed=%v)", want, quote, changed)
+	}
+	if _, changed := ob.IndicativeChanged(); changed {
+		t.Error("Expected unchanged indicative quote")
+	}
+
+	result := ob.Uncross()
+	if result.AuctionQuote != want || len(result.Trades) != 3 {
+		t.Fatalf("Expected 3 trades at %+v, got %+v", want, result)
+	}
+	var volume int64
+	for _, trade := range result.Trades {
+		if trade.Price != 101 || trade.TakerSide != 0 {
+			t.Errorf("Unexpected auction trade %+v", trade)
+		}
+		volume += trade.Quantity
+	}
+	if volume != 14 || ob.GetLastPrice() != 101 {
+		t.Errorf("Expected volume 14 at 101, got %d at %d", volume, ob.GetLastPrice())
+	}
+	if bid, _ := ob.GetBestBid(); bid != 99 {
+		t.Errorf("Expected best bid 99 after uncross, got %d", bid)
+	}
+	if ask, qty := ob.GetBestAsk(); ask != 101 || qty != 1 {
+		t.Errorf("Expected 1 left at 101 after uncross, got %d@%d", qty, ask)
+	}
+	if ob.OrderCount() != 3 {
+		t.Errorf("Expected 3 orders left after uncross, got %d", ob.OrderCount())
+	}
+
+	// 成交量与未成交数量相同时取最接近最新成交价的价格，没有最新成交价时取中间价，距离相同取较低价格
+	tie := func(lastPrice int64) int64 {
+		book := NewHybridOrderBook("BTCUSDT")
+		book.lastPrice = lastPrice
+		book.SetPhase(types.PhaseAuction)
+		book.Match(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 100, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit})
+		book.Match(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 102, Quantity: 5, Side: types.SideBuy, Type: types.TypeLimit})
+		return book.Indicative().Price
+	}
+	if price := tie(0); price != 100 {
+		t.Errorf("Expected lower price without reference, got %d", price)
+	}
+	if price := tie(105); price != 102 {
+		t.Errorf("Expected price nearest to reference, got %d", price)
+	}
+}
+
+func TestHybridOrderBook_AuctionSelfTrade(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.SetPhase(types.PhaseAuction)
+
+	// 买单2的客户端时间早于卖单1，但较晚进入订单簿，竞价撮合时视为吃单
+	orders := []*types.Order{
+		{ID: 1, Price: 100, Quantity: 10, Side: types.SideSell, ClientID: "alice", Timestamp: 500},
+		{ID: 2, Price: 100, Quantity: 4, Side: types.SideBuy, ClientID: "alice", Timestamp: 100, STPMode: types.STPDecrementCancel},
+		{ID: 3, Price: 100, Quantity: 5, Side: types.SideBuy, ClientID: "bob"},
+	}
+	for _, order := range orders {
+		order.Symbol = "BTCUSDT"
+		order.Type = types.TypeLimit
+		ob.Match(order)
+	}
+
+	result := ob.UncrossAt(1000)
+	if len(result.SelfTrades) != 1 {
+		t.Fatalf("Expected 1 self-trade event, got %d", len(result.SelfTrades))
+	}
+	event := result.SelfTrades[0]
+	if event.TakerOrderID != 2 || event.MakerOrderID != 1 || event.Mode != types.STPDecrementCancel ||
+		event.TakerCancelledQty != 4 || event.MakerCancelledQty != 4 || event.MakerRemoved || event.Price != 100 || event.Timestamp != 1000 {
+		t.Errorf("Unexpected self-trade event %+v", event)
+	}
+	if !reflect.DeepEqual(result.Cancelled, []uint64{2}) {
+		t.Errorf("Expected order 2 removed, got %v", result.Cancelled)
+	}
+
+	// 卖单1扣减4后与买单3成交5，剩余1
+	if len(result.Trades) != 1 || result.Trades[0].TakerOrderID != 3 || result.Trades[0].MakerOrderID != 1 || result.Trades[0].Quantity != 5 {
+		t.Fatalf("Expected order 3 to fill 5 against order 1, got %+v", result.Trades)
+	}
+	if ask, qty := ob.GetBestAsk(); ask != 100 || qty != 1 {
+		t.Errorf("Expected 1 left at 100 after uncross, got %d@%d", qty, ask)
+	}
+}
+
+func TestHybridOrderBook_MassCancel(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	orders := []*types.Order{
+		{ID: 1, Price: 99, Quantity: 5, Side: types.SideBuy, Type: types.TypeLimit, Account: "A", ClientID: "x"},
+		{ID: 2, Price: 101, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit, Account: "A", ClientID: "x"},
+		{ID: 3, Price: 98, Quantity: 5, Side: types.SideBuy, Type: types.TypeLimit, Account: "B", ClientID: "y"},
+		{ID: 4, StopPrice: 90, Quantity: 5, Side: types.SideSell, Type: types.TypeStopMarket, Account: "A", ClientID: "x"},
+		{ID: 5, Price: 102, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit, Account: "B", ClientID: "x"},
+		{ID: 6, Price: 103, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit, Account: "C", ClientID: "z"},
+	}
+	for _, order := range orders {
+		order.Symbol = "BTCUSDT"
+		ob.Match(order)
+	}
+
+	// 完全成交的挂单移出索引
+	ob.Match(&types.Order{ID: 7, Symbol: "BTCUSDT", Price: 103, Quantity: 5, Side: types.SideBuy, Type: types.TypeLimit, TimeInForce: types.TimeInForceIOC})
+	ob.Match(&types.Order{ID: 8, Symbol: "BTCUSDT", Price: 103, Quantity: 10, Side: types.SideBuy, Type: types.TypeLimit, TimeInForce: types.TimeInForceIOC})
+	if _, ok := ob.accounts["C"]; ok {
+		t.Error("Expected filled order removed from account index")
+	}
+
+	if got := ob.MassCancel(CancelFilter{Account: "A", Side: types.SideBuy}); !reflect.DeepEqual(got, []uint64{1}) {
+		t.Errorf("Expected account A bids [1] cancelled, got %v", got)
+	}
+
+	// 索引及账户随快照恢复
+	data, err := ob.Snapshot().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &BookSnapshot{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	restored := NewHybridOrderBook("BTCUSDT")
+	if err := restored.Restore(decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		filter CancelFilter
+		want   []uint64
+	}{
+		{CancelFilter{Account: "A"}, []uint64{4}},
+		{CancelFilter{ClientID: "x"}, nil},
+		{CancelFilter{Side: types.SideSell}, nil},
+		{CancelFilter{}, []uint64{3}},
+	} {
+		if got := restored.MassCancel(tt.filter); len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+			t.Errorf("MassCancel(%+v): expected %v, got %v", tt.filter, tt.want, got)
+		}
+	}
+	if restored.OrderCount() != 0 || len(restored.accounts) != 0 || len(restored.clients) != 0 {
+		t.Errorf("Expected empty book and indexes, got %d orders, %v, %v", restored.OrderCount(), restored.accounts, restored.clients)
 	}
 }
 
@@ -323,19 +1178,19 @@ func BenchmarkSkipTree_Insert(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		tree.Insert(float64(i), &PriceLevel{Price: float64(i)})
+		tree.Insert(int64(i), &PriceLevel{Price: int64(i)})
 	}
 }
 
 func BenchmarkSkipTree_Get(b *testing.B) {
 	tree := NewSkipTree(16, false)
 	for i := 0; i < 10000; i++ {
-		tree.Insert(float64(i), &PriceLevel{Price: float64(i)})
+		tree.Insert(int64(i), &PriceLevel{Price: int64(i)})
 	}
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		tree.Get(float64(i % 10000))
+		tree.Get(int64(i % 10000))
 	}
 }
 
@@ -347,7 +1202,7 @@ func BenchmarkHybridOrderBook_Match(b *testing.B) {
 		order := &types.Order{
 			ID:       uint64(i),
 			Symbol:   "BTCUSDT",
-			Price:    50000.0 + float64(i),
+			Price:    50000.0 + int64(i),
 			Quantity: 100,
 			Side:     types.SideSell,
 			Type:     types.TypeLimit,
@@ -370,3 +1225,199 @@ func BenchmarkHybridOrderBook_Match(b *testing.B) {
 		ob.Match(buyOrder)
 	}
 }
+
+func TestHybridOrderBook_MatchPolicy(t *testing.T) {
+	// 同价位挂单: 1:10 2:30(账户x) 3:60 4:3，吃单买入20
+	fills := func(policy MatchPolicy, clientID string) map[uint64]int64 {
+		ob := NewHybridOrderBook("BTCUSDT")
+		ob.SetMatchPolicy(policy)
+		for i, qty := range []int64{10, 30, 60, 3} {
+			order := &types.Order{ID: uint64(i + 1), Symbol: "BTCUSDT", Price: 100, Quantity: qty, Side: types.SideSell, Type: types.TypeLimit}
+			if order.ID == 2 {
+				order.ClientID = "x"
+			}
+			ob.addOrderToBook(order, qty)
+		}
+
+		result := ob.Match(&types.Order{ID: 10, Symbol: "BTCUSDT", Price: 100, Quantity: 20, Side: types.SideBuy, Type: types.TypeLimit, ClientID: clientID, STPMode: types.STPCancelOldest})
+		got := make(map[uint64]int64)
+		for _, trade := range result.Trades {
+			got[trade.MakerOrderID] += trade.Quantity
+		}
+		if result.TotalFilledQty() != 20 {
+			t.Errorf("%s: expected filled 20, got %d", policy.Name(), result.TotalFilledQty())
+		}
+		return got
+	}
+	newPolicy := func(name string, minAllocation, lotSize int64) MatchPolicy {
+		policy, err := NewMatchPolicy(name, minAllocation, lotSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return policy
+	}
+
+	cases := []struct {
+		name     string
+		policy   MatchPolicy
+		clientID string
+		want     map[uint64]int64
+	}{
+		{"fifo", newPolicy(MatchFIFO, 0, 1), "", map[uint64]int64{1: 10, 2: 10}},
+		// 按比例向下取整: 1.94/5.82/11.65/0.58，订单1、4低于最小成交数量2，余量4按时间优先给订单1
+		{"prorata min allocation", newPolicy(MatchProRata, 2, 1), "", map[uint64]int64{1: 4, 2: 5, 3: 11}},
+		// 按lot=5取整: 0/5/10/0，余量5按时间优先给订单1
+		{"prorata lot rounding", newPolicy(MatchProRata, 0, 5), "", map[uint64]int64{1: 5, 2: 5, 3: 10}},
+		// 队首订单1先成交10，剩余10按比例分配给2、3、4: 3/6/0，余量1给订单2
+		{"prorata top order", newPolicy(MatchProRataTopOrder, 0, 1), "", map[uint64]int64{1: 10, 2: 4, 3: 6}},
+		// 同一账户的挂单先按撤旧单模式撤销: 1、3、4按73分配 2/16/0，余量2给订单1
+		{"prorata self trade", newPolicy(MatchProRata, 0, 1), "x", map[uint64]int64{1: 4, 3: 16}},
+	}
+	for _, tc := range cases {
+		if got := fills(tc.policy, tc.clientID); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	if _, err := NewMatchPolicy("lifo", 0, 1); !errors.Is(err, ErrInvalidMatchPolicy) {
+		t.Errorf("Expected ErrInvalidMatchPolicy, got %v", err)
+	}
+}
+
+func TestHybridOrderBook_DepthUpdate(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	for _, order := range []*types.Order{
+		{ID: 1, Price: 100, Quantity: 10, Side: types.SideSell, Type: types.TypeLimit},
+		{ID: 2, Price: 101, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit},
+		{ID: 3, Price: 101, Quantity: 3, Side: types.SideSell, Type: types.TypeLimit},
+		{ID: 4, Price: 98, Quantity: 7, Side: types.SideBuy, Type: types.TypeLimit},
+	} {
+		order.Symbol = "BTCUSDT"
+		ob.Match(order)
+	}
+
+	first, ok := ob.DepthUpdate()
+	if !ok || first.FirstUpdateID != 1 {
+		t.Fatalf("first update: %+v", first)
+	}
+	if len(first.Asks) != 2 || first.Asks[0] != (types.PriceLevel{Price: 100, Quantity: 10, Count: 1}) ||
+		first.Asks[1] != (types.PriceLevel{Price: 101, Quantity: 8, Count: 2}) {
+		t.Errorf("unexpected asks: %+v", first.Asks)
+	}
+	if len(first.Bids) != 1 || first.Bids[0] != (types.PriceLevel{Price: 98, Quantity: 7, Count: 1}) {
+		t.Errorf("unexpected bids: %+v", first.Bids)
+	}
+	if _, ok := ob.DepthUpdate(); ok {
+		t.Error("expected no update without changes")
+	}
+
+	// 吃掉100整档并部分成交101，数量为0表示价格层级已移除
+	ob.Match(&types.Order{ID: 5, Symbol: "BTCUSDT", Price: 101, Quantity: 12, Side: types.SideBuy, Type: types.TypeLimit})
+	second, ok := ob.DepthUpdate()
+	if !ok || second.FirstUpdateID != first.LastUpdateID+1 {
+		t.Fatalf("update ids not contiguous: %+v after %+v", second, first)
+	}
+	if len(second.Asks) != 2 || second.Asks[0] != (types.PriceLevel{Price: 100}) ||
+		second.Asks[1] != (types.PriceLevel{Price: 101, Quantity: 6, Count: 2}) {
+		t.Errorf("unexpected asks: %+v", second.Asks)
+	}
+
+	// 快照携带最后一次更新ID
+	snapshot := ob.GetSnapshot(10)
+	if snapshot.UpdateID != second.LastUpdateID {
+		t.Errorf("snapshot update id: got %d, want %d", snapshot.UpdateID, second.LastUpdateID)
+	}
+
+	// 恢复后从快照版本继续编号
+	restored := NewHybridOrderBook("BTCUSDT")
+	if err := restored.Restore(ob.Snapshot()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := restored.DepthUpdate(); ok {
+		t.Error("expected no update right after restore")
+	}
+	restored.CancelOrder(4)
+	third, ok := restored.DepthUpdate()
+	if !ok || third.FirstUpdateID != second.LastUpdateID+1 {
+		t.Fatalf("update ids not contiguous after restore: %+v", third)
+	}
+	if len(third.Bids) != 1 || third.Bids[0] != (types.PriceLevel{Price: 98}) || len(third.Asks) != 0 {
+		t.Errorf("unexpected levels after cancel: %+v", third)
+	}
+}
+
+func TestHybridOrderBook_OrderEvents(t *testing.T) {
+	ob := NewHybridOrderBook("BTCUSDT")
+	ob.Match(&types.Order{ID: 1, Symbol: "BTCUSDT", Price: 100, Quantity: 10, DisplayQty: 4, Side: types.SideSell, Type: types.TypeLimit, ClientID: "alice"})
+	ob.Match(&types.Order{ID: 2, Symbol: "BTCUSDT", Price: 100, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit, ClientID: "bob"})
+	ob.Match(&types.Order{ID: 3, Symbol: "BTCUSDT", Price: 101, Quantity: 5, Side: types.SideSell, Type: types.TypeLimit})
+
+	// 冰山单展示数量成交完后补充峰值重新排队
+	result := ob.Match(&types.Order{ID: 4, Symbol: "BTCUSDT", Price: 100, Quantity: 6, Side: types.SideBuy, Type: types.TypeLimit})
+	// 原地减量保持优先级，改价撤出原位置后重新挂单
+	ob.AmendOrder(2, 0, 1, 0)
+	ob.AmendOrder(3, 102, 0, 0)
+	ob.CancelOrder(1)
+
+	want := []OrderEvent{
+		{Type: OrderEventAdd, OrderID: 1, Side: types.SideSell, Price: 100, Quantity: 4},
+		{Type: OrderEventAdd, OrderID: 2, Side: types.SideSell, Price: 100, Quantity: 5},
+		{Type: OrderEventAdd, OrderID: 3, Side: types.SideSell, Price: 101, Quantity: 5},
+		{Type: OrderEventExecute, OrderID: 1, Side: types.SideSell, Price: 100, ExecQty: 4, TradeID: result.Trades[0].TradeID},
+		{Type: OrderEventAdd, OrderID: 1, Side: types.SideSell, Price: 100, Quantity: 4},
+		{Type: OrderEventExecute, OrderID: 2, Side: types.SideSell, Price: 100, Quantity: 3, ExecQty: 2, TradeID: result.Trades[1].TradeID},
+		{Type: OrderEventModify, OrderID: 2, Side: types.SideSell, Price: 100, Quantity: 1},
+		{Type: OrderEventDelete, OrderID: 3, Side: types.SideSell, Price: 101},
+		{Type: OrderEventAdd, OrderID: 3, Side: types.SideSell, Price: 102, Quantity: 5},
+		{Type: OrderEventDelete, OrderID: 1, Side: types.SideSell, Price: 100},
+	}
+	for i := range want {
+		want[i].Seq = uint64(i + 1)
+	}
+	if got := ob.OrderEvents(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected order events:\ngot  %+v\nwant %+v", got, want)
+	}
+	if events := ob.OrderEvents(); len(events) != 0 {
+		t.Errorf("expected events drained, got %+v", events)
+	}
+}
+
+func TestHybridOrderBook_ProRataSelfTrade(t *testing.T) {
+	// 同账户挂单2位于队列中间，吃单按各自的自成交预防模式处理并上报事件
+	newBook := func() *HybridOrderBook {
+		ob := NewHybridOrderBook("BTCUSDT")
+		ob.SetMatchPolicy(&ProRataPolicy{LotSize: 1})
+		for i, qty := range []int64{10, 30, 60} {
+			order := &types.Order{ID: uint64(i + 1), Symbol: "BTCUSDT", Price: 100, Quantity: qty, Side: types.SideSell, Type: types.TypeLimit}
+			if order.ID == 2 {
+				order.ClientID = "x"
+			}
+			ob.addOrderToBook(order, qty)
+		}
+		return ob
+	}
+	taker := func(mode int8) *types.Order {
+		return &types.Order{ID: 10, Symbol: "BTCUSDT", Price: 100, Quantity: 20, Side: types.SideBuy, Type: types.TypeLimit, ClientID: "x", STPMode: mode}
+	}
+
+	// 撤新单: 吃单不成交，挂单保留
+	ob := newBook()
+	result := ob.Match(taker(types.STPCancelNewest))
+	if len(result.Trades) != 0 || result.CancelledQty != 20 || len(result.SelfTrades) != 1 || result.SelfTrades[0].MakerOrderID != 2 {
+		t.Errorf("cancel newest: unexpected result trades %d cancelled %d self trades %+v", len(result.Trades), result.CancelledQty, result.SelfTrades)
+	}
+	if _, ok := ob.orderMap.Load(uint64(2)); !ok {
+		t.Error("cancel newest: maker 2 should stay in the book")
+	}
+
+	// 撤旧单: 挂单2撤销后1、3按比例分配 2/17，余量1给订单1
+	ob = newBook()
+	result = ob.Match(taker(types.STPCancelOldest))
+	fills := make(map[uint64]int64)
+	for _, trade := range result.Trades {
+		fills[trade.MakerOrderID] += trade.Quantity
+	}
+	if !reflect.DeepEqual(fills, map[uint64]int64{1: 3, 3: 17}) || len(result.SelfTrades) != 1 || !result.SelfTrades[0].MakerRemoved {
+		t.Errorf("cancel oldest: unexpected fills %v self trades %+v", fills, result.SelfTrades)
+	}
+}
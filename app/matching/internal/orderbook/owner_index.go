@@ -0,0 +1,110 @@
+package orderbook
+
+import (
+	"slices"
+
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+)
+
+// CancelFilter 批量撤单条件，为空的条件不做限制
+type CancelFilter struct {
+	Side     int8 // 0表示买卖双边
+	Account  string
+	ClientID string
+}
+
+// matches 订单是否符合批量撤单条件
+func (f CancelFilter) matches(order *types.Order) bool {
+	return (f.Side == 0 || order.Side == f.Side) &&
+		(f.Account == "" || order.Account == f.Account) &&
+		(f.ClientID == "" || order.ClientID == f.ClientID)
+}
+
+// ownerIndex 账户或ClientID -> 该所有者在订单簿及触发簿中的订单
+type ownerIndex map[string]map[uint64]*types.Order
+
+func (idx ownerIndex) add(owner string, order *types.Order) {
+	if owner == "" {
+		return
+	}
+	orders, ok := idx[owner]
+	if !ok {
+		orders = make(map[uint64]*types.Order)
+		idx[owner] = orders
+	}
+	orders[order.ID] = order
+}
+
+func (idx ownerIndex) remove(owner string, orderID uint64) {
+	orders, ok := idx[owner]
+	if !ok {
+		return
+	}
+	delete(orders, orderID)
+	if len(orders) == 0 {
+		delete(idx, owner)
+	}
+}
+
+// indexOrder 订单进入订单簿或触发簿时加入所有者索引(调用方需持有写锁)
+func (h *HybridOrderBook) indexOrder(order *types.Order) {
+	h.accounts.add(order.Account, order)
+	h.clients.add(order.ClientID, order)
+}
+
+// unindexOrder 订单离开订单簿或触发簿时移出所有者索引(调用方需持有写锁)
+func (h *HybridOrderBook) unindexOrder(order *types.Order) {
+	h.accounts.remove(order.Account, order.ID)
+	h.clients.remove(order.ClientID, order.ID)
+}
+
+// MassCancel 撤销符合条件的挂单及未触发的止损单，返回按订单ID升序排列的被撤销订单
+// 指定账户或ClientID时只遍历该所有者的订单，不扫描整个订单簿
+func (h *HybridOrderBook) MassCancel(filter CancelFilter) []uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var orderIDs []uint64
+	collect := func(order *types.Order) {
+		if filter.matches(order) {
+			orderIDs = append(orderIDs, order.ID)
+		}
+	}
+
+	switch {
+	case filter.Account != "":
+		for _, order := range h.accounts[filter.Account] {
+			collect(order)
+		}
+	case filter.ClientID != "":
+		for _, order := range h.clients[filter.ClientID] {
+			collect(order)
+		}
+	default:
+		trees := []*SkipTree{h.buys, h.sells}
+		switch filter.Side {
+		case types.SideBuy:
+			trees = trees[:1]
+		case types.SideSell:
+			trees = trees[1:]
+		}
+		for _, tree := range trees {
+			for _, order := range collectOrders(tree) {
+				collect(order)
+			}
+		}
+		for _, order := range h.triggers.Orders() {
+			collect(order)
+		}
+	}
+
+	// 按订单ID撤销，结果与索引的遍历顺序无关
+	slices.Sort(orderIDs)
+	cancelled := orderIDs[:0]
+	for _, orderID := range orderIDs {
+		if h.cancelOrder(orderID) {
+			cancelled = append(cancelled, orderID)
+		}
+	}
+	return cancelled
+}
@@ -9,9 +9,9 @@ import (
 
 // PriceLevelManager 价格层级管理器
 type PriceLevelManager struct {
-	levels     map[float64]*PriceLevel
+	levels     map[int64]*PriceLevel
 	orderMap   map[uint64]*types.Order // orderID -> Order
-	seqMap     map[float64]*OrderQueue // 同价位订单队列
+	seqMap     map[int64]*OrderQueue   // 同价位订单队列
 	mu         sync.RWMutex
 	totalQty   int64
 	orderCount int32
@@ -29,9 +29,9 @@ type OrderQueue struct {
 // NewPriceLevelManager 创建价格层级管理器
 func NewPriceLevelManager() *PriceLevelManager {
 	return &PriceLevelManager{
-		levels:   make(map[float64]*PriceLevel),
+		levels:   make(map[int64]*PriceLevel),
 		orderMap: make(map[uint64]*types.Order),
-		seqMap:   make(map[float64]*OrderQueue),
+		seqMap:   make(map[int64]*OrderQueue),
 	}
 }
 
@@ -122,7 +122,7 @@ func (plm *PriceLevelManager) RemoveOrder(orderID uint64) bool {
 }
 
 // GetEarliestOrder 获取同价位最早的订单
-func (plm *PriceLevelManager) GetEarliestOrder(price float64) *types.Order {
+func (plm *PriceLevelManager) GetEarliestOrder(price int64) *types.Order {
 	plm.mu.RLock()
 	defer plm.mu.RUnlock()
 
@@ -135,7 +135,7 @@ func (plm *PriceLevelManager) GetEarliestOrder(price float64) *types.Order {
 }
 
 // GetLevel 获取价格层级
-func (plm *PriceLevelManager) GetLevel(price float64) *PriceLevel {
+func (plm *PriceLevelManager) GetLevel(price int64) *PriceLevel {
 	plm.mu.RLock()
 	defer plm.mu.RUnlock()
 
@@ -10,7 +10,7 @@ import (
 
 // SkipNode 跳表节点
 type SkipNode struct {
-	key     float64
+	key     int64
 	value   *PriceLevel
 	forward []*SkipNode
 	level   int
@@ -30,9 +30,10 @@ type SkipTree struct {
 
 // PriceLevel 价格层级,按照价格点位聚合相同价格的订单集合,优化撮合性能
 type PriceLevel struct {
-	Price    float64
-	TotalQty int64
-	Orders   []*types.Order
+	Price      int64
+	TotalQty   int64 // 剩余总数量，含冰山单隐藏数量
+	VisibleQty int64 // 对外展示的数量
+	Orders     []*types.Order
 }
 
 // NewSkipTree 创建跳表价格树
@@ -73,17 +74,14 @@ func (st *SkipTree) randomLevel() int {
 	return level
 }
 
-// compare 比较函数
-func (st *SkipTree) compare(a, b float64) int {
-	const epsilon = 1e-10
-	diff := a - b
-
-	if diff > epsilon {
+// compare 比较函数，价格为整数tick可精确比较
+func (st *SkipTree) compare(a, b int64) int {
+	if a > b {
 		if st.reverse {
 			return -1 // 降序：大的在前
 		}
 		return 1 // 升序：大的在后
-	} else if diff < -epsilon {
+	} else if a < b {
 		if st.reverse {
 			return 1 // 降序：小的在后
 		}
@@ -93,7 +91,7 @@ func (st *SkipTree) compare(a, b float64) int {
 }
 
 // Insert 插入价格层级
-func (st *SkipTree) Insert(price float64, level *PriceLevel) {
+func (st *SkipTree) Insert(price int64, level *PriceLevel) {
 	st.mu.Lock()
 	defer st.mu.Unlock()
 
@@ -142,7 +140,7 @@ func (st *SkipTree) Insert(price float64, level *PriceLevel) {
 }
 
 // Remove 移除价格层级
-func (st *SkipTree) Remove(price float64) {
+func (st *SkipTree) Remove(price int64) {
 	st.mu.Lock()
 	defer st.mu.Unlock()
 
@@ -179,7 +177,7 @@ func (st *SkipTree) Remove(price float64) {
 }
 
 // Get 获取价格层级
-func (st *SkipTree) Get(price float64) *PriceLevel {
+func (st *SkipTree) Get(price int64) *PriceLevel {
 	st.mu.RLock()
 	defer st.mu.RUnlock()
 
@@ -297,7 +295,7 @@ func (st *SkipTree) GetTopLevels(limit int) []*PriceLevel {
 }
 
 // Range 范围遍历
-func (st *SkipTree) Range(fn func(price float64, level *PriceLevel) bool) {
+func (st *SkipTree) Range(fn func(price int64, level *PriceLevel) bool) {
 	st.mu.RLock()
 	defer st.mu.RUnlock()
 
@@ -311,7 +309,7 @@ func (st *SkipTree) Range(fn func(price float64, level *PriceLevel) bool) {
 }
 
 // RangeFrom 从指定价格开始遍历
-func (st *SkipTree) RangeFrom(startPrice float64, fn func(price float64, level *PriceLevel) bool) {
+func (st *SkipTree) RangeFrom(startPrice int64, fn func(price int64, level *PriceLevel) bool) {
 	st.mu.RLock()
 	defer st.mu.RUnlock()
 
@@ -333,7 +331,7 @@ func (st *SkipTree) RangeFrom(startPrice float64, fn func(price float64, level *
 }
 
 // RangeBetween 在价格范围内遍历
-func (st *SkipTree) RangeBetween(minPrice, maxPrice float64, fn func(price float64, level *PriceLevel) bool) {
+func (st *SkipTree) RangeBetween(minPrice, maxPrice int64, fn func(price int64, level *PriceLevel) bool) {
 	st.mu.RLock()
 	defer st.mu.RUnlock()
 
@@ -384,7 +382,7 @@ func (st *SkipTree) GetAllLevels() []*PriceLevel {
 }
 
 // GetPriceRange 获取价格范围
-func (st *SkipTree) GetPriceRange() (min, max float64) {
+func (st *SkipTree) GetPriceRange() (min, max int64) {
 	st.mu.RLock()
 	defer st.mu.RUnlock()
 
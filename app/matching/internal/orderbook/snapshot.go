@@ -0,0 +1,412 @@
+package orderbook
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+)
+
+// 快照二进制格式: magic(4B) + 格式版本(2B) + 头部 + 买盘/卖盘/止损单列表，整数使用varint编码
+// 格式变化时增加版本号，解码按版本分支，旧版本快照仍可读取
+const (
+	snapshotMagic    = "OBSN"
+	snapshotFormatV1 = uint16(1)
+	snapshotFormatV2 = uint16(2) // 头部增加交易阶段
+	snapshotFormatV3 = uint16(3) // 订单增加所属账户
+	snapshotFormatV4 = uint16(4) // 头部增加逐笔委托序号
+	snapshotFormatV5 = uint16(5) // 头部增加成交序号
+	snapshotFormatV6 = uint16(6) // 订单增加排队序号
+)
+
+var (
+	ErrSnapshotFormat   = errors.New("unsupported order book snapshot format")
+	ErrSnapshotCorrupt  = errors.New("order book snapshot corrupted")
+	ErrSnapshotMismatch = errors.New("order book snapshot does not match order book")
+)
+
+// BookSnapshot 订单簿完整快照，包含每个挂单的队列位置、剩余数量及所属账户/ClientID
+type BookSnapshot struct {
+	Symbol    string
+	Seq       uint64             // 快照已包含的最后一条命令序号
+	Version   uint64             // 订单簿版本
+	LastPrice int64              // 最新成交价，止损单触发依据
+	Timestamp int64              // 快照时间(纳秒)
+	Phase     types.TradingPhase // 交易阶段，第1版快照为0
+	FeedSeq   uint64             // 最后一个逐笔委托事件的序号，第4版之前的快照为0
+	TradeSeq  uint64             // 最后一笔成交的ID，第5版之前的快照为0
+	Bids      []*types.Order     // 买盘挂单，按价格优先、时间优先排列，第6版起保留排队序号(Order.Arrival)
+	Asks      []*types.Order     // 卖盘挂单，按价格优先、时间优先排列
+	Stops     []*types.Order     // 未触发的止损单，按触发顺序排列
+}
+
+// Snapshot 拍摄订单簿完整快照，挂单为副本，不受之后撮合影响
+func (h *HybridOrderBook) Snapshot() *BookSnapshot {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return &BookSnapshot{
+		Symbol:    h.symbol,
+		Version:   h.version,
+		LastPrice: h.lastPrice,
+		Phase:     h.phase,
+		FeedSeq:   h.feedSeq,
+		TradeSeq:  h.tradeSeq,
+		Bids:      copyOrders(collectOrders(h.buys)),
+		Asks:      copyOrders(collectOrders(h.sells)),
+		Stops:     copyOrders(h.triggers.Orders()),
+	}
+}
+
+// Restore 用快照替换订单簿状态，恢复后撮合行为与拍摄快照时一致
+// 快照中的订单对象直接挂入订单簿，调用方之后不能再使用
+func (h *HybridOrderBook) Restore(snapshot *BookSnapshot) error {
+	if snapshot.Symbol != h.symbol {
+		return fmt.Errorf("%w: symbol %s, snapshot %s", ErrSnapshotMismatch, h.symbol, snapshot.Symbol)
+	}
+	for _, order := range snapshot.Bids {
+		if order.Side != types.SideBuy {
+			return fmt.Errorf("%w: order %d is not a bid", ErrSnapshotCorrupt, order.ID)
+		}
+	}
+	for _, order := range snapshot.Asks {
+		if order.Side != types.SideSell {
+			return fmt.Errorf("%w: order %d is not an ask", ErrSnapshotCorrupt, order.ID)
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.buys = NewSkipTree(16, true)
+	h.sells = NewSkipTree(16, false)
+	h.orderMap = &sync.Map{}
+	h.expiries = &expiryQueue{}
+	h.triggers = NewTriggerBook()
+	h.accounts = make(ownerIndex)
+	h.clients = make(ownerIndex)
+	h.arrivalSeq = 0
+
+	for _, orders := range [][]*types.Order{snapshot.Bids, snapshot.Asks} {
+		for _, order := range orders {
+			order.Symbol = h.symbol
+			h.restoreOrder(order)
+		}
+	}
+	for _, order := range snapshot.Stops {
+		order.Symbol = h.symbol
+		h.addOrderToTriggers(order)
+	}
+
+	h.version = snapshot.Version
+	h.lastPrice = snapshot.LastPrice
+	h.dirty = nil
+	h.depthID = snapshot.Version
+	h.orderEvents = nil
+	h.feedSeq = snapshot.FeedSeq
+	h.tradeSeq = snapshot.TradeSeq
+	if snapshot.Phase != 0 {
+		h.phase = snapshot.Phase
+	}
+	return nil
+}
+
+// restoreOrder 按快照中的剩余数量及排队序号把挂单追加到价格层级队尾(调用方需持有写锁)
+// 第6版之前的快照没有排队序号，按买盘、卖盘的恢复顺序重新分配
+func (h *HybridOrderBook) restoreOrder(order *types.Order) {
+	tree := h.treeOf(order)
+	if order.Arrival == 0 {
+		h.enqueue(order)
+	} else {
+		h.arrivalSeq = max(h.arrivalSeq, order.Arrival)
+	}
+
+	level := tree.Get(order.Price)
+	if level == nil {
+		level = &PriceLevel{
+			Price:  order.Price,
+			Orders: make([]*types.Order, 0),
+		}
+		tree.Insert(order.Price, level)
+	}
+
+	level.Orders = append(level.Orders, order)
+	level.TotalQty += order.VisibleQty + order.HiddenQty
+	level.VisibleQty += order.VisibleQty
+	h.orderMap.Store(order.ID, order)
+	h.indexOrder(order)
+
+	if order.GetTimeInForce() == types.TimeInForceGTD {
+		h.expiries.push(order)
+	}
+}
+
+// collectOrders 按价格优先、时间优先列出价格树中的全部挂单
+func collectOrders(tree *SkipTree) []*types.Order {
+	var orders []*types.Order
+	tree.Range(func(_ int64, level *PriceLevel) bool {
+		orders = append(orders, level.Orders...)
+		return true
+	})
+	return orders
+}
+
+// copyOrders 拷贝订单，快照与订单簿不共享订单对象
+func copyOrders(orders []*types.Order) []*types.Order {
+	copied := make([]*types.Order, len(orders))
+	for i, order := range orders {
+		o := *order
+		copied[i] = &o
+	}
+	return copied
+}
+
+// Orders 快照中的全部订单: 买盘、卖盘、未触发的止损单
+func (s *BookSnapshot) Orders() []*types.Order {
+	orders := make([]*types.Order, 0, len(s.Bids)+len(s.Asks)+len(s.Stops))
+	orders = append(orders, s.Bids...)
+	orders = append(orders, s.Asks...)
+	return append(orders, s.Stops...)
+}
+
+// MarshalBinary 按当前格式版本编码快照
+func (s *BookSnapshot) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 64+len(s.Bids)*64+len(s.Asks)*64+len(s.Stops)*64)
+	buf = append(buf, snapshotMagic...)
+	buf = binary.BigEndian.AppendUint16(buf, snapshotFormatV6)
+
+	buf = appendString(buf, s.Symbol)
+	buf = binary.AppendUvarint(buf, s.Seq)
+	buf = binary.AppendUvarint(buf, s.Version)
+	buf = binary.AppendVarint(buf, s.LastPrice)
+	buf = binary.AppendVarint(buf, s.Timestamp)
+	buf = append(buf, byte(s.Phase))
+	buf = binary.AppendUvarint(buf, s.FeedSeq)
+	buf = binary.AppendUvarint(buf, s.TradeSeq)
+
+	for _, orders := range [][]*types.Order{s.Bids, s.Asks, s.Stops} {
+		buf = binary.AppendUvarint(buf, uint64(len(orders)))
+		for _, order := range orders {
+			buf = appendOrderV1(buf, order)
+			buf = appendString(buf, order.Account)
+			buf = binary.AppendUvarint(buf, order.Arrival)
+		}
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary 解码快照，支持的格式版本见snapshotFormatV1~V6
+func (s *BookSnapshot) UnmarshalBinary(data []byte) error {
+	if len(data) < len(snapshotMagic)+2 || string(data[:len(snapshotMagic)]) != snapshotMagic {
+		return ErrSnapshotFormat
+	}
+
+	format := binary.BigEndian.Uint16(data[len(snapshotMagic):])
+	r := &snapshotReader{r: bytes.NewReader(data[len(snapshotMagic)+2:])}
+
+	switch format {
+	case snapshotFormatV1:
+		s.decodeV1(r)
+	case snapshotFormatV2:
+		s.decodeV2(r)
+	case snapshotFormatV3:
+		s.decodeV3(r)
+	case snapshotFormatV4:
+		s.decodeV4(r)
+	case snapshotFormatV5:
+		s.decodeV5(r)
+	case snapshotFormatV6:
+		s.decodeV6(r)
+	default:
+		return fmt.Errorf("%w: version %d", ErrSnapshotFormat, format)
+	}
+
+	if r.err != nil {
+		return fmt.Errorf("%w: %v", ErrSnapshotCorrupt, r.err)
+	}
+	if r.r.Len() != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrSnapshotCorrupt, r.r.Len())
+	}
+	return nil
+}
+
+// decodeV1 解码第1版快照
+func (s *BookSnapshot) decodeV1(r *snapshotReader) {
+	s.decodeHeader(r)
+	s.decodeOrders(r, false, false)
+}
+
+// decodeV2 解码第2版快照: 头部之后为交易阶段
+func (s *BookSnapshot) decodeV2(r *snapshotReader) {
+	s.decodeHeader(r)
+	s.Phase = types.TradingPhase(r.int8())
+	s.decodeOrders(r, false, false)
+}
+
+// decodeV3 解码第3版快照: 每个订单之后为所属账户
+func (s *BookSnapshot) decodeV3(r *snapshotReader) {
+	s.decodeHeader(r)
+	s.Phase = types.TradingPhase(r.int8())
+	s.decodeOrders(r, true, false)
+}
+
+// decodeV4 解码第4版快照: 交易阶段之后为逐笔委托序号
+func (s *BookSnapshot) decodeV4(r *snapshotReader) {
+	s.decodeHeader(r)
+	s.Phase = types.TradingPhase(r.int8())
+	s.FeedSeq = r.uvarint()
+	s.decodeOrders(r, true, false)
+}
+
+// decodeV5 解码第5版快照: 逐笔委托序号之后为成交序号
+func (s *BookSnapshot) decodeV5(r *snapshotReader) {
+	s.decodeHeader(r)
+	s.Phase = types.TradingPhase(r.int8())
+	s.FeedSeq = r.uvarint()
+	s.TradeSeq = r.uvarint()
+	s.decodeOrders(r, true, false)
+}
+
+// decodeV6 解码第6版快照: 每个订单的所属账户之后为排队序号
+func (s *BookSnapshot) decodeV6(r *snapshotReader) {
+	s.decodeHeader(r)
+	s.Phase = types.TradingPhase(r.int8())
+	s.FeedSeq = r.uvarint()
+	s.TradeSeq = r.uvarint()
+	s.decodeOrders(r, true, true)
+}
+
+func (s *BookSnapshot) decodeHeader(r *snapshotReader) {
+	s.Symbol = r.string()
+	s.Seq = r.uvarint()
+	s.Version = r.uvarint()
+	s.LastPrice = r.varint()
+	s.Timestamp = r.varint()
+}
+
+// decodeOrders 解码买盘、卖盘及止损单列表，第3版起订单之后附带所属账户，第6版起再附带排队序号
+func (s *BookSnapshot) decodeOrders(r *snapshotReader, withAccount, withArrival bool) {
+	for _, orders := range []*[]*types.Order{&s.Bids, &s.Asks, &s.Stops} {
+		count := r.uvarint()
+		if r.err != nil || count > uint64(r.r.Len()) {
+			r.fail(fmt.Errorf("invalid order count %d", count))
+			return
+		}
+
+		*orders = make([]*types.Order, 0, count)
+		for i := uint64(0); i < count && r.err == nil; i++ {
+			order := &types.Order{Symbol: s.Symbol}
+			r.orderV1(order)
+			if withAccount {
+				order.Account = r.string()
+			}
+			if withArrival {
+				order.Arrival = r.uvarint()
+			}
+			*orders = append(*orders, order)
+		}
+	}
+}
+
+// appendOrderV1 按第1版格式编码订单，Symbol取快照的交易对不重复编码
+func appendOrderV1(buf []byte, o *types.Order) []byte {
+	buf = binary.AppendUvarint(buf, o.ID)
+	buf = binary.AppendVarint(buf, o.Price)
+	buf = binary.AppendVarint(buf, o.Quantity)
+	buf = append(buf, byte(o.Side), byte(o.Type), byte(o.TimeInForce), byte(o.PostOnly), byte(o.STPMode))
+	buf = binary.AppendVarint(buf, o.Timestamp)
+	buf = appendString(buf, o.ClientID)
+	buf = binary.AppendUvarint(buf, uint64(o.Version))
+	buf = binary.AppendVarint(buf, o.ExpireTime)
+	buf = binary.AppendVarint(buf, o.StopPrice)
+	buf = binary.AppendVarint(buf, o.DisplayQty)
+	buf = binary.AppendVarint(buf, o.QuoteQty)
+	buf = binary.AppendVarint(buf, o.VisibleQty)
+	buf = binary.AppendVarint(buf, o.HiddenQty)
+	return buf
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+// snapshotReader 快照解码器，出错后后续读取均返回零值
+type snapshotReader struct {
+	r   *bytes.Reader
+	err error
+}
+
+func (r *snapshotReader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *snapshotReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, err := binary.ReadUvarint(r.r)
+	r.fail(err)
+	return v
+}
+
+func (r *snapshotReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, err := binary.ReadVarint(r.r)
+	r.fail(err)
+	return v
+}
+
+func (r *snapshotReader) int8() int8 {
+	if r.err != nil {
+		return 0
+	}
+	b, err := r.r.ReadByte()
+	r.fail(err)
+	return int8(b)
+}
+
+func (r *snapshotReader) string() string {
+	n := r.uvarint()
+	if r.err != nil {
+		return ""
+	}
+	if n > uint64(r.r.Len()) {
+		r.fail(io.ErrUnexpectedEOF)
+		return ""
+	}
+	b := make([]byte, n)
+	_, err := io.ReadFull(r.r, b)
+	r.fail(err)
+	return string(b)
+}
+
+// orderV1 按第1版格式解码订单
+func (r *snapshotReader) orderV1(o *types.Order) {
+	o.ID = r.uvarint()
+	o.Price = r.varint()
+	o.Quantity = r.varint()
+	o.Side = r.int8()
+	o.Type = r.int8()
+	o.TimeInForce = r.int8()
+	o.PostOnly = r.int8()
+	o.STPMode = r.int8()
+	o.Timestamp = r.varint()
+	o.ClientID = r.string()
+	o.Version = uint32(r.uvarint())
+	o.ExpireTime = r.varint()
+	o.StopPrice = r.varint()
+	o.DisplayQty = r.varint()
+	o.QuoteQty = r.varint()
+	o.VisibleQty = r.varint()
+	o.HiddenQty = r.varint()
+}
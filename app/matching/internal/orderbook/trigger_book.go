@@ -0,0 +1,133 @@
+package orderbook
+
+import (
+	"github.com/tsfdsong/tradeengin/app/pkg/types"
+)
+
+// TriggerBook 止损单触发簿，未触发的止损单按触发价聚合存放
+// 买入止损按触发价升序(最先被上涨价格触发的在前)，卖出止损按触发价降序，同价位按提交顺序
+type TriggerBook struct {
+	buyStops  *SkipTree               // 买入止损 - 触发价升序
+	sellStops *SkipTree               // 卖出止损 - 触发价降序
+	orders    map[uint64]*types.Order // orderID -> 止损单
+}
+
+// NewTriggerBook 创建止损单触发簿
+func NewTriggerBook() *TriggerBook {
+	return &TriggerBook{
+		buyStops:  NewSkipTree(16, false),
+		sellStops: NewSkipTree(16, true),
+		orders:    make(map[uint64]*types.Order),
+	}
+}
+
+// Add 添加未触发的止损单
+func (tb *TriggerBook) Add(order *types.Order) {
+	tree := tb.treeOf(order)
+
+	level := tree.Get(order.StopPrice)
+	if level == nil {
+		level = &PriceLevel{
+			Price:    order.StopPrice,
+			TotalQty: 0,
+			Orders:   make([]*types.Order, 0),
+		}
+		tree.Insert(order.StopPrice, level)
+	}
+
+	level.Orders = append(level.Orders, order)
+	level.TotalQty += order.Quantity
+	tb.orders[order.ID] = order
+}
+
+// Get 查找未触发的止损单
+func (tb *TriggerBook) Get(orderID uint64) *types.Order {
+	return tb.orders[orderID]
+}
+
+// Remove 移除未触发的止损单
+func (tb *TriggerBook) Remove(orderID uint64) bool {
+	order, exists := tb.orders[orderID]
+	if !exists {
+		return false
+	}
+
+	tree := tb.treeOf(order)
+	level := tree.Get(order.StopPrice)
+	if level != nil {
+		for i, o := range level.Orders {
+			if o.ID == orderID {
+				level.Orders = append(level.Orders[:i], level.Orders[i+1:]...)
+				level.TotalQty -= o.Quantity
+				break
+			}
+		}
+		if len(level.Orders) == 0 {
+			tree.Remove(order.StopPrice)
+		}
+	}
+
+	delete(tb.orders, orderID)
+	return true
+}
+
+// PopTriggered 按确定顺序取出一个被最新成交价触发的止损单，没有则返回nil
+// 买入止损在 lastPrice >= 触发价 时触发，卖出止损在 lastPrice <= 触发价 时触发；买卖同时触发时买入止损优先
+func (tb *TriggerBook) PopTriggered(lastPrice int64) *types.Order {
+	if lastPrice <= 0 {
+		return nil
+	}
+
+	if level := tb.buyStops.MinPriceNode(); level != nil && lastPrice >= level.Price {
+		return tb.popFront(level)
+	}
+
+	if level := tb.sellStops.MaxPriceNode(); level != nil && lastPrice <= level.Price {
+		return tb.popFront(level)
+	}
+
+	return nil
+}
+
+// Orders 按触发顺序列出未触发的止损单: 买入止损按触发价升序，卖出止损按触发价降序，同价位按提交顺序
+func (tb *TriggerBook) Orders() []*types.Order {
+	orders := make([]*types.Order, 0, len(tb.orders))
+	for _, tree := range []*SkipTree{tb.buyStops, tb.sellStops} {
+		tree.Range(func(_ int64, level *PriceLevel) bool {
+			orders = append(orders, level.Orders...)
+			return true
+		})
+	}
+	return orders
+}
+
+// Len 未触发的止损单数量
+func (tb *TriggerBook) Len() int {
+	return len(tb.orders)
+}
+
+// IsTriggered 止损单在给定成交价下是否满足触发条件
+func IsTriggered(order *types.Order, lastPrice int64) bool {
+	if lastPrice <= 0 {
+		return false
+	}
+	if order.Side == types.SideBuy {
+		return lastPrice >= order.StopPrice
+	}
+	return lastPrice <= order.StopPrice
+}
+
+// popFront 取出价格层级中最早提交的止损单
+func (tb *TriggerBook) popFront(level *PriceLevel) *types.Order {
+	order := level.Orders[0]
+	tb.Remove(order.ID)
+	return order
+}
+
+// treeOf 获取止损单所在的触发价树
+func (tb *TriggerBook) treeOf(order *types.Order) *SkipTree {
+	if order.Side == types.SideBuy {
+		return tb.buyStops
+	}
+	return tb.sellStops
+}
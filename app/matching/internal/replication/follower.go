@@ -0,0 +1,113 @@
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	engine "github.com/tsfdsong/tradeengin/app/matching/internal/engin"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/journal"
+	"github.com/tsfdsong/tradeengin/app/matching/match"
+	"github.com/tsfdsong/tradeengin/app/matching/matchservice"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	minBackoff = 100 * time.Millisecond
+	maxBackoff = 5 * time.Second
+)
+
+// Follower 从primary复制命令日志并应用到本地引擎，断线后从本地最后序号重连追赶
+type Follower struct {
+	id     string
+	client matchservice.MatchService
+	engine *engine.MatchingEngine
+}
+
+// NewFollower 创建follower复制服务
+func NewFollower(id string, client matchservice.MatchService, engine *engine.MatchingEngine) *Follower {
+	return &Follower{
+		id:     id,
+		client: client,
+		engine: engine,
+	}
+}
+
+// Run 持续复制直到ctx结束或本节点被提升为primary
+func (f *Follower) Run(ctx context.Context) {
+	logx.Infof("Follower %s replication started", f.id)
+
+	backoff := minBackoff
+	for {
+		received, err := f.replicate(ctx)
+		f.engine.MarkPrimaryDisconnected()
+
+		if errors.Is(err, engine.ErrNotFollower) {
+			logx.Infof("Follower %s promoted to primary, replication stopped", f.id)
+			return
+		}
+		if ctx.Err() != nil {
+			logx.Infof("Follower %s replication stopped", f.id)
+			return
+		}
+
+		if received {
+			backoff = minBackoff
+		}
+		logx.Errorf("Replication from primary interrupted: %v, retry in %v", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// replicate 建立一次复制连接，每应用一条命令向primary确认一次，返回是否收到过primary的消息
+func (f *Follower) replicate(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := f.client.Replicate(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	fromSeq := f.engine.LastSeq()
+	if err := stream.Send(&match.ReplicationAck{FollowerId: f.id, FromSeq: fromSeq, AppliedSeq: fromSeq}); err != nil {
+		return false, err
+	}
+
+	received := false
+	for {
+		entry, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+		if !received {
+			logx.Infof("Follower %s connected to primary, replicating after seq %d", f.id, fromSeq)
+			received = true
+		}
+
+		// 心跳只携带primary的最后序号
+		if entry.Seq == 0 {
+			f.engine.ObservePrimary(entry.PrimarySeq)
+			continue
+		}
+
+		cmd := &journal.Command{}
+		if err := cmd.Unmarshal(entry.Command); err != nil {
+			return received, fmt.Errorf("decode replicated command %d: %w", entry.Seq, err)
+		}
+		if err := f.engine.ApplyReplicated(cmd, entry.PrimarySeq); err != nil {
+			return received, err
+		}
+
+		if err := stream.Send(&match.ReplicationAck{FollowerId: f.id, AppliedSeq: cmd.Seq}); err != nil {
+			return received, err
+		}
+	}
+}
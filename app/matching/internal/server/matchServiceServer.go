@@ -32,3 +32,58 @@ func (s *MatchServiceServer) GetOrderBook(ctx context.Context, in *match.OrderBo
 	l := logic.NewGetOrderBookLogic(ctx, s.svcCtx)
 	return l.GetOrderBook(in)
 }
+
+func (s *MatchServiceServer) CancelOrder(ctx context.Context, in *match.CancelOrderRequest) (*match.CancelOrderResponse, error) {
+	l := logic.NewCancelOrderLogic(ctx, s.svcCtx)
+	return l.CancelOrder(in)
+}
+
+func (s *MatchServiceServer) QueryOrder(ctx context.Context, in *match.QueryOrderRequest) (*match.QueryOrderResponse, error) {
+	l := logic.NewQueryOrderLogic(ctx, s.svcCtx)
+	return l.QueryOrder(in)
+}
+
+func (s *MatchServiceServer) AmendOrder(ctx context.Context, in *match.AmendOrderRequest) (*match.AmendOrderResponse, error) {
+	l := logic.NewAmendOrderLogic(ctx, s.svcCtx)
+	return l.AmendOrder(in)
+}
+
+func (s *MatchServiceServer) MassCancel(ctx context.Context, in *match.MassCancelRequest) (*match.MassCancelResponse, error) {
+	l := logic.NewMassCancelLogic(ctx, s.svcCtx)
+	return l.MassCancel(in)
+}
+
+func (s *MatchServiceServer) GetExchangeInfo(ctx context.Context, in *match.ExchangeInfoRequest) (*match.ExchangeInfoResponse, error) {
+	l := logic.NewGetExchangeInfoLogic(ctx, s.svcCtx)
+	return l.GetExchangeInfo(in)
+}
+
+func (s *MatchServiceServer) Replicate(stream match.MatchService_ReplicateServer) error {
+	l := logic.NewReplicateLogic(stream.Context(), s.svcCtx)
+	return l.Replicate(stream)
+}
+
+func (s *MatchServiceServer) GetReplicationStatus(ctx context.Context, in *match.ReplicationStatusRequest) (*match.ReplicationStatusResponse, error) {
+	l := logic.NewGetReplicationStatusLogic(ctx, s.svcCtx)
+	return l.GetReplicationStatus(in)
+}
+
+func (s *MatchServiceServer) Promote(ctx context.Context, in *match.PromoteRequest) (*match.ReplicationStatusResponse, error) {
+	l := logic.NewPromoteLogic(ctx, s.svcCtx)
+	return l.Promote(in)
+}
+
+func (s *MatchServiceServer) ManageSymbol(ctx context.Context, in *match.ManageSymbolRequest) (*match.ManageSymbolResponse, error) {
+	l := logic.NewManageSymbolLogic(ctx, s.svcCtx)
+	return l.ManageSymbol(in)
+}
+
+func (s *MatchServiceServer) SubscribeMarketData(in *match.MarketDataRequest, stream match.MatchService_SubscribeMarketDataServer) error {
+	l := logic.NewSubscribeMarketDataLogic(stream.Context(), s.svcCtx)
+	return l.SubscribeMarketData(in, stream)
+}
+
+func (s *MatchServiceServer) SubscribeOrderFeed(in *match.OrderFeedRequest, stream match.MatchService_SubscribeOrderFeedServer) error {
+	l := logic.NewSubscribeOrderFeedLogic(stream.Context(), s.svcCtx)
+	return l.SubscribeOrderFeed(in, stream)
+}
@@ -1,10 +1,16 @@
 package svc
 
 import (
+	"context"
+
 	"github.com/tsfdsong/tradeengin/app/matching/internal/config"
 	engine "github.com/tsfdsong/tradeengin/app/matching/internal/engin"
+	"github.com/tsfdsong/tradeengin/app/matching/internal/replication"
+	"github.com/tsfdsong/tradeengin/app/matching/matchservice"
 	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/threading"
+	"github.com/zeromicro/go-zero/zrpc"
 )
 
 type ServiceContext struct {
@@ -12,6 +18,8 @@ type ServiceContext struct {
 	Engine      *engine.MatchingEngine
 	RedisClient *redis.Redis           // 新增: Redis客户端
 	Persister   *engine.RedisPersister // 新增: Redis持久化服务
+
+	stopFollower context.CancelFunc // follower: 停止复制
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -32,23 +40,57 @@ func NewServiceContext(c config.Config) *ServiceContext {
 	if c.Matching.PersistEnabled && svcCtx.RedisClient != nil {
 		svcCtx.Persister = engine.NewRedisPersister(
 			svcCtx.RedisClient,
-			svcCtx.Engine.GetOrderBooks(),
+			svcCtx.Engine.GetOrderBooks,
 			c.Matching.PersistInterval,
 			true,
 		)
 		logx.Info("Redis persister initialized")
 	}
 
+	// 初始化快照存储，启动时从最新的有效快照恢复订单簿
+	store, err := engine.NewSnapshotStore(c.Matching.SnapshotStore, svcCtx.RedisClient)
+	logx.Must(err)
+	if store != nil {
+		svcCtx.Engine.SetSnapshotStore(store)
+		logx.Infof("Snapshot store initialized, type: %s", c.Matching.SnapshotStore.Type)
+	}
+
 	// 启动引擎
 	if err := svcCtx.Engine.Start(); err != nil {
 		panic(err)
 	}
 
+	// follower从primary复制命令日志
+	if c.Matching.Replication.Role == engine.RoleFollower {
+		svcCtx.startFollower(c)
+	}
+
 	return svcCtx
 }
 
+// startFollower 连接primary并持续复制，默认以ListenOn作为follower标识
+func (s *ServiceContext) startFollower(c config.Config) {
+	followerID := c.Matching.Replication.FollowerID
+	if followerID == "" {
+		followerID = c.ListenOn
+	}
+
+	client := matchservice.NewMatchService(zrpc.MustNewClient(c.Matching.Replication.Primary))
+	follower := replication.NewFollower(followerID, client, s.Engine)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopFollower = cancel
+	threading.GoSafe(func() {
+		follower.Run(ctx)
+	})
+	logx.Infof("Follower %s replicating from primary", followerID)
+}
+
 // Close 关闭服务上下文
 func (s *ServiceContext) Close() {
+	if s.stopFollower != nil {
+		s.stopFollower()
+	}
 	if s.Engine != nil {
 		s.Engine.Stop()
 		logx.Info("Matching engine stopped")
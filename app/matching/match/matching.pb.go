@@ -21,16 +21,26 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 价格、数量、金额均为十进制字符串，精度由交易对配置决定
 type Order struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      int64                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	Side          int32                  `protobuf:"varint,5,opt,name=side,proto3" json:"side,omitempty"`
 	Type          int32                  `protobuf:"varint,6,opt,name=type,proto3" json:"type,omitempty"`
 	Timestamp     int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	ClientId      string                 `protobuf:"bytes,8,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	TimeInForce   int32                  `protobuf:"varint,9,opt,name=time_in_force,json=timeInForce,proto3" json:"time_in_force,omitempty"` // 1:GTC, 2:IOC, 3:FOK, 4:GTD, 0按GTC处理
+	ExpireTime    int64                  `protobuf:"varint,10,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`     // GTD订单过期时间(纳秒)
+	PostOnly      int32                  `protobuf:"varint,11,opt,name=post_only,json=postOnly,proto3" json:"post_only,omitempty"`           // 只做Maker: 0:否, 1:会吃单时拒绝, 2:会吃单时调整价格
+	StopPrice     string                 `protobuf:"bytes,12,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`         // 止损触发价，止损市价/止损限价单必填
+	DisplayQty    string                 `protobuf:"bytes,13,opt,name=display_qty,json=displayQty,proto3" json:"display_qty,omitempty"`      // 冰山单每次展示的数量，0表示普通订单
+	StpMode       int32                  `protobuf:"varint,14,opt,name=stp_mode,json=stpMode,proto3" json:"stp_mode,omitempty"`              // 自成交预防: 0:交易对默认, 1:撤新, 2:撤旧, 3:双撤, 4:减量撤销
+	QuoteQty      string                 `protobuf:"bytes,15,opt,name=quote_qty,json=quoteQty,proto3" json:"quote_qty,omitempty"`            // 按计价货币金额下单的市价单金额，此时quantity为0，不支持FOK
+	Async         bool                   `protobuf:"varint,16,opt,name=async,proto3" json:"async,omitempty"`                                 // 异步下单: 入队即返回，不等待撮合结果
+	Account       string                 `protobuf:"bytes,17,opt,name=account,proto3" json:"account,omitempty"`                              // 下单账户，可按账户批量撤单
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -79,18 +89,18 @@ func (x *Order) GetSymbol() string {
 	return ""
 }
 
-func (x *Order) GetPrice() float64 {
+func (x *Order) GetPrice() string {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return ""
 }
 
-func (x *Order) GetQuantity() int64 {
+func (x *Order) GetQuantity() string {
 	if x != nil {
 		return x.Quantity
 	}
-	return 0
+	return ""
 }
 
 func (x *Order) GetSide() int32 {
@@ -121,15 +131,79 @@ func (x *Order) GetClientId() string {
 	return ""
 }
 
+func (x *Order) GetTimeInForce() int32 {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return 0
+}
+
+func (x *Order) GetExpireTime() int64 {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return 0
+}
+
+func (x *Order) GetPostOnly() int32 {
+	if x != nil {
+		return x.PostOnly
+	}
+	return 0
+}
+
+func (x *Order) GetStopPrice() string {
+	if x != nil {
+		return x.StopPrice
+	}
+	return ""
+}
+
+func (x *Order) GetDisplayQty() string {
+	if x != nil {
+		return x.DisplayQty
+	}
+	return ""
+}
+
+func (x *Order) GetStpMode() int32 {
+	if x != nil {
+		return x.StpMode
+	}
+	return 0
+}
+
+func (x *Order) GetQuoteQty() string {
+	if x != nil {
+		return x.QuoteQty
+	}
+	return ""
+}
+
+func (x *Order) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *Order) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
 type Trade struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TradeId       uint64                 `protobuf:"varint,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	TradeId       uint64                 `protobuf:"varint,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"` // 交易对内按执行顺序递增，与symbol一起唯一标识成交
 	TakerOrderId  uint64                 `protobuf:"varint,2,opt,name=taker_order_id,json=takerOrderId,proto3" json:"taker_order_id,omitempty"`
 	MakerOrderId  uint64                 `protobuf:"varint,3,opt,name=maker_order_id,json=makerOrderId,proto3" json:"maker_order_id,omitempty"`
 	Symbol        string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      int64                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Price         string                 `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                  // 命令时间(纳秒)
+	TakerSide     int32                  `protobuf:"varint,8,opt,name=taker_side,json=takerSide,proto3" json:"taker_side,omitempty"` // 新增: Taker方向
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -192,18 +266,18 @@ func (x *Trade) GetSymbol() string {
 	return ""
 }
 
-func (x *Trade) GetPrice() float64 {
+func (x *Trade) GetPrice() string {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return ""
 }
 
-func (x *Trade) GetQuantity() int64 {
+func (x *Trade) GetQuantity() string {
 	if x != nil {
 		return x.Quantity
 	}
-	return 0
+	return ""
 }
 
 func (x *Trade) GetTimestamp() int64 {
@@ -213,18 +287,146 @@ func (x *Trade) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *Trade) GetTakerSide() int32 {
+	if x != nil {
+		return x.TakerSide
+	}
+	return 0
+}
+
+// 自成交预防事件，同一账户的买卖单相遇时代替成交返回
+type SelfTradeEvent struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TakerOrderId      uint64                 `protobuf:"varint,1,opt,name=taker_order_id,json=takerOrderId,proto3" json:"taker_order_id,omitempty"`
+	MakerOrderId      uint64                 `protobuf:"varint,2,opt,name=maker_order_id,json=makerOrderId,proto3" json:"maker_order_id,omitempty"`
+	Symbol            string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	ClientId          string                 `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Price             string                 `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Mode              int32                  `protobuf:"varint,6,opt,name=mode,proto3" json:"mode,omitempty"`
+	TakerCancelledQty string                 `protobuf:"bytes,7,opt,name=taker_cancelled_qty,json=takerCancelledQty,proto3" json:"taker_cancelled_qty,omitempty"`
+	MakerCancelledQty string                 `protobuf:"bytes,8,opt,name=maker_cancelled_qty,json=makerCancelledQty,proto3" json:"maker_cancelled_qty,omitempty"`
+	MakerRemoved      bool                   `protobuf:"varint,9,opt,name=maker_removed,json=makerRemoved,proto3" json:"maker_removed,omitempty"`
+	Timestamp         int64                  `protobuf:"varint,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SelfTradeEvent) Reset() {
+	*x = SelfTradeEvent{}
+	mi := &file_matching_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelfTradeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelfTradeEvent) ProtoMessage() {}
+
+func (x *SelfTradeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelfTradeEvent.ProtoReflect.Descriptor instead.
+func (*SelfTradeEvent) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SelfTradeEvent) GetTakerOrderId() uint64 {
+	if x != nil {
+		return x.TakerOrderId
+	}
+	return 0
+}
+
+func (x *SelfTradeEvent) GetMakerOrderId() uint64 {
+	if x != nil {
+		return x.MakerOrderId
+	}
+	return 0
+}
+
+func (x *SelfTradeEvent) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SelfTradeEvent) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *SelfTradeEvent) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *SelfTradeEvent) GetMode() int32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *SelfTradeEvent) GetTakerCancelledQty() string {
+	if x != nil {
+		return x.TakerCancelledQty
+	}
+	return ""
+}
+
+func (x *SelfTradeEvent) GetMakerCancelledQty() string {
+	if x != nil {
+		return x.MakerCancelledQty
+	}
+	return ""
+}
+
+func (x *SelfTradeEvent) GetMakerRemoved() bool {
+	if x != nil {
+		return x.MakerRemoved
+	}
+	return false
+}
+
+func (x *SelfTradeEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 type MatchResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Trades        []*Trade               `protobuf:"bytes,1,rep,name=trades,proto3" json:"trades,omitempty"`
-	Order         *Order                 `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Trades         []*Trade               `protobuf:"bytes,1,rep,name=trades,proto3" json:"trades,omitempty"`
+	Order          *Order                 `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	Timestamp      int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	SelfTrades     []*SelfTradeEvent      `protobuf:"bytes,4,rep,name=self_trades,json=selfTrades,proto3" json:"self_trades,omitempty"`
+	RemainingQuote string                 `protobuf:"bytes,5,opt,name=remaining_quote,json=remainingQuote,proto3" json:"remaining_quote,omitempty"` // 按金额下单的市价单未用完(已撤销)的金额
+	Status         int32                  `protobuf:"varint,6,opt,name=status,proto3" json:"status,omitempty"`                                      // 撮合后订单状态: 0:挂单中, 1:部分成交, 2:完全成交, 3:已取消
+	FilledQuantity string                 `protobuf:"bytes,7,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"` // 累计成交数量
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *MatchResult) Reset() {
 	*x = MatchResult{}
-	mi := &file_matching_proto_msgTypes[2]
+	mi := &file_matching_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -236,7 +438,7 @@ func (x *MatchResult) String() string {
 func (*MatchResult) ProtoMessage() {}
 
 func (x *MatchResult) ProtoReflect() protoreflect.Message {
-	mi := &file_matching_proto_msgTypes[2]
+	mi := &file_matching_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,7 +451,7 @@ func (x *MatchResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchResult.ProtoReflect.Descriptor instead.
 func (*MatchResult) Descriptor() ([]byte, []int) {
-	return file_matching_proto_rawDescGZIP(), []int{2}
+	return file_matching_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *MatchResult) GetTrades() []*Trade {
@@ -273,6 +475,34 @@ func (x *MatchResult) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *MatchResult) GetSelfTrades() []*SelfTradeEvent {
+	if x != nil {
+		return x.SelfTrades
+	}
+	return nil
+}
+
+func (x *MatchResult) GetRemainingQuote() string {
+	if x != nil {
+		return x.RemainingQuote
+	}
+	return ""
+}
+
+func (x *MatchResult) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *MatchResult) GetFilledQuantity() string {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return ""
+}
+
 type OrderBookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
@@ -283,7 +513,7 @@ type OrderBookRequest struct {
 
 func (x *OrderBookRequest) Reset() {
 	*x = OrderBookRequest{}
-	mi := &file_matching_proto_msgTypes[3]
+	mi := &file_matching_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -295,7 +525,7 @@ func (x *OrderBookRequest) String() string {
 func (*OrderBookRequest) ProtoMessage() {}
 
 func (x *OrderBookRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_matching_proto_msgTypes[3]
+	mi := &file_matching_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -308,7 +538,7 @@ func (x *OrderBookRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderBookRequest.ProtoReflect.Descriptor instead.
 func (*OrderBookRequest) Descriptor() ([]byte, []int) {
-	return file_matching_proto_rawDescGZIP(), []int{3}
+	return file_matching_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *OrderBookRequest) GetSymbol() string {
@@ -331,13 +561,14 @@ type OrderBookSnapshot struct {
 	Bids          []*PriceLevel          `protobuf:"bytes,2,rep,name=bids,proto3" json:"bids,omitempty"`
 	Asks          []*PriceLevel          `protobuf:"bytes,3,rep,name=asks,proto3" json:"asks,omitempty"`
 	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	UpdateId      uint64                 `protobuf:"varint,5,opt,name=update_id,json=updateId,proto3" json:"update_id,omitempty"` // 快照对应的深度更新ID，增量深度从update_id+1开始应用
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OrderBookSnapshot) Reset() {
 	*x = OrderBookSnapshot{}
-	mi := &file_matching_proto_msgTypes[4]
+	mi := &file_matching_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -349,7 +580,7 @@ func (x *OrderBookSnapshot) String() string {
 func (*OrderBookSnapshot) ProtoMessage() {}
 
 func (x *OrderBookSnapshot) ProtoReflect() protoreflect.Message {
-	mi := &file_matching_proto_msgTypes[4]
+	mi := &file_matching_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -362,7 +593,7 @@ func (x *OrderBookSnapshot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderBookSnapshot.ProtoReflect.Descriptor instead.
 func (*OrderBookSnapshot) Descriptor() ([]byte, []int) {
-	return file_matching_proto_rawDescGZIP(), []int{4}
+	return file_matching_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *OrderBookSnapshot) GetSymbol() string {
@@ -393,10 +624,17 @@ func (x *OrderBookSnapshot) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *OrderBookSnapshot) GetUpdateId() uint64 {
+	if x != nil {
+		return x.UpdateId
+	}
+	return 0
+}
+
 type PriceLevel struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Price         float64                `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      int64                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         string                 `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	OrderCount    int32                  `protobuf:"varint,3,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -404,7 +642,7 @@ type PriceLevel struct {
 
 func (x *PriceLevel) Reset() {
 	*x = PriceLevel{}
-	mi := &file_matching_proto_msgTypes[5]
+	mi := &file_matching_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -416,7 +654,7 @@ func (x *PriceLevel) String() string {
 func (*PriceLevel) ProtoMessage() {}
 
 func (x *PriceLevel) ProtoReflect() protoreflect.Message {
-	mi := &file_matching_proto_msgTypes[5]
+	mi := &file_matching_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -429,21 +667,21 @@ func (x *PriceLevel) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PriceLevel.ProtoReflect.Descriptor instead.
 func (*PriceLevel) Descriptor() ([]byte, []int) {
-	return file_matching_proto_rawDescGZIP(), []int{5}
+	return file_matching_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *PriceLevel) GetPrice() float64 {
+func (x *PriceLevel) GetPrice() string {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return ""
 }
 
-func (x *PriceLevel) GetQuantity() int64 {
+func (x *PriceLevel) GetQuantity() string {
 	if x != nil {
 		return x.Quantity
 	}
-	return 0
+	return ""
 }
 
 func (x *PriceLevel) GetOrderCount() int32 {
@@ -453,49 +691,2172 @@ func (x *PriceLevel) GetOrderCount() int32 {
 	return 0
 }
 
-var File_matching_proto protoreflect.FileDescriptor
+// 新增: 取消订单请求
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_matching_proto_rawDesc = "" +
-	"\n" +
-	"\x0ematching.proto\x12\x05match\"\xc4\x01\n" +
-	"\x05Order\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
-	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x03R\bquantity\x12\x12\n" +
-	"\x04side\x18\x05 \x01(\x05R\x04side\x12\x12\n" +
-	"\x04type\x18\x06 \x01(\x05R\x04type\x12\x1c\n" +
-	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\x12\x1b\n" +
-	"\tclient_id\x18\b \x01(\tR\bclientId\"\xd6\x01\n" +
-	"\x05Trade\x12\x19\n" +
-	"\btrade_id\x18\x01 \x01(\x04R\atradeId\x12$\n" +
-	"\x0etaker_order_id\x18\x02 \x01(\x04R\ftakerOrderId\x12$\n" +
-	"\x0emaker_order_id\x18\x03 \x01(\x04R\fmakerOrderId\x12\x16\n" +
-	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x06 \x01(\x03R\bquantity\x12\x1c\n" +
-	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\"u\n" +
-	"\vMatchResult\x12$\n" +
-	"\x06trades\x18\x01 \x03(\v2\f.match.TradeR\x06trades\x12\"\n" +
-	"\x05order\x18\x02 \x01(\v2\f.match.OrderR\x05order\x12\x1c\n" +
-	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\"@\n" +
-	"\x10OrderBookRequest\x12\x16\n" +
-	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x14\n" +
-	"\x05depth\x18\x02 \x01(\x05R\x05depth\"\x97\x01\n" +
-	"\x11OrderBookSnapshot\x12\x16\n" +
-	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12%\n" +
-	"\x04bids\x18\x02 \x03(\v2\x11.match.PriceLevelR\x04bids\x12%\n" +
-	"\x04asks\x18\x03 \x03(\v2\x11.match.PriceLevelR\x04asks\x12\x1c\n" +
-	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"_\n" +
-	"\n" +
-	"PriceLevel\x12\x14\n" +
-	"\x05price\x18\x01 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x03R\bquantity\x12\x1f\n" +
-	"\vorder_count\x18\x03 \x01(\x05R\n" +
-	"orderCount2\x83\x01\n" +
-	"\fMatchService\x120\n" +
-	"\fProcessOrder\x12\f.match.Order\x1a\x12.match.MatchResult\x12A\n" +
-	"\fGetOrderBook\x12\x17.match.OrderBookRequest\x1a\x18.match.OrderBookSnapshotB\tZ\a./matchb\x06proto3"
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_matching_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelOrderRequest) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *CancelOrderRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// 新增: 取消订单响应
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	OrderId       uint64                 `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Code          uint32                 `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"` // 失败时的错误码，如交易对暂停
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_matching_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelOrderResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelOrderResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CancelOrderResponse) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *CancelOrderResponse) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+// 新增: 查询订单请求
+type QueryOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryOrderRequest) Reset() {
+	*x = QueryOrderRequest{}
+	mi := &file_matching_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryOrderRequest) ProtoMessage() {}
+
+func (x *QueryOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryOrderRequest.ProtoReflect.Descriptor instead.
+func (*QueryOrderRequest) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *QueryOrderRequest) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *QueryOrderRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// 新增: 查询订单响应
+type QueryOrderResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Order          *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Status         int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"` // 0:挂单中, 1:部分成交, 2:完全成交, 3:已取消
+	FilledQuantity string                 `protobuf:"bytes,3,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
+	Version        uint32                 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"` // 订单版本，改单时需携带
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *QueryOrderResponse) Reset() {
+	*x = QueryOrderResponse{}
+	mi := &file_matching_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryOrderResponse) ProtoMessage() {}
+
+func (x *QueryOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryOrderResponse.ProtoReflect.Descriptor instead.
+func (*QueryOrderResponse) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *QueryOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *QueryOrderResponse) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *QueryOrderResponse) GetFilledQuantity() string {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return ""
+}
+
+func (x *QueryOrderResponse) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// 改单请求，price/quantity为空或0表示不修改，未触发的止损单不支持改单
+type AmendOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Price         string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,4,opt,name=quantity,proto3" json:"quantity,omitempty"` // 修改后的剩余数量
+	Version       uint32                 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`  // 客户端持有的订单版本，与当前版本不一致时拒绝
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AmendOrderRequest) Reset() {
+	*x = AmendOrderRequest{}
+	mi := &file_matching_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendOrderRequest) ProtoMessage() {}
+
+func (x *AmendOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendOrderRequest.ProtoReflect.Descriptor instead.
+func (*AmendOrderRequest) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AmendOrderRequest) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *AmendOrderRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// 改单响应
+type AmendOrderResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	OrderId         uint64                 `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Version         uint32                 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"` // 改单后的订单版本
+	RestingQuantity string                 `protobuf:"bytes,5,opt,name=resting_quantity,json=restingQuantity,proto3" json:"resting_quantity,omitempty"`
+	Trades          []*Trade               `protobuf:"bytes,6,rep,name=trades,proto3" json:"trades,omitempty"` // 改价后立即成交产生的成交
+	Code            uint32                 `protobuf:"varint,7,opt,name=code,proto3" json:"code,omitempty"`    // 失败时的错误码，如只撤单阶段拒绝改单
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AmendOrderResponse) Reset() {
+	*x = AmendOrderResponse{}
+	mi := &file_matching_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendOrderResponse) ProtoMessage() {}
+
+func (x *AmendOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendOrderResponse.ProtoReflect.Descriptor instead.
+func (*AmendOrderResponse) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AmendOrderResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AmendOrderResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AmendOrderResponse) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *AmendOrderResponse) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AmendOrderResponse) GetRestingQuantity() string {
+	if x != nil {
+		return x.RestingQuantity
+	}
+	return ""
+}
+
+func (x *AmendOrderResponse) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+func (x *AmendOrderResponse) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+// 批量撤单请求，为空的条件不做限制；symbol为空时在全部交易对上撤单，此时account与client_id至少指定一个
+type MassCancelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          int32                  `protobuf:"varint,2,opt,name=side,proto3" json:"side,omitempty"` // 0:双边, 1:买, 2:卖
+	Account       string                 `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`
+	ClientId      string                 `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MassCancelRequest) Reset() {
+	*x = MassCancelRequest{}
+	mi := &file_matching_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MassCancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MassCancelRequest) ProtoMessage() {}
+
+func (x *MassCancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MassCancelRequest.ProtoReflect.Descriptor instead.
+func (*MassCancelRequest) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MassCancelRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *MassCancelRequest) GetSide() int32 {
+	if x != nil {
+		return x.Side
+	}
+	return 0
+}
+
+func (x *MassCancelRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *MassCancelRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+// 批量撤单响应
+type MassCancelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderIds      []uint64               `protobuf:"varint,1,rep,packed,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`        // 被撤销的挂单及未触发的止损单
+	FailedSymbols []string               `protobuf:"bytes,2,rep,name=failed_symbols,json=failedSymbols,proto3" json:"failed_symbols,omitempty"` // symbol为空时无法撤单的交易对，如正在下架
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MassCancelResponse) Reset() {
+	*x = MassCancelResponse{}
+	mi := &file_matching_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MassCancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MassCancelResponse) ProtoMessage() {}
+
+func (x *MassCancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MassCancelResponse.ProtoReflect.Descriptor instead.
+func (*MassCancelResponse) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MassCancelResponse) GetOrderIds() []uint64 {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *MassCancelResponse) GetFailedSymbols() []string {
+	if x != nil {
+		return x.FailedSymbols
+	}
+	return nil
+}
+
+// 交易所信息请求
+type ExchangeInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"` // 为空返回全部交易对
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeInfoRequest) Reset() {
+	*x = ExchangeInfoRequest{}
+	mi := &file_matching_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeInfoRequest) ProtoMessage() {}
+
+func (x *ExchangeInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeInfoRequest.ProtoReflect.Descriptor instead.
+func (*ExchangeInfoRequest) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExchangeInfoRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// 交易对规格，数量与价格均为十进制字符串
+type InstrumentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                                     // TRADING / POST_ONLY / CANCEL_ONLY / HALTED / AUCTION / DELISTING
+	PriceScale    int32                  `protobuf:"varint,3,opt,name=price_scale,json=priceScale,proto3" json:"price_scale,omitempty"`          // 价格小数位数
+	QuantityScale int32                  `protobuf:"varint,4,opt,name=quantity_scale,json=quantityScale,proto3" json:"quantity_scale,omitempty"` // 数量小数位数
+	TickSize      string                 `protobuf:"bytes,5,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`                 // 最小价格变动
+	LotSize       string                 `protobuf:"bytes,6,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`                    // 最小数量变动
+	MinQty        string                 `protobuf:"bytes,7,opt,name=min_qty,json=minQty,proto3" json:"min_qty,omitempty"`                       // 单笔最小数量
+	MaxQty        string                 `protobuf:"bytes,8,opt,name=max_qty,json=maxQty,proto3" json:"max_qty,omitempty"`                       // 单笔最大数量，"0"表示不限制
+	MinNotional   string                 `protobuf:"bytes,9,opt,name=min_notional,json=minNotional,proto3" json:"min_notional,omitempty"`        // 单笔最小金额
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstrumentInfo) Reset() {
+	*x = InstrumentInfo{}
+	mi := &file_matching_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstrumentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstrumentInfo) ProtoMessage() {}
+
+func (x *InstrumentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstrumentInfo.ProtoReflect.Descriptor instead.
+func (*InstrumentInfo) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *InstrumentInfo) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *InstrumentInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *InstrumentInfo) GetPriceScale() int32 {
+	if x != nil {
+		return x.PriceScale
+	}
+	return 0
+}
+
+func (x *InstrumentInfo) GetQuantityScale() int32 {
+	if x != nil {
+		return x.QuantityScale
+	}
+	return 0
+}
+
+func (x *InstrumentInfo) GetTickSize() string {
+	if x != nil {
+		return x.TickSize
+	}
+	return ""
+}
+
+func (x *InstrumentInfo) GetLotSize() string {
+	if x != nil {
+		return x.LotSize
+	}
+	return ""
+}
+
+func (x *InstrumentInfo) GetMinQty() string {
+	if x != nil {
+		return x.MinQty
+	}
+	return ""
+}
+
+func (x *InstrumentInfo) GetMaxQty() string {
+	if x != nil {
+		return x.MaxQty
+	}
+	return ""
+}
+
+func (x *InstrumentInfo) GetMinNotional() string {
+	if x != nil {
+		return x.MinNotional
+	}
+	return ""
+}
+
+// 交易所信息响应
+type ExchangeInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instruments   []*InstrumentInfo      `protobuf:"bytes,1,rep,name=instruments,proto3" json:"instruments,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeInfoResponse) Reset() {
+	*x = ExchangeInfoResponse{}
+	mi := &file_matching_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeInfoResponse) ProtoMessage() {}
+
+func (x *ExchangeInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeInfoResponse.ProtoReflect.Descriptor instead.
+func (*ExchangeInfoResponse) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ExchangeInfoResponse) GetInstruments() []*InstrumentInfo {
+	if x != nil {
+		return x.Instruments
+	}
+	return nil
+}
+
+func (x *ExchangeInfoResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// follower发给primary的消息，首条消息携带起始序号，之后每写入一条命令确认一次
+type ReplicationAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FollowerId    string                 `protobuf:"bytes,1,opt,name=follower_id,json=followerId,proto3" json:"follower_id,omitempty"`
+	FromSeq       uint64                 `protobuf:"varint,2,opt,name=from_seq,json=fromSeq,proto3" json:"from_seq,omitempty"`          // 首条消息: 从该序号之后开始复制
+	AppliedSeq    uint64                 `protobuf:"varint,3,opt,name=applied_seq,json=appliedSeq,proto3" json:"applied_seq,omitempty"` // 已写入follower的最后一条命令序号
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicationAck) Reset() {
+	*x = ReplicationAck{}
+	mi := &file_matching_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicationAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationAck) ProtoMessage() {}
+
+func (x *ReplicationAck) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationAck.ProtoReflect.Descriptor instead.
+func (*ReplicationAck) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReplicationAck) GetFollowerId() string {
+	if x != nil {
+		return x.FollowerId
+	}
+	return ""
+}
+
+func (x *ReplicationAck) GetFromSeq() uint64 {
+	if x != nil {
+		return x.FromSeq
+	}
+	return 0
+}
+
+func (x *ReplicationAck) GetAppliedSeq() uint64 {
+	if x != nil {
+		return x.AppliedSeq
+	}
+	return 0
+}
+
+// primary发给follower的命令
+type ReplicationEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           uint64                 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Command       []byte                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`                          // 命令日志编码
+	PrimarySeq    uint64                 `protobuf:"varint,3,opt,name=primary_seq,json=primarySeq,proto3" json:"primary_seq,omitempty"` // 发送时primary最后一条命令的序号
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicationEntry) Reset() {
+	*x = ReplicationEntry{}
+	mi := &file_matching_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicationEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationEntry) ProtoMessage() {}
+
+func (x *ReplicationEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationEntry.ProtoReflect.Descriptor instead.
+func (*ReplicationEntry) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReplicationEntry) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ReplicationEntry) GetCommand() []byte {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *ReplicationEntry) GetPrimarySeq() uint64 {
+	if x != nil {
+		return x.PrimarySeq
+	}
+	return 0
+}
+
+// 复制状态请求
+type ReplicationStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicationStatusRequest) Reset() {
+	*x = ReplicationStatusRequest{}
+	mi := &file_matching_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicationStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationStatusRequest) ProtoMessage() {}
+
+func (x *ReplicationStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationStatusRequest.ProtoReflect.Descriptor instead.
+func (*ReplicationStatusRequest) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{20}
+}
+
+// primary端看到的follower状态
+type FollowerStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FollowerId    string                 `protobuf:"bytes,1,opt,name=follower_id,json=followerId,proto3" json:"follower_id,omitempty"`
+	AckedSeq      uint64                 `protobuf:"varint,2,opt,name=acked_seq,json=ackedSeq,proto3" json:"acked_seq,omitempty"`          // follower已确认的最后序号
+	Lag           uint64                 `protobuf:"varint,3,opt,name=lag,proto3" json:"lag,omitempty"`                                    // 落后primary的命令数
+	ConnectedAt   int64                  `protobuf:"varint,4,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"` // 连接时间(纳秒)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowerStatus) Reset() {
+	*x = FollowerStatus{}
+	mi := &file_matching_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowerStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowerStatus) ProtoMessage() {}
+
+func (x *FollowerStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowerStatus.ProtoReflect.Descriptor instead.
+func (*FollowerStatus) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *FollowerStatus) GetFollowerId() string {
+	if x != nil {
+		return x.FollowerId
+	}
+	return ""
+}
+
+func (x *FollowerStatus) GetAckedSeq() uint64 {
+	if x != nil {
+		return x.AckedSeq
+	}
+	return 0
+}
+
+func (x *FollowerStatus) GetLag() uint64 {
+	if x != nil {
+		return x.Lag
+	}
+	return 0
+}
+
+func (x *FollowerStatus) GetConnectedAt() int64 {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return 0
+}
+
+// 复制状态响应
+type ReplicationStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`                                // primary / follower
+	LastSeq       uint64                 `protobuf:"varint,2,opt,name=last_seq,json=lastSeq,proto3" json:"last_seq,omitempty"`          // 本节点最后一条命令序号
+	Followers     []*FollowerStatus      `protobuf:"bytes,3,rep,name=followers,proto3" json:"followers,omitempty"`                      // primary: 已连接的follower
+	Connected     bool                   `protobuf:"varint,4,opt,name=connected,proto3" json:"connected,omitempty"`                     // follower: 是否已连接primary
+	PrimarySeq    uint64                 `protobuf:"varint,5,opt,name=primary_seq,json=primarySeq,proto3" json:"primary_seq,omitempty"` // follower: 已知的primary最后序号
+	Lag           uint64                 `protobuf:"varint,6,opt,name=lag,proto3" json:"lag,omitempty"`                                 // follower: 落后primary的命令数
+	LagMs         int64                  `protobuf:"varint,7,opt,name=lag_ms,json=lagMs,proto3" json:"lag_ms,omitempty"`                // follower: 最近一条命令从primary接收到本地应用的延迟(毫秒)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplicationStatusResponse) Reset() {
+	*x = ReplicationStatusResponse{}
+	mi := &file_matching_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplicationStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicationStatusResponse) ProtoMessage() {}
+
+func (x *ReplicationStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicationStatusResponse.ProtoReflect.Descriptor instead.
+func (*ReplicationStatusResponse) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReplicationStatusResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ReplicationStatusResponse) GetLastSeq() uint64 {
+	if x != nil {
+		return x.LastSeq
+	}
+	return 0
+}
+
+func (x *ReplicationStatusResponse) GetFollowers() []*FollowerStatus {
+	if x != nil {
+		return x.Followers
+	}
+	return nil
+}
+
+func (x *ReplicationStatusResponse) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *ReplicationStatusResponse) GetPrimarySeq() uint64 {
+	if x != nil {
+		return x.PrimarySeq
+	}
+	return 0
+}
+
+func (x *ReplicationStatusResponse) GetLag() uint64 {
+	if x != nil {
+		return x.Lag
+	}
+	return 0
+}
+
+func (x *ReplicationStatusResponse) GetLagMs() int64 {
+	if x != nil {
+		return x.LagMs
+	}
+	return 0
+}
+
+// 将follower提升为primary
+type PromoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteRequest) Reset() {
+	*x = PromoteRequest{}
+	mi := &file_matching_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteRequest) ProtoMessage() {}
+
+func (x *PromoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteRequest.ProtoReflect.Descriptor instead.
+func (*PromoteRequest) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{23}
+}
+
+// 交易对管理请求
+type ManageSymbolRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        int32                  `protobuf:"varint,1,opt,name=action,proto3" json:"action,omitempty"` // 0:查询全部, 1:上架, 2:暂停交易, 3:恢复交易, 4:下架, 5:切换交易阶段, 6:查询交易阶段切换记录
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Instrument    *InstrumentInfo        `protobuf:"bytes,3,opt,name=instrument,proto3" json:"instrument,omitempty"` // 上架交易对的规格，status为空时按TRADING上架
+	Phase         string                 `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`           // 切换后的交易阶段: TRADING / POST_ONLY / CANCEL_ONLY / HALTED / AUCTION，退出AUCTION时执行集合竞价撮合
+	Operator      string                 `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"`     // 操作人，随切换命令记录用于审计
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`         // 切换原因
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManageSymbolRequest) Reset() {
+	*x = ManageSymbolRequest{}
+	mi := &file_matching_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManageSymbolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManageSymbolRequest) ProtoMessage() {}
+
+func (x *ManageSymbolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManageSymbolRequest.ProtoReflect.Descriptor instead.
+func (*ManageSymbolRequest) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ManageSymbolRequest) GetAction() int32 {
+	if x != nil {
+		return x.Action
+	}
+	return 0
+}
+
+func (x *ManageSymbolRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ManageSymbolRequest) GetInstrument() *InstrumentInfo {
+	if x != nil {
+		return x.Instrument
+	}
+	return nil
+}
+
+func (x *ManageSymbolRequest) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *ManageSymbolRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *ManageSymbolRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 交易阶段切换记录
+type PhaseChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Seq           uint64                 `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`             // 切换命令序号
+	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // 切换时间(纳秒)
+	Operator      string                 `protobuf:"bytes,6,opt,name=operator,proto3" json:"operator,omitempty"`    // 操作人，行情推送中为空
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhaseChange) Reset() {
+	*x = PhaseChange{}
+	mi := &file_matching_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhaseChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseChange) ProtoMessage() {}
+
+func (x *PhaseChange) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseChange.ProtoReflect.Descriptor instead.
+func (*PhaseChange) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PhaseChange) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *PhaseChange) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *PhaseChange) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *PhaseChange) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *PhaseChange) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *PhaseChange) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *PhaseChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 交易对运行状态
+type SymbolInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instrument    *InstrumentInfo        `protobuf:"bytes,1,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	Worker        int32                  `protobuf:"varint,2,opt,name=worker,proto3" json:"worker,omitempty"`                           // 所属worker编号
+	QueueSize     uint64                 `protobuf:"varint,3,opt,name=queue_size,json=queueSize,proto3" json:"queue_size,omitempty"`    // 待执行的命令数
+	OrderCount    int32                  `protobuf:"varint,4,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"` // 挂单及未触发的止损单数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SymbolInfo) Reset() {
+	*x = SymbolInfo{}
+	mi := &file_matching_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SymbolInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SymbolInfo) ProtoMessage() {}
+
+func (x *SymbolInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SymbolInfo.ProtoReflect.Descriptor instead.
+func (*SymbolInfo) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SymbolInfo) GetInstrument() *InstrumentInfo {
+	if x != nil {
+		return x.Instrument
+	}
+	return nil
+}
+
+func (x *SymbolInfo) GetWorker() int32 {
+	if x != nil {
+		return x.Worker
+	}
+	return 0
+}
+
+func (x *SymbolInfo) GetQueueSize() uint64 {
+	if x != nil {
+		return x.QueueSize
+	}
+	return 0
+}
+
+func (x *SymbolInfo) GetOrderCount() int32 {
+	if x != nil {
+		return x.OrderCount
+	}
+	return 0
+}
+
+// 交易对管理响应
+type ManageSymbolResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbols         []*SymbolInfo          `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"`                                         // 查询返回全部交易对，其它操作返回操作后的交易对，下架后为空
+	CancelledOrders int32                  `protobuf:"varint,2,opt,name=cancelled_orders,json=cancelledOrders,proto3" json:"cancelled_orders,omitempty"` // 下架撤销的挂单数
+	FinalSeq        uint64                 `protobuf:"varint,3,opt,name=final_seq,json=finalSeq,proto3" json:"final_seq,omitempty"`                      // 下架最终快照包含的最后一条命令序号
+	Archived        bool                   `protobuf:"varint,4,opt,name=archived,proto3" json:"archived,omitempty"`                                      // 下架最终快照是否已归档
+	PhaseChanges    []*PhaseChange         `protobuf:"bytes,5,rep,name=phase_changes,json=phaseChanges,proto3" json:"phase_changes,omitempty"`           // 交易阶段切换记录，按执行顺序排列
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ManageSymbolResponse) Reset() {
+	*x = ManageSymbolResponse{}
+	mi := &file_matching_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManageSymbolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManageSymbolResponse) ProtoMessage() {}
+
+func (x *ManageSymbolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManageSymbolResponse.ProtoReflect.Descriptor instead.
+func (*ManageSymbolResponse) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ManageSymbolResponse) GetSymbols() []*SymbolInfo {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+func (x *ManageSymbolResponse) GetCancelledOrders() int32 {
+	if x != nil {
+		return x.CancelledOrders
+	}
+	return 0
+}
+
+func (x *ManageSymbolResponse) GetFinalSeq() uint64 {
+	if x != nil {
+		return x.FinalSeq
+	}
+	return 0
+}
+
+func (x *ManageSymbolResponse) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *ManageSymbolResponse) GetPhaseChanges() []*PhaseChange {
+	if x != nil {
+		return x.PhaseChanges
+	}
+	return nil
+}
+
+// 行情订阅请求
+type MarketDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbols       []string               `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"` // 为空时订阅全部交易对
+	Depth         bool                   `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`    // 同时订阅增量深度
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketDataRequest) Reset() {
+	*x = MarketDataRequest{}
+	mi := &file_matching_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketDataRequest) ProtoMessage() {}
+
+func (x *MarketDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketDataRequest.ProtoReflect.Descriptor instead.
+func (*MarketDataRequest) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MarketDataRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+func (x *MarketDataRequest) GetDepth() bool {
+	if x != nil {
+		return x.Depth
+	}
+	return false
+}
+
+// 集合竞价参考报价
+type AuctionQuote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Price         string                 `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`         // 参考成交价，"0"表示买卖盘没有交叉
+	Volume        string                 `protobuf:"bytes,2,opt,name=volume,proto3" json:"volume,omitempty"`       // 按参考成交价可成交的数量
+	Imbalance     string                 `protobuf:"bytes,3,opt,name=imbalance,proto3" json:"imbalance,omitempty"` // 参考成交价上未成交的数量，买方剩余为正，卖方剩余为负
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuctionQuote) Reset() {
+	*x = AuctionQuote{}
+	mi := &file_matching_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionQuote) ProtoMessage() {}
+
+func (x *AuctionQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionQuote.ProtoReflect.Descriptor instead.
+func (*AuctionQuote) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AuctionQuote) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *AuctionQuote) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *AuctionQuote) GetImbalance() string {
+	if x != nil {
+		return x.Imbalance
+	}
+	return ""
+}
+
+// 增量深度，价格层级为更新后的数量及订单数，数量为"0"表示该价格层级已移除
+// 同一交易对相邻两次更新满足 first_update_id = 上一次的last_update_id + 1，不满足时需重新获取快照
+type DepthUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FirstUpdateId uint64                 `protobuf:"varint,1,opt,name=first_update_id,json=firstUpdateId,proto3" json:"first_update_id,omitempty"`
+	LastUpdateId  uint64                 `protobuf:"varint,2,opt,name=last_update_id,json=lastUpdateId,proto3" json:"last_update_id,omitempty"`
+	Bids          []*PriceLevel          `protobuf:"bytes,3,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks          []*PriceLevel          `protobuf:"bytes,4,rep,name=asks,proto3" json:"asks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepthUpdate) Reset() {
+	*x = DepthUpdate{}
+	mi := &file_matching_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepthUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepthUpdate) ProtoMessage() {}
+
+func (x *DepthUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepthUpdate.ProtoReflect.Descriptor instead.
+func (*DepthUpdate) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DepthUpdate) GetFirstUpdateId() uint64 {
+	if x != nil {
+		return x.FirstUpdateId
+	}
+	return 0
+}
+
+func (x *DepthUpdate) GetLastUpdateId() uint64 {
+	if x != nil {
+		return x.LastUpdateId
+	}
+	return 0
+}
+
+func (x *DepthUpdate) GetBids() []*PriceLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *DepthUpdate) GetAsks() []*PriceLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+// 行情事件
+type MarketDataEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"` // 1:交易阶段切换, 2:集合竞价参考报价, 3:集合竞价撮合, 4:增量深度
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Seq           uint64                 `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`             // 产生事件的命令序号
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // 事件时间(纳秒)
+	Phase         *PhaseChange           `protobuf:"bytes,5,opt,name=phase,proto3" json:"phase,omitempty"`          // type为1时有效
+	Auction       *AuctionQuote          `protobuf:"bytes,6,opt,name=auction,proto3" json:"auction,omitempty"`      // type为2/3时有效，撮合时为实际成交价及成交量
+	Trades        []*Trade               `protobuf:"bytes,7,rep,name=trades,proto3" json:"trades,omitempty"`        // type为3时有效，集合竞价成交没有主动方
+	Depth         *DepthUpdate           `protobuf:"bytes,8,opt,name=depth,proto3" json:"depth,omitempty"`          // type为4时有效
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketDataEvent) Reset() {
+	*x = MarketDataEvent{}
+	mi := &file_matching_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketDataEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketDataEvent) ProtoMessage() {}
+
+func (x *MarketDataEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketDataEvent.ProtoReflect.Descriptor instead.
+func (*MarketDataEvent) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MarketDataEvent) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *MarketDataEvent) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *MarketDataEvent) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *MarketDataEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *MarketDataEvent) GetPhase() *PhaseChange {
+	if x != nil {
+		return x.Phase
+	}
+	return nil
+}
+
+func (x *MarketDataEvent) GetAuction() *AuctionQuote {
+	if x != nil {
+		return x.Auction
+	}
+	return nil
+}
+
+func (x *MarketDataEvent) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+func (x *MarketDataEvent) GetDepth() *DepthUpdate {
+	if x != nil {
+		return x.Depth
+	}
+	return nil
+}
+
+type OrderFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbols       []string               `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"` // 为空时订阅全部交易对
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderFeedRequest) Reset() {
+	*x = OrderFeedRequest{}
+	mi := &file_matching_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderFeedRequest) ProtoMessage() {}
+
+func (x *OrderFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFeedRequest.ProtoReflect.Descriptor instead.
+func (*OrderFeedRequest) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *OrderFeedRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+// 逐笔委托事件，不含账户及client_id
+type OrderFeedEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           uint64                 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`   // 同一交易对内连续递增，不连续时说明有遗漏
+	Type          int32                  `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"` // 1:新增, 2:原地减量, 3:成交, 4:删除
+	OrderId       uint64                 `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Side          int32                  `protobuf:"varint,4,opt,name=side,proto3" json:"side,omitempty"`
+	Price         string                 `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      string                 `protobuf:"bytes,6,opt,name=quantity,proto3" json:"quantity,omitempty"`               // 事件后的展示数量，"0"表示订单已移出订单簿
+	ExecQty       string                 `protobuf:"bytes,7,opt,name=exec_qty,json=execQty,proto3" json:"exec_qty,omitempty"`  // type为3时有效
+	TradeId       uint64                 `protobuf:"varint,8,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"` // type为3时有效
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderFeedEntry) Reset() {
+	*x = OrderFeedEntry{}
+	mi := &file_matching_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderFeedEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderFeedEntry) ProtoMessage() {}
+
+func (x *OrderFeedEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFeedEntry.ProtoReflect.Descriptor instead.
+func (*OrderFeedEntry) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *OrderFeedEntry) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *OrderFeedEntry) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *OrderFeedEntry) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *OrderFeedEntry) GetSide() int32 {
+	if x != nil {
+		return x.Side
+	}
+	return 0
+}
+
+func (x *OrderFeedEntry) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *OrderFeedEntry) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *OrderFeedEntry) GetExecQty() string {
+	if x != nil {
+		return x.ExecQty
+	}
+	return ""
+}
+
+func (x *OrderFeedEntry) GetTradeId() uint64 {
+	if x != nil {
+		return x.TradeId
+	}
+	return 0
+}
+
+// 一条命令产生的逐笔委托事件
+type OrderFeedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Seq           uint64                 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`             // 产生事件的命令序号
+	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // 事件时间(纳秒)
+	Entries       []*OrderFeedEntry      `protobuf:"bytes,4,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderFeedEvent) Reset() {
+	*x = OrderFeedEvent{}
+	mi := &file_matching_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderFeedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderFeedEvent) ProtoMessage() {}
+
+func (x *OrderFeedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_matching_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFeedEvent.ProtoReflect.Descriptor instead.
+func (*OrderFeedEvent) Descriptor() ([]byte, []int) {
+	return file_matching_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *OrderFeedEvent) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OrderFeedEvent) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *OrderFeedEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *OrderFeedEvent) GetEntries() []*OrderFeedEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+var File_matching_proto protoreflect.FileDescriptor
+
+const file_matching_proto_rawDesc = "" +
+	"\n" +
+	"\x0ematching.proto\x12\x05match\"\xce\x03\n" +
+	"\x05Order\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\tR\bquantity\x12\x12\n" +
+	"\x04side\x18\x05 \x01(\x05R\x04side\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\x05R\x04type\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\x12\x1b\n" +
+	"\tclient_id\x18\b \x01(\tR\bclientId\x12\"\n" +
+	"\rtime_in_force\x18\t \x01(\x05R\vtimeInForce\x12\x1f\n" +
+	"\vexpire_time\x18\n" +
+	" \x01(\x03R\n" +
+	"expireTime\x12\x1b\n" +
+	"\tpost_only\x18\v \x01(\x05R\bpostOnly\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\f \x01(\tR\tstopPrice\x12\x1f\n" +
+	"\vdisplay_qty\x18\r \x01(\tR\n" +
+	"displayQty\x12\x19\n" +
+	"\bstp_mode\x18\x0e \x01(\x05R\astpMode\x12\x1b\n" +
+	"\tquote_qty\x18\x0f \x01(\tR\bquoteQty\x12\x14\n" +
+	"\x05async\x18\x10 \x01(\bR\x05async\x12\x18\n" +
+	"\aaccount\x18\x11 \x01(\tR\aaccount\"\xf5\x01\n" +
+	"\x05Trade\x12\x19\n" +
+	"\btrade_id\x18\x01 \x01(\x04R\atradeId\x12$\n" +
+	"\x0etaker_order_id\x18\x02 \x01(\x04R\ftakerOrderId\x12$\n" +
+	"\x0emaker_order_id\x18\x03 \x01(\x04R\fmakerOrderId\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\tR\bquantity\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"taker_side\x18\b \x01(\x05R\ttakerSide\"\xde\x02\n" +
+	"\x0eSelfTradeEvent\x12$\n" +
+	"\x0etaker_order_id\x18\x01 \x01(\x04R\ftakerOrderId\x12$\n" +
+	"\x0emaker_order_id\x18\x02 \x01(\x04R\fmakerOrderId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\tR\x05price\x12\x12\n" +
+	"\x04mode\x18\x06 \x01(\x05R\x04mode\x12.\n" +
+	"\x13taker_cancelled_qty\x18\a \x01(\tR\x11takerCancelledQty\x12.\n" +
+	"\x13maker_cancelled_qty\x18\b \x01(\tR\x11makerCancelledQty\x12#\n" +
+	"\rmaker_removed\x18\t \x01(\bR\fmakerRemoved\x12\x1c\n" +
+	"\ttimestamp\x18\n" +
+	" \x01(\x03R\ttimestamp\"\x97\x02\n" +
+	"\vMatchResult\x12$\n" +
+	"\x06trades\x18\x01 \x03(\v2\f.match.TradeR\x06trades\x12\"\n" +
+	"\x05order\x18\x02 \x01(\v2\f.match.OrderR\x05order\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x126\n" +
+	"\vself_trades\x18\x04 \x03(\v2\x15.match.SelfTradeEventR\n" +
+	"selfTrades\x12'\n" +
+	"\x0fremaining_quote\x18\x05 \x01(\tR\x0eremainingQuote\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\x05R\x06status\x12'\n" +
+	"\x0ffilled_quantity\x18\a \x01(\tR\x0efilledQuantity\"@\n" +
+	"\x10OrderBookRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\"\xb4\x01\n" +
+	"\x11OrderBookSnapshot\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12%\n" +
+	"\x04bids\x18\x02 \x03(\v2\x11.match.PriceLevelR\x04bids\x12%\n" +
+	"\x04asks\x18\x03 \x03(\v2\x11.match.PriceLevelR\x04asks\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12\x1b\n" +
+	"\tupdate_id\x18\x05 \x01(\x04R\bupdateId\"_\n" +
+	"\n" +
+	"PriceLevel\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\tR\bquantity\x12\x1f\n" +
+	"\vorder_count\x18\x03 \x01(\x05R\n" +
+	"orderCount\"G\n" +
+	"\x12CancelOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\"x\n" +
+	"\x13CancelOrderResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\x04R\aorderId\x12\x12\n" +
+	"\x04code\x18\x04 \x01(\rR\x04code\"F\n" +
+	"\x11QueryOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\"\x93\x01\n" +
+	"\x12QueryOrderResponse\x12\"\n" +
+	"\x05order\x18\x01 \x01(\v2\f.match.OrderR\x05order\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x05R\x06status\x12'\n" +
+	"\x0ffilled_quantity\x18\x03 \x01(\tR\x0efilledQuantity\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\rR\aversion\"\x92\x01\n" +
+	"\x11AmendOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\tR\bquantity\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\rR\aversion\"\xe2\x01\n" +
+	"\x12AmendOrderResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\x04R\aorderId\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\rR\aversion\x12)\n" +
+	"\x10resting_quantity\x18\x05 \x01(\tR\x0frestingQuantity\x12$\n" +
+	"\x06trades\x18\x06 \x03(\v2\f.match.TradeR\x06trades\x12\x12\n" +
+	"\x04code\x18\a \x01(\rR\x04code\"v\n" +
+	"\x11MassCancelRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\x02 \x01(\x05R\x04side\x12\x18\n" +
+	"\aaccount\x18\x03 \x01(\tR\aaccount\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\"X\n" +
+	"\x12MassCancelResponse\x12\x1b\n" +
+	"\torder_ids\x18\x01 \x03(\x04R\borderIds\x12%\n" +
+	"\x0efailed_symbols\x18\x02 \x03(\tR\rfailedSymbols\"-\n" +
+	"\x13ExchangeInfoRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"\x95\x02\n" +
+	"\x0eInstrumentInfo\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
+	"\vprice_scale\x18\x03 \x01(\x05R\n" +
+	"priceScale\x12%\n" +
+	"\x0equantity_scale\x18\x04 \x01(\x05R\rquantityScale\x12\x1b\n" +
+	"\ttick_size\x18\x05 \x01(\tR\btickSize\x12\x19\n" +
+	"\blot_size\x18\x06 \x01(\tR\alotSize\x12\x17\n" +
+	"\amin_qty\x18\a \x01(\tR\x06minQty\x12\x17\n" +
+	"\amax_qty\x18\b \x01(\tR\x06maxQty\x12!\n" +
+	"\fmin_notional\x18\t \x01(\tR\vminNotional\"m\n" +
+	"\x14ExchangeInfoResponse\x127\n" +
+	"\vinstruments\x18\x01 \x03(\v2\x15.match.InstrumentInfoR\vinstruments\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\"m\n" +
+	"\x0eReplicationAck\x12\x1f\n" +
+	"\vfollower_id\x18\x01 \x01(\tR\n" +
+	"followerId\x12\x19\n" +
+	"\bfrom_seq\x18\x02 \x01(\x04R\afromSeq\x12\x1f\n" +
+	"\vapplied_seq\x18\x03 \x01(\x04R\n" +
+	"appliedSeq\"_\n" +
+	"\x10ReplicationEntry\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\fR\acommand\x12\x1f\n" +
+	"\vprimary_seq\x18\x03 \x01(\x04R\n" +
+	"primarySeq\"\x1a\n" +
+	"\x18ReplicationStatusRequest\"\x83\x01\n" +
+	"\x0eFollowerStatus\x12\x1f\n" +
+	"\vfollower_id\x18\x01 \x01(\tR\n" +
+	"followerId\x12\x1b\n" +
+	"\tacked_seq\x18\x02 \x01(\x04R\backedSeq\x12\x10\n" +
+	"\x03lag\x18\x03 \x01(\x04R\x03lag\x12!\n" +
+	"\fconnected_at\x18\x04 \x01(\x03R\vconnectedAt\"\xe7\x01\n" +
+	"\x19ReplicationStatusResponse\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x19\n" +
+	"\blast_seq\x18\x02 \x01(\x04R\alastSeq\x123\n" +
+	"\tfollowers\x18\x03 \x03(\v2\x15.match.FollowerStatusR\tfollowers\x12\x1c\n" +
+	"\tconnected\x18\x04 \x01(\bR\tconnected\x12\x1f\n" +
+	"\vprimary_seq\x18\x05 \x01(\x04R\n" +
+	"primarySeq\x12\x10\n" +
+	"\x03lag\x18\x06 \x01(\x04R\x03lag\x12\x15\n" +
+	"\x06lag_ms\x18\a \x01(\x03R\x05lagMs\"\x10\n" +
+	"\x0ePromoteRequest\"\xc6\x01\n" +
+	"\x13ManageSymbolRequest\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\x05R\x06action\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x125\n" +
+	"\n" +
+	"instrument\x18\x03 \x01(\v2\x15.match.InstrumentInfoR\n" +
+	"instrument\x12\x14\n" +
+	"\x05phase\x18\x04 \x01(\tR\x05phase\x12\x1a\n" +
+	"\boperator\x18\x05 \x01(\tR\boperator\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\"\xad\x01\n" +
+	"\vPhaseChange\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x10\n" +
+	"\x03seq\x18\x04 \x01(\x04R\x03seq\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12\x1a\n" +
+	"\boperator\x18\x06 \x01(\tR\boperator\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\"\x9b\x01\n" +
+	"\n" +
+	"SymbolInfo\x125\n" +
+	"\n" +
+	"instrument\x18\x01 \x01(\v2\x15.match.InstrumentInfoR\n" +
+	"instrument\x12\x16\n" +
+	"\x06worker\x18\x02 \x01(\x05R\x06worker\x12\x1d\n" +
+	"\n" +
+	"queue_size\x18\x03 \x01(\x04R\tqueueSize\x12\x1f\n" +
+	"\vorder_count\x18\x04 \x01(\x05R\n" +
+	"orderCount\"\xe0\x01\n" +
+	"\x14ManageSymbolResponse\x12+\n" +
+	"\asymbols\x18\x01 \x03(\v2\x11.match.SymbolInfoR\asymbols\x12)\n" +
+	"\x10cancelled_orders\x18\x02 \x01(\x05R\x0fcancelledOrders\x12\x1b\n" +
+	"\tfinal_seq\x18\x03 \x01(\x04R\bfinalSeq\x12\x1a\n" +
+	"\barchived\x18\x04 \x01(\bR\barchived\x127\n" +
+	"\rphase_changes\x18\x05 \x03(\v2\x12.match.PhaseChangeR\fphaseChanges\"C\n" +
+	"\x11MarketDataRequest\x12\x18\n" +
+	"\asymbols\x18\x01 \x03(\tR\asymbols\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\bR\x05depth\"Z\n" +
+	"\fAuctionQuote\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\tR\x05price\x12\x16\n" +
+	"\x06volume\x18\x02 \x01(\tR\x06volume\x12\x1c\n" +
+	"\timbalance\x18\x03 \x01(\tR\timbalance\"\xa9\x01\n" +
+	"\vDepthUpdate\x12&\n" +
+	"\x0ffirst_update_id\x18\x01 \x01(\x04R\rfirstUpdateId\x12$\n" +
+	"\x0elast_update_id\x18\x02 \x01(\x04R\flastUpdateId\x12%\n" +
+	"\x04bids\x18\x03 \x03(\v2\x11.match.PriceLevelR\x04bids\x12%\n" +
+	"\x04asks\x18\x04 \x03(\v2\x11.match.PriceLevelR\x04asks\"\x96\x02\n" +
+	"\x0fMarketDataEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\x05R\x04type\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x10\n" +
+	"\x03seq\x18\x03 \x01(\x04R\x03seq\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12(\n" +
+	"\x05phase\x18\x05 \x01(\v2\x12.match.PhaseChangeR\x05phase\x12-\n" +
+	"\aauction\x18\x06 \x01(\v2\x13.match.AuctionQuoteR\aauction\x12$\n" +
+	"\x06trades\x18\a \x03(\v2\f.match.TradeR\x06trades\x12(\n" +
+	"\x05depth\x18\b \x01(\v2\x12.match.DepthUpdateR\x05depth\",\n" +
+	"\x10OrderFeedRequest\x12\x18\n" +
+	"\asymbols\x18\x01 \x03(\tR\asymbols\"\xcd\x01\n" +
+	"\x0eOrderFeedEntry\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\x05R\x04type\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\x04R\aorderId\x12\x12\n" +
+	"\x04side\x18\x04 \x01(\x05R\x04side\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\tR\bquantity\x12\x19\n" +
+	"\bexec_qty\x18\a \x01(\tR\aexecQty\x12\x19\n" +
+	"\btrade_id\x18\b \x01(\x04R\atradeId\"\x89\x01\n" +
+	"\x0eOrderFeedEvent\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x04R\x03seq\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12/\n" +
+	"\aentries\x18\x04 \x03(\v2\x15.match.OrderFeedEntryR\aentries2\x9a\a\n" +
+	"\fMatchService\x120\n" +
+	"\fProcessOrder\x12\f.match.Order\x1a\x12.match.MatchResult\x12A\n" +
+	"\fGetOrderBook\x12\x17.match.OrderBookRequest\x1a\x18.match.OrderBookSnapshot\x12D\n" +
+	"\vCancelOrder\x12\x19.match.CancelOrderRequest\x1a\x1a.match.CancelOrderResponse\x12A\n" +
+	"\n" +
+	"QueryOrder\x12\x18.match.QueryOrderRequest\x1a\x19.match.QueryOrderResponse\x12A\n" +
+	"\n" +
+	"AmendOrder\x12\x18.match.AmendOrderRequest\x1a\x19.match.AmendOrderResponse\x12A\n" +
+	"\n" +
+	"MassCancel\x12\x18.match.MassCancelRequest\x1a\x19.match.MassCancelResponse\x12J\n" +
+	"\x0fGetExchangeInfo\x12\x1a.match.ExchangeInfoRequest\x1a\x1b.match.ExchangeInfoResponse\x12?\n" +
+	"\tReplicate\x12\x15.match.ReplicationAck\x1a\x17.match.ReplicationEntry(\x010\x01\x12Y\n" +
+	"\x14GetReplicationStatus\x12\x1f.match.ReplicationStatusRequest\x1a .match.ReplicationStatusResponse\x12B\n" +
+	"\aPromote\x12\x15.match.PromoteRequest\x1a .match.ReplicationStatusResponse\x12G\n" +
+	"\fManageSymbol\x12\x1a.match.ManageSymbolRequest\x1a\x1b.match.ManageSymbolResponse\x12I\n" +
+	"\x13SubscribeMarketData\x12\x18.match.MarketDataRequest\x1a\x16.match.MarketDataEvent0\x01\x12F\n" +
+	"\x12SubscribeOrderFeed\x12\x17.match.OrderFeedRequest\x1a\x15.match.OrderFeedEvent0\x01B\tZ\a./matchb\x06proto3"
 
 var (
 	file_matching_proto_rawDescOnce sync.Once
@@ -509,29 +2870,96 @@ func file_matching_proto_rawDescGZIP() []byte {
 	return file_matching_proto_rawDescData
 }
 
-var file_matching_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_matching_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_matching_proto_goTypes = []any{
-	(*Order)(nil),             // 0: match.Order
-	(*Trade)(nil),             // 1: match.Trade
-	(*MatchResult)(nil),       // 2: match.MatchResult
-	(*OrderBookRequest)(nil),  // 3: match.OrderBookRequest
-	(*OrderBookSnapshot)(nil), // 4: match.OrderBookSnapshot
-	(*PriceLevel)(nil),        // 5: match.PriceLevel
+	(*Order)(nil),                     // 0: match.Order
+	(*Trade)(nil),                     // 1: match.Trade
+	(*SelfTradeEvent)(nil),            // 2: match.SelfTradeEvent
+	(*MatchResult)(nil),               // 3: match.MatchResult
+	(*OrderBookRequest)(nil),          // 4: match.OrderBookRequest
+	(*OrderBookSnapshot)(nil),         // 5: match.OrderBookSnapshot
+	(*PriceLevel)(nil),                // 6: match.PriceLevel
+	(*CancelOrderRequest)(nil),        // 7: match.CancelOrderRequest
+	(*CancelOrderResponse)(nil),       // 8: match.CancelOrderResponse
+	(*QueryOrderRequest)(nil),         // 9: match.QueryOrderRequest
+	(*QueryOrderResponse)(nil),        // 10: match.QueryOrderResponse
+	(*AmendOrderRequest)(nil),         // 11: match.AmendOrderRequest
+	(*AmendOrderResponse)(nil),        // 12: match.AmendOrderResponse
+	(*MassCancelRequest)(nil),         // 13: match.MassCancelRequest
+	(*MassCancelResponse)(nil),        // 14: match.MassCancelResponse
+	(*ExchangeInfoRequest)(nil),       // 15: match.ExchangeInfoRequest
+	(*InstrumentInfo)(nil),            // 16: match.InstrumentInfo
+	(*ExchangeInfoResponse)(nil),      // 17: match.ExchangeInfoResponse
+	(*ReplicationAck)(nil),            // 18: match.ReplicationAck
+	(*ReplicationEntry)(nil),          // 19: match.ReplicationEntry
+	(*ReplicationStatusRequest)(nil),  // 20: match.ReplicationStatusRequest
+	(*FollowerStatus)(nil),            // 21: match.FollowerStatus
+	(*ReplicationStatusResponse)(nil), // 22: match.ReplicationStatusResponse
+	(*PromoteRequest)(nil),            // 23: match.PromoteRequest
+	(*ManageSymbolRequest)(nil),       // 24: match.ManageSymbolRequest
+	(*PhaseChange)(nil),               // 25: match.PhaseChange
+	(*SymbolInfo)(nil),                // 26: match.SymbolInfo
+	(*ManageSymbolResponse)(nil),      // 27: match.ManageSymbolResponse
+	(*MarketDataRequest)(nil),         // 28: match.MarketDataRequest
+	(*AuctionQuote)(nil),              // 29: match.AuctionQuote
+	(*DepthUpdate)(nil),               // 30: match.DepthUpdate
+	(*MarketDataEvent)(nil),           // 31: match.MarketDataEvent
+	(*OrderFeedRequest)(nil),          // 32: match.OrderFeedRequest
+	(*OrderFeedEntry)(nil),            // 33: match.OrderFeedEntry
+	(*OrderFeedEvent)(nil),            // 34: match.OrderFeedEvent
 }
 var file_matching_proto_depIdxs = []int32{
-	1, // 0: match.MatchResult.trades:type_name -> match.Trade
-	0, // 1: match.MatchResult.order:type_name -> match.Order
-	5, // 2: match.OrderBookSnapshot.bids:type_name -> match.PriceLevel
-	5, // 3: match.OrderBookSnapshot.asks:type_name -> match.PriceLevel
-	0, // 4: match.MatchService.ProcessOrder:input_type -> match.Order
-	3, // 5: match.MatchService.GetOrderBook:input_type -> match.OrderBookRequest
-	2, // 6: match.MatchService.ProcessOrder:output_type -> match.MatchResult
-	4, // 7: match.MatchService.GetOrderBook:output_type -> match.OrderBookSnapshot
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	1,  // 0: match.MatchResult.trades:type_name -> match.Trade
+	0,  // 1: match.MatchResult.order:type_name -> match.Order
+	2,  // 2: match.MatchResult.self_trades:type_name -> match.SelfTradeEvent
+	6,  // 3: match.OrderBookSnapshot.bids:type_name -> match.PriceLevel
+	6,  // 4: match.OrderBookSnapshot.asks:type_name -> match.PriceLevel
+	0,  // 5: match.QueryOrderResponse.order:type_name -> match.Order
+	1,  // 6: match.AmendOrderResponse.trades:type_name -> match.Trade
+	16, // 7: match.ExchangeInfoResponse.instruments:type_name -> match.InstrumentInfo
+	21, // 8: match.ReplicationStatusResponse.followers:type_name -> match.FollowerStatus
+	16, // 9: match.ManageSymbolRequest.instrument:type_name -> match.InstrumentInfo
+	16, // 10: match.SymbolInfo.instrument:type_name -> match.InstrumentInfo
+	26, // 11: match.ManageSymbolResponse.symbols:type_name -> match.SymbolInfo
+	25, // 12: match.ManageSymbolResponse.phase_changes:type_name -> match.PhaseChange
+	6,  // 13: match.DepthUpdate.bids:type_name -> match.PriceLevel
+	6,  // 14: match.DepthUpdate.asks:type_name -> match.PriceLevel
+	25, // 15: match.MarketDataEvent.phase:type_name -> match.PhaseChange
+	29, // 16: match.MarketDataEvent.auction:type_name -> match.AuctionQuote
+	1,  // 17: match.MarketDataEvent.trades:type_name -> match.Trade
+	30, // 18: match.MarketDataEvent.depth:type_name -> match.DepthUpdate
+	33, // 19: match.OrderFeedEvent.entries:type_name -> match.OrderFeedEntry
+	0,  // 20: match.MatchService.ProcessOrder:input_type -> match.Order
+	4,  // 21: match.MatchService.GetOrderBook:input_type -> match.OrderBookRequest
+	7,  // 22: match.MatchService.CancelOrder:input_type -> match.CancelOrderRequest
+	9,  // 23: match.MatchService.QueryOrder:input_type -> match.QueryOrderRequest
+	11, // 24: match.MatchService.AmendOrder:input_type -> match.AmendOrderRequest
+	13, // 25: match.MatchService.MassCancel:input_type -> match.MassCancelRequest
+	15, // 26: match.MatchService.GetExchangeInfo:input_type -> match.ExchangeInfoRequest
+	18, // 27: match.MatchService.Replicate:input_type -> match.ReplicationAck
+	20, // 28: match.MatchService.GetReplicationStatus:input_type -> match.ReplicationStatusRequest
+	23, // 29: match.MatchService.Promote:input_type -> match.PromoteRequest
+	24, // 30: match.MatchService.ManageSymbol:input_type -> match.ManageSymbolRequest
+	28, // 31: match.MatchService.SubscribeMarketData:input_type -> match.MarketDataRequest
+	32, // 32: match.MatchService.SubscribeOrderFeed:input_type -> match.OrderFeedRequest
+	3,  // 33: match.MatchService.ProcessOrder:output_type -> match.MatchResult
+	5,  // 34: match.MatchService.GetOrderBook:output_type -> match.OrderBookSnapshot
+	8,  // 35: match.MatchService.CancelOrder:output_type -> match.CancelOrderResponse
+	10, // 36: match.MatchService.QueryOrder:output_type -> match.QueryOrderResponse
+	12, // 37: match.MatchService.AmendOrder:output_type -> match.AmendOrderResponse
+	14, // 38: match.MatchService.MassCancel:output_type -> match.MassCancelResponse
+	17, // 39: match.MatchService.GetExchangeInfo:output_type -> match.ExchangeInfoResponse
+	19, // 40: match.MatchService.Replicate:output_type -> match.ReplicationEntry
+	22, // 41: match.MatchService.GetReplicationStatus:output_type -> match.ReplicationStatusResponse
+	22, // 42: match.MatchService.Promote:output_type -> match.ReplicationStatusResponse
+	27, // 43: match.MatchService.ManageSymbol:output_type -> match.ManageSymbolResponse
+	31, // 44: match.MatchService.SubscribeMarketData:output_type -> match.MarketDataEvent
+	34, // 45: match.MatchService.SubscribeOrderFeed:output_type -> match.OrderFeedEvent
+	33, // [33:46] is the sub-list for method output_type
+	20, // [20:33] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_matching_proto_init() }
@@ -545,7 +2973,7 @@ func file_matching_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_matching_proto_rawDesc), len(file_matching_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MatchService_ProcessOrder_FullMethodName = "/match.MatchService/ProcessOrder"
-	MatchService_GetOrderBook_FullMethodName = "/match.MatchService/GetOrderBook"
+	MatchService_ProcessOrder_FullMethodName         = "/match.MatchService/ProcessOrder"
+	MatchService_GetOrderBook_FullMethodName         = "/match.MatchService/GetOrderBook"
+	MatchService_CancelOrder_FullMethodName          = "/match.MatchService/CancelOrder"
+	MatchService_QueryOrder_FullMethodName           = "/match.MatchService/QueryOrder"
+	MatchService_AmendOrder_FullMethodName           = "/match.MatchService/AmendOrder"
+	MatchService_MassCancel_FullMethodName           = "/match.MatchService/MassCancel"
+	MatchService_GetExchangeInfo_FullMethodName      = "/match.MatchService/GetExchangeInfo"
+	MatchService_Replicate_FullMethodName            = "/match.MatchService/Replicate"
+	MatchService_GetReplicationStatus_FullMethodName = "/match.MatchService/GetReplicationStatus"
+	MatchService_Promote_FullMethodName              = "/match.MatchService/Promote"
+	MatchService_ManageSymbol_FullMethodName         = "/match.MatchService/ManageSymbol"
+	MatchService_SubscribeMarketData_FullMethodName  = "/match.MatchService/SubscribeMarketData"
+	MatchService_SubscribeOrderFeed_FullMethodName   = "/match.MatchService/SubscribeOrderFeed"
 )
 
 // MatchServiceClient is the client API for MatchService service.
@@ -29,6 +40,17 @@ const (
 type MatchServiceClient interface {
 	ProcessOrder(ctx context.Context, in *Order, opts ...grpc.CallOption) (*MatchResult, error)
 	GetOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (*OrderBookSnapshot, error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+	QueryOrder(ctx context.Context, in *QueryOrderRequest, opts ...grpc.CallOption) (*QueryOrderResponse, error)
+	AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*AmendOrderResponse, error)
+	MassCancel(ctx context.Context, in *MassCancelRequest, opts ...grpc.CallOption) (*MassCancelResponse, error)
+	GetExchangeInfo(ctx context.Context, in *ExchangeInfoRequest, opts ...grpc.CallOption) (*ExchangeInfoResponse, error)
+	Replicate(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ReplicationAck, ReplicationEntry], error)
+	GetReplicationStatus(ctx context.Context, in *ReplicationStatusRequest, opts ...grpc.CallOption) (*ReplicationStatusResponse, error)
+	Promote(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*ReplicationStatusResponse, error)
+	ManageSymbol(ctx context.Context, in *ManageSymbolRequest, opts ...grpc.CallOption) (*ManageSymbolResponse, error)
+	SubscribeMarketData(ctx context.Context, in *MarketDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MarketDataEvent], error)
+	SubscribeOrderFeed(ctx context.Context, in *OrderFeedRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderFeedEvent], error)
 }
 
 type matchServiceClient struct {
@@ -59,12 +81,154 @@ func (c *matchServiceClient) GetOrderBook(ctx context.Context, in *OrderBookRequ
 	return out, nil
 }
 
+func (c *matchServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelOrderResponse)
+	err := c.cc.Invoke(ctx, MatchService_CancelOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) QueryOrder(ctx context.Context, in *QueryOrderRequest, opts ...grpc.CallOption) (*QueryOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryOrderResponse)
+	err := c.cc.Invoke(ctx, MatchService_QueryOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*AmendOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AmendOrderResponse)
+	err := c.cc.Invoke(ctx, MatchService_AmendOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) MassCancel(ctx context.Context, in *MassCancelRequest, opts ...grpc.CallOption) (*MassCancelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MassCancelResponse)
+	err := c.cc.Invoke(ctx, MatchService_MassCancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) GetExchangeInfo(ctx context.Context, in *ExchangeInfoRequest, opts ...grpc.CallOption) (*ExchangeInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExchangeInfoResponse)
+	err := c.cc.Invoke(ctx, MatchService_GetExchangeInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) Replicate(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ReplicationAck, ReplicationEntry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MatchService_ServiceDesc.Streams[0], MatchService_Replicate_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReplicationAck, ReplicationEntry]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchService_ReplicateClient = grpc.BidiStreamingClient[ReplicationAck, ReplicationEntry]
+
+func (c *matchServiceClient) GetReplicationStatus(ctx context.Context, in *ReplicationStatusRequest, opts ...grpc.CallOption) (*ReplicationStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplicationStatusResponse)
+	err := c.cc.Invoke(ctx, MatchService_GetReplicationStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) Promote(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*ReplicationStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplicationStatusResponse)
+	err := c.cc.Invoke(ctx, MatchService_Promote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) ManageSymbol(ctx context.Context, in *ManageSymbolRequest, opts ...grpc.CallOption) (*ManageSymbolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ManageSymbolResponse)
+	err := c.cc.Invoke(ctx, MatchService_ManageSymbol_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) SubscribeMarketData(ctx context.Context, in *MarketDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MarketDataEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MatchService_ServiceDesc.Streams[1], MatchService_SubscribeMarketData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MarketDataRequest, MarketDataEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchService_SubscribeMarketDataClient = grpc.ServerStreamingClient[MarketDataEvent]
+
+func (c *matchServiceClient) SubscribeOrderFeed(ctx context.Context, in *OrderFeedRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderFeedEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MatchService_ServiceDesc.Streams[2], MatchService_SubscribeOrderFeed_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[OrderFeedRequest, OrderFeedEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchService_SubscribeOrderFeedClient = grpc.ServerStreamingClient[OrderFeedEvent]
+
 // MatchServiceServer is the server API for MatchService service.
 // All implementations must embed UnimplementedMatchServiceServer
 // for forward compatibility.
 type MatchServiceServer interface {
 	ProcessOrder(context.Context, *Order) (*MatchResult, error)
 	GetOrderBook(context.Context, *OrderBookRequest) (*OrderBookSnapshot, error)
+	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
+	QueryOrder(context.Context, *QueryOrderRequest) (*QueryOrderResponse, error)
+	AmendOrder(context.Context, *AmendOrderRequest) (*AmendOrderResponse, error)
+	MassCancel(context.Context, *MassCancelRequest) (*MassCancelResponse, error)
+	GetExchangeInfo(context.Context, *ExchangeInfoRequest) (*ExchangeInfoResponse, error)
+	Replicate(grpc.BidiStreamingServer[ReplicationAck, ReplicationEntry]) error
+	GetReplicationStatus(context.Context, *ReplicationStatusRequest) (*ReplicationStatusResponse, error)
+	Promote(context.Context, *PromoteRequest) (*ReplicationStatusResponse, error)
+	ManageSymbol(context.Context, *ManageSymbolRequest) (*ManageSymbolResponse, error)
+	SubscribeMarketData(*MarketDataRequest, grpc.ServerStreamingServer[MarketDataEvent]) error
+	SubscribeOrderFeed(*OrderFeedRequest, grpc.ServerStreamingServer[OrderFeedEvent]) error
 	mustEmbedUnimplementedMatchServiceServer()
 }
 
@@ -81,6 +245,39 @@ func (UnimplementedMatchServiceServer) ProcessOrder(context.Context, *Order) (*M
 func (UnimplementedMatchServiceServer) GetOrderBook(context.Context, *OrderBookRequest) (*OrderBookSnapshot, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrderBook not implemented")
 }
+func (UnimplementedMatchServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedMatchServiceServer) QueryOrder(context.Context, *QueryOrderRequest) (*QueryOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryOrder not implemented")
+}
+func (UnimplementedMatchServiceServer) AmendOrder(context.Context, *AmendOrderRequest) (*AmendOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AmendOrder not implemented")
+}
+func (UnimplementedMatchServiceServer) MassCancel(context.Context, *MassCancelRequest) (*MassCancelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MassCancel not implemented")
+}
+func (UnimplementedMatchServiceServer) GetExchangeInfo(context.Context, *ExchangeInfoRequest) (*ExchangeInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExchangeInfo not implemented")
+}
+func (UnimplementedMatchServiceServer) Replicate(grpc.BidiStreamingServer[ReplicationAck, ReplicationEntry]) error {
+	return status.Errorf(codes.Unimplemented, "method Replicate not implemented")
+}
+func (UnimplementedMatchServiceServer) GetReplicationStatus(context.Context, *ReplicationStatusRequest) (*ReplicationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplicationStatus not implemented")
+}
+func (UnimplementedMatchServiceServer) Promote(context.Context, *PromoteRequest) (*ReplicationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Promote not implemented")
+}
+func (UnimplementedMatchServiceServer) ManageSymbol(context.Context, *ManageSymbolRequest) (*ManageSymbolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ManageSymbol not implemented")
+}
+func (UnimplementedMatchServiceServer) SubscribeMarketData(*MarketDataRequest, grpc.ServerStreamingServer[MarketDataEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMarketData not implemented")
+}
+func (UnimplementedMatchServiceServer) SubscribeOrderFeed(*OrderFeedRequest, grpc.ServerStreamingServer[OrderFeedEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeOrderFeed not implemented")
+}
 func (UnimplementedMatchServiceServer) mustEmbedUnimplementedMatchServiceServer() {}
 func (UnimplementedMatchServiceServer) testEmbeddedByValue()                      {}
 
@@ -138,6 +335,179 @@ func _MatchService_GetOrderBook_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MatchService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_QueryOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).QueryOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_QueryOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).QueryOrder(ctx, req.(*QueryOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_AmendOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AmendOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).AmendOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_AmendOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).AmendOrder(ctx, req.(*AmendOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_MassCancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MassCancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).MassCancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_MassCancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).MassCancel(ctx, req.(*MassCancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_GetExchangeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).GetExchangeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_GetExchangeInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).GetExchangeInfo(ctx, req.(*ExchangeInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_Replicate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MatchServiceServer).Replicate(&grpc.GenericServerStream[ReplicationAck, ReplicationEntry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchService_ReplicateServer = grpc.BidiStreamingServer[ReplicationAck, ReplicationEntry]
+
+func _MatchService_GetReplicationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplicationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).GetReplicationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_GetReplicationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).GetReplicationStatus(ctx, req.(*ReplicationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_Promote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).Promote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_Promote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).Promote(ctx, req.(*PromoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_ManageSymbol_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ManageSymbolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).ManageSymbol(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_ManageSymbol_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).ManageSymbol(ctx, req.(*ManageSymbolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_SubscribeMarketData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MarketDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchServiceServer).SubscribeMarketData(m, &grpc.GenericServerStream[MarketDataRequest, MarketDataEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchService_SubscribeMarketDataServer = grpc.ServerStreamingServer[MarketDataEvent]
+
+func _MatchService_SubscribeOrderFeed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OrderFeedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchServiceServer).SubscribeOrderFeed(m, &grpc.GenericServerStream[OrderFeedRequest, OrderFeedEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchService_SubscribeOrderFeedServer = grpc.ServerStreamingServer[OrderFeedEvent]
+
 // MatchService_ServiceDesc is the grpc.ServiceDesc for MatchService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,7 +523,56 @@ var MatchService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOrderBook",
 			Handler:    _MatchService_GetOrderBook_Handler,
 		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _MatchService_CancelOrder_Handler,
+		},
+		{
+			MethodName: "QueryOrder",
+			Handler:    _MatchService_QueryOrder_Handler,
+		},
+		{
+			MethodName: "AmendOrder",
+			Handler:    _MatchService_AmendOrder_Handler,
+		},
+		{
+			MethodName: "MassCancel",
+			Handler:    _MatchService_MassCancel_Handler,
+		},
+		{
+			MethodName: "GetExchangeInfo",
+			Handler:    _MatchService_GetExchangeInfo_Handler,
+		},
+		{
+			MethodName: "GetReplicationStatus",
+			Handler:    _MatchService_GetReplicationStatus_Handler,
+		},
+		{
+			MethodName: "Promote",
+			Handler:    _MatchService_Promote_Handler,
+		},
+		{
+			MethodName: "ManageSymbol",
+			Handler:    _MatchService_ManageSymbol_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Replicate",
+			Handler:       _MatchService_Replicate_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeMarketData",
+			Handler:       _MatchService_SubscribeMarketData_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeOrderFeed",
+			Handler:       _MatchService_SubscribeOrderFeed_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "matching.proto",
 }
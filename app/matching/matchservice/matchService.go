@@ -14,16 +14,56 @@ import (
 )
 
 type (
-	MatchResult       = match.MatchResult
-	Order             = match.Order
-	OrderBookRequest  = match.OrderBookRequest
-	OrderBookSnapshot = match.OrderBookSnapshot
-	PriceLevel        = match.PriceLevel
-	Trade             = match.Trade
+	AmendOrderRequest         = match.AmendOrderRequest
+	AmendOrderResponse        = match.AmendOrderResponse
+	AuctionQuote              = match.AuctionQuote
+	CancelOrderRequest        = match.CancelOrderRequest
+	CancelOrderResponse       = match.CancelOrderResponse
+	DepthUpdate               = match.DepthUpdate
+	ExchangeInfoRequest       = match.ExchangeInfoRequest
+	ExchangeInfoResponse      = match.ExchangeInfoResponse
+	FollowerStatus            = match.FollowerStatus
+	InstrumentInfo            = match.InstrumentInfo
+	ManageSymbolRequest       = match.ManageSymbolRequest
+	ManageSymbolResponse      = match.ManageSymbolResponse
+	MarketDataEvent           = match.MarketDataEvent
+	MassCancelRequest         = match.MassCancelRequest
+	MassCancelResponse        = match.MassCancelResponse
+	MarketDataRequest         = match.MarketDataRequest
+	MatchResult               = match.MatchResult
+	Order                     = match.Order
+	OrderBookRequest          = match.OrderBookRequest
+	OrderBookSnapshot         = match.OrderBookSnapshot
+	OrderFeedEntry            = match.OrderFeedEntry
+	OrderFeedEvent            = match.OrderFeedEvent
+	OrderFeedRequest          = match.OrderFeedRequest
+	PhaseChange               = match.PhaseChange
+	PriceLevel                = match.PriceLevel
+	PromoteRequest            = match.PromoteRequest
+	QueryOrderRequest         = match.QueryOrderRequest
+	QueryOrderResponse        = match.QueryOrderResponse
+	ReplicationAck            = match.ReplicationAck
+	ReplicationEntry          = match.ReplicationEntry
+	ReplicationStatusRequest  = match.ReplicationStatusRequest
+	ReplicationStatusResponse = match.ReplicationStatusResponse
+	SelfTradeEvent            = match.SelfTradeEvent
+	SymbolInfo                = match.SymbolInfo
+	Trade                     = match.Trade
 
 	MatchService interface {
 		ProcessOrder(ctx context.Context, in *Order, opts ...grpc.CallOption) (*MatchResult, error)
 		GetOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (*OrderBookSnapshot, error)
+		CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+		QueryOrder(ctx context.Context, in *QueryOrderRequest, opts ...grpc.CallOption) (*QueryOrderResponse, error)
+		AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*AmendOrderResponse, error)
+		MassCancel(ctx context.Context, in *MassCancelRequest, opts ...grpc.CallOption) (*MassCancelResponse, error)
+		GetExchangeInfo(ctx context.Context, in *ExchangeInfoRequest, opts ...grpc.CallOption) (*ExchangeInfoResponse, error)
+		Replicate(ctx context.Context, opts ...grpc.CallOption) (match.MatchService_ReplicateClient, error)
+		GetReplicationStatus(ctx context.Context, in *ReplicationStatusRequest, opts ...grpc.CallOption) (*ReplicationStatusResponse, error)
+		Promote(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*ReplicationStatusResponse, error)
+		ManageSymbol(ctx context.Context, in *ManageSymbolRequest, opts ...grpc.CallOption) (*ManageSymbolResponse, error)
+		SubscribeMarketData(ctx context.Context, in *MarketDataRequest, opts ...grpc.CallOption) (match.MatchService_SubscribeMarketDataClient, error)
+		SubscribeOrderFeed(ctx context.Context, in *OrderFeedRequest, opts ...grpc.CallOption) (match.MatchService_SubscribeOrderFeedClient, error)
 	}
 
 	defaultMatchService struct {
@@ -46,3 +86,58 @@ func (m *defaultMatchService) GetOrderBook(ctx context.Context, in *OrderBookReq
 	client := match.NewMatchServiceClient(m.cli.Conn())
 	return client.GetOrderBook(ctx, in, opts...)
 }
+
+func (m *defaultMatchService) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
+	client := match.NewMatchServiceClient(m.cli.Conn())
+	return client.CancelOrder(ctx, in, opts...)
+}
+
+func (m *defaultMatchService) QueryOrder(ctx context.Context, in *QueryOrderRequest, opts ...grpc.CallOption) (*QueryOrderResponse, error) {
+	client := match.NewMatchServiceClient(m.cli.Conn())
+	return client.QueryOrder(ctx, in, opts...)
+}
+
+func (m *defaultMatchService) AmendOrder(ctx context.Context, in *AmendOrderRequest, opts ...grpc.CallOption) (*AmendOrderResponse, error) {
+	client := match.NewMatchServiceClient(m.cli.Conn())
+	return client.AmendOrder(ctx, in, opts...)
+}
+
+func (m *defaultMatchService) MassCancel(ctx context.Context, in *MassCancelRequest, opts ...grpc.CallOption) (*MassCancelResponse, error) {
+	client := match.NewMatchServiceClient(m.cli.Conn())
+	return client.MassCancel(ctx, in, opts...)
+}
+
+func (m *defaultMatchService) GetExchangeInfo(ctx context.Context, in *ExchangeInfoRequest, opts ...grpc.CallOption) (*ExchangeInfoResponse, error) {
+	client := match.NewMatchServiceClient(m.cli.Conn())
+	return client.GetExchangeInfo(ctx, in, opts...)
+}
+
+func (m *defaultMatchService) Replicate(ctx context.Context, opts ...grpc.CallOption) (match.MatchService_ReplicateClient, error) {
+	client := match.NewMatchServiceClient(m.cli.Conn())
+	return client.Replicate(ctx, opts...)
+}
+
+func (m *defaultMatchService) GetReplicationStatus(ctx context.Context, in *ReplicationStatusRequest, opts ...grpc.CallOption) (*ReplicationStatusResponse, error) {
+	client := match.NewMatchServiceClient(m.cli.Conn())
+	return client.GetReplicationStatus(ctx, in, opts...)
+}
+
+func (m *defaultMatchService) Promote(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*ReplicationStatusResponse, error) {
+	client := match.NewMatchServiceClient(m.cli.Conn())
+	return client.Promote(ctx, in, opts...)
+}
+
+func (m *defaultMatchService) ManageSymbol(ctx context.Context, in *ManageSymbolRequest, opts ...grpc.CallOption) (*ManageSymbolResponse, error) {
+	client := match.NewMatchServiceClient(m.cli.Conn())
+	return client.ManageSymbol(ctx, in, opts...)
+}
+
+func (m *defaultMatchService) SubscribeMarketData(ctx context.Context, in *MarketDataRequest, opts ...grpc.CallOption) (match.MatchService_SubscribeMarketDataClient, error) {
+	client := match.NewMatchServiceClient(m.cli.Conn())
+	return client.SubscribeMarketData(ctx, in, opts...)
+}
+
+func (m *defaultMatchService) SubscribeOrderFeed(ctx context.Context, in *OrderFeedRequest, opts ...grpc.CallOption) (match.MatchService_SubscribeOrderFeedClient, error) {
+	client := match.NewMatchServiceClient(m.cli.Conn())
+	return client.SubscribeOrderFeed(ctx, in, opts...)
+}
@@ -1,11 +1,16 @@
 package config
 
-import "github.com/zeromicro/go-zero/zrpc"
+import (
+	"time"
+
+	"github.com/zeromicro/go-zero/zrpc"
+)
 
 type Config struct {
 	zrpc.RpcServerConf
 	RedisConf RedisConfig
 	Matching  zrpc.RpcClientConf
+	Session   SessionConfig
 }
 
 type RedisConfig struct {
@@ -13,3 +18,9 @@ type RedisConfig struct {
 	Password string `json:",optional"`
 	DB       int    `json:",default=0"`
 }
+
+// SessionConfig 交易会话配置
+type SessionConfig struct {
+	HeartbeatTimeout time.Duration `json:",default=30s"` // 超过该时间未收到心跳视为连接断开
+	CheckInterval    time.Duration `json:",default=1s"`  // 检查心跳超时及死人开关的间隔
+}
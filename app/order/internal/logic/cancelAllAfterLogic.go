@@ -0,0 +1,41 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tsfdsong/tradeengin/app/order/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/order/order"
+	"github.com/tsfdsong/tradeengin/app/pkg/xerr"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CancelAllAfterLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCancelAllAfterLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelAllAfterLogic {
+	return &CancelAllAfterLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// CancelAllAfter 启用或关闭死人开关
+func (l *CancelAllAfterLogic) CancelAllAfter(in *order.CancelAllAfterRequest) (*order.SessionResponse, error) {
+	if in.Timeout < 0 {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.REUQEST_PARAM_ERROR), "cancel all after negative timeout: %+v", in)
+	}
+
+	s, err := l.svcCtx.Sessions.CancelAllAfter(in.SessionId, in.Account, time.Duration(in.Timeout)*time.Millisecond)
+	if err != nil {
+		return nil, errors.Wrapf(toSessionErr(err), "cancel all after: %+v, err: %v", in, err)
+	}
+
+	return toSessionResponse(s), nil
+}
@@ -0,0 +1,35 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/tsfdsong/tradeengin/app/order/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/order/order"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CloseSessionLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCloseSessionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CloseSessionLogic {
+	return &CloseSessionLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// CloseSession 主动断开会话，开启撤单保护时撤销账户全部挂单
+func (l *CloseSessionLogic) CloseSession(in *order.SessionRequest) (*order.SessionResponse, error) {
+	s, err := l.svcCtx.Sessions.Close(in.SessionId, in.Account)
+	if err != nil {
+		return nil, errors.Wrapf(toSessionErr(err), "close session: %+v, err: %v", in, err)
+	}
+
+	return toSessionResponse(s), nil
+}
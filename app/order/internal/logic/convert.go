@@ -0,0 +1,33 @@
+package logic
+
+import (
+	"errors"
+
+	"github.com/tsfdsong/tradeengin/app/order/internal/session"
+	"github.com/tsfdsong/tradeengin/app/order/order"
+	"github.com/tsfdsong/tradeengin/app/pkg/xerr"
+)
+
+// toSessionResponse 时间均转换为毫秒
+func toSessionResponse(s session.Session) *order.SessionResponse {
+	resp := &order.SessionResponse{
+		SessionId:  s.ID,
+		ExpireTime: s.ExpireAt.UnixMilli(),
+	}
+	if !s.CancelAt.IsZero() {
+		resp.CancelTime = s.CancelAt.UnixMilli()
+	}
+	return resp
+}
+
+// toSessionErr 会话错误转换为业务错误码
+func toSessionErr(err error) error {
+	switch {
+	case errors.Is(err, session.ErrSessionNotFound):
+		return xerr.NewErrCode(xerr.ORDER_SESSION_NOT_FOUND)
+	case errors.Is(err, session.ErrCancelDisabled):
+		return xerr.NewErrCode(xerr.ORDER_SESSION_CANCEL_DISABLED)
+	default:
+		return xerr.NewErrCode(xerr.SERVER_COMMON_ERROR)
+	}
+}
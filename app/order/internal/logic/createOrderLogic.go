@@ -13,6 +13,7 @@ import (
 	"github.com/tsfdsong/tradeengin/app/pkg/xerr"
 
 	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -49,43 +50,92 @@ func (l *CreateOrderLogic) CreateOrder(in *order.OrderRequest) (*order.OrderResp
 
 	// 调用 Matching 服务进行撮合
 	matchResp, err := l.svcCtx.MatchRpc.ProcessOrder(l.ctx, &matchservice.Order{
-		Id:        in.Order.Id,
-		Symbol:    in.Order.Symbol,
-		Price:     in.Order.Price,
-		Quantity:  in.Order.Quantity,
-		Side:      in.Order.Side,
-		Type:      in.Order.Type,
-		ClientId:  in.Order.ClientId,
-		Timestamp: in.Order.Timestamp,
+		Id:          in.Order.Id,
+		Symbol:      in.Order.Symbol,
+		Price:       in.Order.Price,
+		Quantity:    in.Order.Quantity,
+		Side:        in.Order.Side,
+		Type:        in.Order.Type,
+		ClientId:    in.Order.ClientId,
+		Timestamp:   in.Order.Timestamp,
+		TimeInForce: in.Order.TimeInForce,
+		ExpireTime:  in.Order.ExpireTime,
+		PostOnly:    in.Order.PostOnly,
+		StopPrice:   in.Order.StopPrice,
+		DisplayQty:  in.Order.DisplayQty,
+		StpMode:     in.Order.StpMode,
+		QuoteQty:    in.Order.QuoteQty,
+		Async:       in.Order.Async,
+		Account:     in.Order.Account,
 	})
 	if err != nil {
+		// 等待撮合结果或备节点确认超时时订单已进入撮合队列，返回订单号供调用方查询
+		if st, ok := status.FromError(err); ok && isMatchPendingCode(uint32(st.Code())) {
+			l.Infof("match result timeout, order %d accepted as pending", orderID)
+			return &orderservice.OrderResponse{
+				OrderId:   orderID,
+				Status:    StatusPending,
+				Timestamp: time.Now().UnixMilli(),
+			}, nil
+		}
+
+		// 撮合服务返回的参数错误及撮合模块业务错误码直接透传给调用方
+		if st, ok := status.FromError(err); ok && isMatchRejectCode(uint32(st.Code())) {
+			return nil, errors.Wrapf(xerr.NewErrCode(uint32(st.Code())), "match server rejected order: %+v", in)
+		}
 		return nil, errors.Wrapf(xerr.NewErrMsg("server internal error"), "match server process order failed: %+v, err: %v", in, err)
 	}
 
-	// 根据撮合结果确定订单状态
-	status := determineOrderStatus(matchResp)
+	fills := make([]*orderservice.Fill, 0, len(matchResp.Trades))
+	for _, trade := range matchResp.Trades {
+		fills = append(fills, &orderservice.Fill{
+			TradeId:   trade.TradeId,
+			Price:     trade.Price,
+			Quantity:  trade.Quantity,
+			Timestamp: trade.Timestamp,
+		})
+	}
 
 	return &orderservice.OrderResponse{
-		OrderId:   orderID,
-		Status:    int32(status),
-		Timestamp: time.Now().UnixMilli(),
+		OrderId:        orderID,
+		Status:         determineOrderStatus(matchResp),
+		Timestamp:      time.Now().UnixMilli(),
+		FilledQuantity: matchResp.FilledQuantity,
+		Fills:          fills,
 	}, nil
 }
 
-func determineOrderStatus(matchResult *matchservice.MatchResult) int32 {
-	if len(matchResult.Trades) == 0 {
-		return StatusPending
-	}
+// isMatchPendingCode 订单已被撮合服务接收但未等到最终结果的错误码
+func isMatchPendingCode(code uint32) bool {
+	return code == xerr.ORDER_MATCH_TIMEOUT || code == xerr.ORDER_REPLICATION_TIMEOUT
+}
 
-	// 检查是否完全成交
-	remainingQty := matchResult.Order.Quantity
-	for _, trade := range matchResult.Trades {
-		remainingQty -= trade.Quantity
-	}
+// isMatchRejectCode 是否为撮合服务拒绝订单的业务错误码(撮合模块错误码以300开头)
+func isMatchRejectCode(code uint32) bool {
+	return code == xerr.REUQEST_PARAM_ERROR || code/1000 == 300
+}
 
-	if remainingQty == 0 {
-		return StatusFilled
-	} else {
+// 撮合服务返回的订单状态
+const (
+	matchStatusPending   int32 = 0
+	matchStatusPartial   int32 = 1
+	matchStatusFilled    int32 = 2
+	matchStatusCancelled int32 = 3
+)
+
+// determineOrderStatus 根据撮合结果确定订单状态，有部分成交后被撤销的订单仍视为部分成交
+func determineOrderStatus(matchResult *matchservice.MatchResult) int32 {
+	switch matchResult.Status {
+	case matchStatusPartial:
 		return StatusPartial
+	case matchStatusFilled:
+		return StatusFilled
+	case matchStatusCancelled:
+		if len(matchResult.Trades) > 0 {
+			return StatusPartial
+		}
+		return StatusRejected
+	default:
+		return StatusPending
 	}
 }
@@ -0,0 +1,35 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/tsfdsong/tradeengin/app/order/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/order/order"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type HeartbeatLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewHeartbeatLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HeartbeatLogic {
+	return &HeartbeatLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// Heartbeat 刷新会话心跳及死人开关
+func (l *HeartbeatLogic) Heartbeat(in *order.SessionRequest) (*order.SessionResponse, error) {
+	s, err := l.svcCtx.Sessions.Heartbeat(in.SessionId, in.Account)
+	if err != nil {
+		return nil, errors.Wrapf(toSessionErr(err), "heartbeat: %+v, err: %v", in, err)
+	}
+
+	return toSessionResponse(s), nil
+}
@@ -0,0 +1,35 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/tsfdsong/tradeengin/app/order/internal/svc"
+	"github.com/tsfdsong/tradeengin/app/order/order"
+	"github.com/tsfdsong/tradeengin/app/pkg/xerr"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type OpenSessionLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewOpenSessionLogic(ctx context.Context, svcCtx *svc.ServiceContext) *OpenSessionLogic {
+	return &OpenSessionLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// OpenSession 开启交易会话，cancel_on_disconnect由网关按API Key配置填写
+func (l *OpenSessionLogic) OpenSession(in *order.SessionRequest) (*order.SessionResponse, error) {
+	if in.Account == "" {
+		return nil, errors.Wrapf(xerr.NewErrCode(xerr.REUQEST_PARAM_ERROR), "open session without account: %+v", in)
+	}
+
+	return toSessionResponse(l.svcCtx.Sessions.Open(in.Account, in.CancelOnDisconnect)), nil
+}
@@ -32,3 +32,23 @@ func (s *OrderServiceServer) CreateBatchOrder(ctx context.Context, in *order.Bat
 	l := logic.NewCreateBatchOrderLogic(ctx, s.svcCtx)
 	return l.CreateBatchOrder(in)
 }
+
+func (s *OrderServiceServer) OpenSession(ctx context.Context, in *order.SessionRequest) (*order.SessionResponse, error) {
+	l := logic.NewOpenSessionLogic(ctx, s.svcCtx)
+	return l.OpenSession(in)
+}
+
+func (s *OrderServiceServer) Heartbeat(ctx context.Context, in *order.SessionRequest) (*order.SessionResponse, error) {
+	l := logic.NewHeartbeatLogic(ctx, s.svcCtx)
+	return l.Heartbeat(in)
+}
+
+func (s *OrderServiceServer) CloseSession(ctx context.Context, in *order.SessionRequest) (*order.SessionResponse, error) {
+	l := logic.NewCloseSessionLogic(ctx, s.svcCtx)
+	return l.CloseSession(in)
+}
+
+func (s *OrderServiceServer) CancelAllAfter(ctx context.Context, in *order.CancelAllAfterRequest) (*order.SessionResponse, error) {
+	l := logic.NewCancelAllAfterLogic(ctx, s.svcCtx)
+	return l.CancelAllAfter(in)
+}
@@ -0,0 +1,238 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrCancelDisabled  = errors.New("cancel on disconnect not enabled")
+)
+
+// Canceller 撤销账户在全部交易对上的挂单，任一交易对撤单失败都需返回错误，账户在下次检查时整体重试
+type Canceller func(ctx context.Context, account string) error
+
+// Session 交易会话，心跳超时视为连接断开
+type Session struct {
+	ID                 string
+	Account            string
+	CancelOnDisconnect bool          // 会话断开(主动关闭或心跳超时)时撤销账户全部挂单
+	ExpireAt           time.Time     // 未收到心跳时会话断开的时间
+	CancelAfter        time.Duration // 死人开关超时时间，0表示未启用
+	CancelAt           time.Time     // 死人开关触发撤单的时间，每次心跳顺延
+}
+
+// Manager 交易会话管理，定期检查心跳超时的会话及到期的死人开关
+// 撤单按账户进行，同一账户的任一会话触发时撤销该账户的全部挂单
+type Manager struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+	pending  map[string]struct{} // 撤单失败待重试的账户
+
+	timeout       time.Duration
+	interval      time.Duration
+	cancelTimeout time.Duration
+	cancel        Canceller
+	now           func() time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewManager timeout为心跳超时时间，interval为检查间隔
+func NewManager(timeout, interval time.Duration, cancel Canceller) *Manager {
+	return &Manager{
+		sessions:      make(map[string]*Session),
+		pending:       make(map[string]struct{}),
+		timeout:       timeout,
+		interval:      interval,
+		cancelTimeout: 5 * time.Second,
+		cancel:        cancel,
+		now:           time.Now,
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+// Start 启动后台检查
+func (m *Manager) Start() {
+	go m.run()
+}
+
+// Stop 停止后台检查，已开启的会话不再触发撤单
+func (m *Manager) Stop() {
+	close(m.stop)
+	<-m.done
+}
+
+func (m *Manager) run() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.sweep()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// Open 为账户开启新的会话
+func (m *Manager) Open(account string, cancelOnDisconnect bool) Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := &Session{
+		ID:                 newSessionID(),
+		Account:            account,
+		CancelOnDisconnect: cancelOnDisconnect,
+		ExpireAt:           m.now().Add(m.timeout),
+	}
+	m.sessions[s.ID] = s
+
+	logx.Infof("Session %s opened, account: %s, cancel on disconnect: %v", s.ID, account, cancelOnDisconnect)
+	return *s
+}
+
+// Heartbeat 刷新会话心跳，已启用的死人开关同时顺延
+func (m *Manager) Heartbeat(id, account string) (Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, err := m.lookup(id, account)
+	if err != nil {
+		return Session{}, err
+	}
+	now := m.now()
+	s.ExpireAt = now.Add(m.timeout)
+	if s.CancelAfter > 0 {
+		s.CancelAt = now.Add(s.CancelAfter)
+	}
+	return *s, nil
+}
+
+// CancelAllAfter 启用死人开关: timeout内未收到心跳则撤销账户全部挂单，timeout为0时关闭
+// 只有开启撤单保护的会话可以使用
+func (m *Manager) CancelAllAfter(id, account string, timeout time.Duration) (Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, err := m.lookup(id, account)
+	if err != nil {
+		return Session{}, err
+	}
+	if !s.CancelOnDisconnect {
+		return Session{}, ErrCancelDisabled
+	}
+
+	now := m.now()
+	s.ExpireAt = now.Add(m.timeout)
+	s.CancelAfter = timeout
+	s.CancelAt = time.Time{}
+	if timeout > 0 {
+		s.CancelAt = now.Add(timeout)
+	}
+	return *s, nil
+}
+
+// Close 主动断开会话，开启撤单保护时立即撤销账户全部挂单
+func (m *Manager) Close(id, account string) (Session, error) {
+	m.mu.Lock()
+	s, err := m.lookup(id, account)
+	if err != nil {
+		m.mu.Unlock()
+		return Session{}, err
+	}
+	delete(m.sessions, id)
+	m.mu.Unlock()
+
+	logx.Infof("Session %s closed, account: %s", id, account)
+	if s.CancelOnDisconnect {
+		m.cancelAccounts([]string{s.Account})
+	}
+	return *s, nil
+}
+
+// lookup 查找会话并校验所属账户(调用方需持有锁)
+func (m *Manager) lookup(id, account string) (*Session, error) {
+	s, ok := m.sessions[id]
+	if !ok || s.Account != account {
+		return nil, ErrSessionNotFound
+	}
+	return s, nil
+}
+
+// sweep 移除心跳超时的会话，撤销其中开启撤单保护的账户及死人开关到期账户的挂单
+func (m *Manager) sweep() {
+	now := m.now()
+
+	m.mu.Lock()
+	accounts := make(map[string]struct{}, len(m.pending))
+	for account := range m.pending {
+		accounts[account] = struct{}{}
+	}
+	for id, s := range m.sessions {
+		switch {
+		case !now.Before(s.ExpireAt):
+			delete(m.sessions, id)
+			logx.Infof("Session %s disconnected on heartbeat timeout, account: %s", id, s.Account)
+			if s.CancelOnDisconnect {
+				accounts[s.Account] = struct{}{}
+			}
+		case s.CancelAfter > 0 && !now.Before(s.CancelAt):
+			// 死人开关触发一次后关闭，会话保持
+			s.CancelAfter = 0
+			s.CancelAt = time.Time{}
+			logx.Infof("Session %s cancel-all timer lapsed, account: %s", id, s.Account)
+			accounts[s.Account] = struct{}{}
+		}
+	}
+	m.mu.Unlock()
+
+	if len(accounts) == 0 {
+		return
+	}
+	list := make([]string, 0, len(accounts))
+	for account := range accounts {
+		list = append(list, account)
+	}
+	m.cancelAccounts(list)
+}
+
+// cancelAccounts 撤销账户挂单，失败的账户在下次检查时重试
+func (m *Manager) cancelAccounts(accounts []string) {
+	for _, account := range accounts {
+		ctx, cancel := context.WithTimeout(context.Background(), m.cancelTimeout)
+		err := m.cancel(ctx, account)
+		cancel()
+
+		m.mu.Lock()
+		if err != nil {
+			m.pending[account] = struct{}{}
+		} else {
+			delete(m.pending, account)
+		}
+		m.mu.Unlock()
+
+		if err != nil {
+			logx.Errorf("Cancel orders of account %s failed, will retry: %v", account, err)
+		}
+	}
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,143 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newTestManager 使用可控时钟的会话管理器，返回撤单记录
+func newTestManager(fail *bool) (*Manager, *time.Time, *[]string) {
+	now := time.Unix(1700000000, 0)
+	var cancelled []string
+	m := NewManager(10*time.Second, time.Second, func(ctx context.Context, account string) error {
+		if fail != nil && *fail {
+			return errors.New("match server unavailable")
+		}
+		cancelled = append(cancelled, account)
+		return nil
+	})
+	m.now = func() time.Time { return now }
+	return m, &now, &cancelled
+}
+
+func TestManager_CancelOnDisconnect(t *testing.T) {
+	m, now, cancelled := newTestManager(nil)
+
+	protected := m.Open("mm-1", true)
+	plain := m.Open("mm-2", false)
+
+	// 心跳顺延超时时间
+	*now = now.Add(8 * time.Second)
+	if _, err := m.Heartbeat(protected.ID, "mm-1"); err != nil {
+		t.Fatalf("heartbeat failed: %v", err)
+	}
+	if _, err := m.Heartbeat(protected.ID, "mm-2"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("heartbeat with other account: got %v, want ErrSessionNotFound", err)
+	}
+
+	*now = now.Add(5 * time.Second)
+	m.sweep()
+	if len(*cancelled) != 0 {
+		t.Fatalf("cancelled before timeout: %v", *cancelled)
+	}
+	if _, err := m.Heartbeat(plain.ID, "mm-2"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expired session: got %v, want ErrSessionNotFound", err)
+	}
+
+	// 心跳超时断开，只撤销开启撤单保护的账户
+	*now = now.Add(10 * time.Second)
+	m.sweep()
+	if !slices.Equal(*cancelled, []string{"mm-1"}) {
+		t.Fatalf("cancelled accounts: got %v, want [mm-1]", *cancelled)
+	}
+
+	// 主动断开立即撤单
+	s := m.Open("mm-3", true)
+	if _, err := m.Close(s.ID, "mm-3"); err != nil {
+		t.Fatalf("close session failed: %v", err)
+	}
+	if !slices.Equal(*cancelled, []string{"mm-1", "mm-3"}) {
+		t.Fatalf("cancelled accounts: got %v, want [mm-1 mm-3]", *cancelled)
+	}
+}
+
+func TestManager_CancelAllAfter(t *testing.T) {
+	fail := false
+	m, now, cancelled := newTestManager(&fail)
+
+	plain := m.Open("mm-1", false)
+	if _, err := m.CancelAllAfter(plain.ID, "mm-1", time.Second); !errors.Is(err, ErrCancelDisabled) {
+		t.Fatalf("cancel all after without opt-in: got %v, want ErrCancelDisabled", err)
+	}
+
+	s := m.Open("mm-2", true)
+	armed, err := m.CancelAllAfter(s.ID, "mm-2", 3*time.Second)
+	if err != nil {
+		t.Fatalf("cancel all after failed: %v", err)
+	}
+	if want := now.Add(3 * time.Second); !armed.CancelAt.Equal(want) {
+		t.Fatalf("cancel at: got %v, want %v", armed.CancelAt, want)
+	}
+
+	// 心跳顺延死人开关
+	*now = now.Add(2 * time.Second)
+	if _, err := m.Heartbeat(s.ID, "mm-2"); err != nil {
+		t.Fatalf("heartbeat failed: %v", err)
+	}
+	*now = now.Add(2 * time.Second)
+	m.sweep()
+	if len(*cancelled) != 0 {
+		t.Fatalf("cancelled before timer lapsed: %v", *cancelled)
+	}
+
+	// 到期撤单失败时下次检查重试，死人开关只触发一次
+	fail = true
+	*now = now.Add(time.Second)
+	m.sweep()
+	fail = false
+	m.sweep()
+	m.sweep()
+	if !slices.Equal(*cancelled, []string{"mm-2"}) {
+		t.Fatalf("cancelled accounts: got %v, want [mm-2]", *cancelled)
+	}
+
+	// 会话保持，timeout为0关闭死人开关
+	disarmed, err := m.CancelAllAfter(s.ID, "mm-2", 0)
+	if err != nil {
+		t.Fatalf("disarm failed: %v", err)
+	}
+	if disarmed.CancelAfter != 0 || !disarmed.CancelAt.IsZero() {
+		t.Fatalf("disarmed session still armed: %+v", disarmed)
+	}
+}
+
+func TestManager_PartialCancelRetried(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	failed := []string{"ETHUSDT"} // 撤单失败的交易对
+	var attempts []string
+	m := NewManager(10*time.Second, time.Second, func(ctx context.Context, account string) error {
+		attempts = append(attempts, account)
+		if len(failed) > 0 {
+			return fmt.Errorf("cancel failed on symbols: %v", failed)
+		}
+		return nil
+	})
+	m.now = func() time.Time { return now }
+
+	m.Open("mm-1", true)
+	now = now.Add(11 * time.Second)
+	m.sweep()
+
+	// 部分交易对失败时账户保留在待重试集合，直到全部成功
+	m.sweep()
+	failed = nil
+	m.sweep()
+	m.sweep()
+	if !slices.Equal(attempts, []string{"mm-1", "mm-1", "mm-1"}) {
+		t.Fatalf("cancel attempts: got %v, want 3 attempts for mm-1", attempts)
+	}
+}
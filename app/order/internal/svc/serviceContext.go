@@ -1,19 +1,41 @@
 package svc
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/tsfdsong/tradeengin/app/matching/matchservice"
 	"github.com/tsfdsong/tradeengin/app/order/internal/config"
+	"github.com/tsfdsong/tradeengin/app/order/internal/session"
+	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/zrpc"
 )
 
 type ServiceContext struct {
 	Config   config.Config
 	MatchRpc matchservice.MatchService
+	Sessions *session.Manager
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
-	return &ServiceContext{
+	svcCtx := &ServiceContext{
 		Config:   c,
 		MatchRpc: matchservice.NewMatchService(zrpc.MustNewClient(c.Matching)),
 	}
+	svcCtx.Sessions = session.NewManager(c.Session.HeartbeatTimeout, c.Session.CheckInterval, svcCtx.cancelAccount)
+	return svcCtx
+}
+
+// cancelAccount 会话断开或死人开关到期时在全部交易对上撤销账户挂单
+func (s *ServiceContext) cancelAccount(ctx context.Context, account string) error {
+	resp, err := s.MatchRpc.MassCancel(ctx, &matchservice.MassCancelRequest{Account: account})
+	if err != nil {
+		return err
+	}
+	logx.Infof("Cancelled %d orders of account %s", len(resp.OrderIds), account)
+	// 部分交易对撤单失败时返回错误，由会话管理器重试，避免挂单在恢复交易后重新生效
+	if len(resp.FailedSymbols) > 0 {
+		return fmt.Errorf("cancel orders of account %s failed on symbols: %v", account, resp.FailedSymbols)
+	}
+	return nil
 }
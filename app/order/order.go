@@ -28,6 +28,8 @@ func main() {
 	var c config.Config
 	conf.MustLoad(*configFile, &c)
 	ctx := svc.NewServiceContext(c)
+	ctx.Sessions.Start()
+	defer ctx.Sessions.Stop()
 
 	s := zrpc.MustNewServer(c.RpcServerConf, func(grpcServer *grpc.Server) {
 		order.RegisterOrderServiceServer(grpcServer, server.NewOrderServiceServer(ctx))
@@ -21,16 +21,26 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 价格、数量、金额均为十进制字符串，精度由交易对配置决定
 type Order struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
 	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price"`
-	Quantity      int64                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity"`
+	Price         string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price"`
+	Quantity      string                 `protobuf:"bytes,4,opt,name=quantity,proto3" json:"quantity"`
 	Side          int32                  `protobuf:"varint,5,opt,name=side,proto3" json:"side"`
 	Type          int32                  `protobuf:"varint,6,opt,name=type,proto3" json:"type"`
 	Timestamp     int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp"`
 	ClientId      string                 `protobuf:"bytes,8,opt,name=client_id,json=clientId,proto3" json:"client_id"`
+	TimeInForce   int32                  `protobuf:"varint,9,opt,name=time_in_force,json=timeInForce,proto3" json:"time_in_force"` // 1:GTC, 2:IOC, 3:FOK, 4:GTD, 0按GTC处理
+	ExpireTime    int64                  `protobuf:"varint,10,opt,name=expire_time,json=expireTime,proto3" json:"expire_time"`     // GTD订单过期时间(纳秒)
+	PostOnly      int32                  `protobuf:"varint,11,opt,name=post_only,json=postOnly,proto3" json:"post_only"`           // 只做Maker: 0:否, 1:会吃单时拒绝, 2:会吃单时调整价格
+	StopPrice     string                 `protobuf:"bytes,12,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price"`         // 止损触发价，止损市价/止损限价单必填
+	DisplayQty    string                 `protobuf:"bytes,13,opt,name=display_qty,json=displayQty,proto3" json:"display_qty"`      // 冰山单每次展示的数量，0表示普通订单
+	StpMode       int32                  `protobuf:"varint,14,opt,name=stp_mode,json=stpMode,proto3" json:"stp_mode"`              // 自成交预防: 0:交易对默认, 1:撤新, 2:撤旧, 3:双撤, 4:减量撤销
+	QuoteQty      string                 `protobuf:"bytes,15,opt,name=quote_qty,json=quoteQty,proto3" json:"quote_qty"`            // 按计价货币金额下单的市价单金额，此时quantity为0，不支持FOK
+	Async         bool                   `protobuf:"varint,16,opt,name=async,proto3" json:"async"`                                 // 异步下单: 入队即返回，不等待撮合结果
+	Account       string                 `protobuf:"bytes,17,opt,name=account,proto3" json:"account"`                              // 下单账户，可按账户批量撤单
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -79,18 +89,18 @@ func (x *Order) GetSymbol() string {
 	return ""
 }
 
-func (x *Order) GetPrice() float64 {
+func (x *Order) GetPrice() string {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return ""
 }
 
-func (x *Order) GetQuantity() int64 {
+func (x *Order) GetQuantity() string {
 	if x != nil {
 		return x.Quantity
 	}
-	return 0
+	return ""
 }
 
 func (x *Order) GetSide() int32 {
@@ -121,6 +131,69 @@ func (x *Order) GetClientId() string {
 	return ""
 }
 
+func (x *Order) GetTimeInForce() int32 {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return 0
+}
+
+func (x *Order) GetExpireTime() int64 {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return 0
+}
+
+func (x *Order) GetPostOnly() int32 {
+	if x != nil {
+		return x.PostOnly
+	}
+	return 0
+}
+
+func (x *Order) GetStopPrice() string {
+	if x != nil {
+		return x.StopPrice
+	}
+	return ""
+}
+
+func (x *Order) GetDisplayQty() string {
+	if x != nil {
+		return x.DisplayQty
+	}
+	return ""
+}
+
+func (x *Order) GetStpMode() int32 {
+	if x != nil {
+		return x.StpMode
+	}
+	return 0
+}
+
+func (x *Order) GetQuoteQty() string {
+	if x != nil {
+		return x.QuoteQty
+	}
+	return ""
+}
+
+func (x *Order) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *Order) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
 type OrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order"`
@@ -165,18 +238,88 @@ func (x *OrderRequest) GetOrder() *Order {
 	return nil
 }
 
-type OrderResponse struct {
+type Fill struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id"`
-	Status        int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status"`
-	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp"`
+	TradeId       uint64                 `protobuf:"varint,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id"`
+	Price         string                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price"`
+	Quantity      string                 `protobuf:"bytes,3,opt,name=quantity,proto3" json:"quantity"`
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Fill) Reset() {
+	*x = Fill{}
+	mi := &file_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fill) ProtoMessage() {}
+
+func (x *Fill) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fill.ProtoReflect.Descriptor instead.
+func (*Fill) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Fill) GetTradeId() uint64 {
+	if x != nil {
+		return x.TradeId
+	}
+	return 0
+}
+
+func (x *Fill) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Fill) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *Fill) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type OrderResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderId        uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id"`
+	Status         int32                  `protobuf:"varint,2,opt,name=status,proto3" json:"status"` // 0:挂单中, 1:部分成交, 2:完全成交, 3:已拒绝/撤销
+	Timestamp      int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp"`
+	FilledQuantity string                 `protobuf:"bytes,4,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity"` // 撮合完成时的累计成交数量，异步下单为空
+	Fills          []*Fill                `protobuf:"bytes,5,rep,name=fills,proto3" json:"fills"`                                         // 本次下单的成交明细，异步下单为空
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
 func (x *OrderResponse) Reset() {
 	*x = OrderResponse{}
-	mi := &file_order_proto_msgTypes[2]
+	mi := &file_order_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -188,7 +331,7 @@ func (x *OrderResponse) String() string {
 func (*OrderResponse) ProtoMessage() {}
 
 func (x *OrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[2]
+	mi := &file_order_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -201,7 +344,7 @@ func (x *OrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrderResponse.ProtoReflect.Descriptor instead.
 func (*OrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{2}
+	return file_order_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *OrderResponse) GetOrderId() uint64 {
@@ -225,6 +368,20 @@ func (x *OrderResponse) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *OrderResponse) GetFilledQuantity() string {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return ""
+}
+
+func (x *OrderResponse) GetFills() []*Fill {
+	if x != nil {
+		return x.Fills
+	}
+	return nil
+}
+
 type BatchOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders"`
@@ -234,7 +391,7 @@ type BatchOrderRequest struct {
 
 func (x *BatchOrderRequest) Reset() {
 	*x = BatchOrderRequest{}
-	mi := &file_order_proto_msgTypes[3]
+	mi := &file_order_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -246,7 +403,7 @@ func (x *BatchOrderRequest) String() string {
 func (*BatchOrderRequest) ProtoMessage() {}
 
 func (x *BatchOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[3]
+	mi := &file_order_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -259,7 +416,7 @@ func (x *BatchOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchOrderRequest.ProtoReflect.Descriptor instead.
 func (*BatchOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{3}
+	return file_order_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *BatchOrderRequest) GetOrders() []*Order {
@@ -278,7 +435,7 @@ type BatchOrderResponse struct {
 
 func (x *BatchOrderResponse) Reset() {
 	*x = BatchOrderResponse{}
-	mi := &file_order_proto_msgTypes[4]
+	mi := &file_order_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -290,7 +447,7 @@ func (x *BatchOrderResponse) String() string {
 func (*BatchOrderResponse) ProtoMessage() {}
 
 func (x *BatchOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[4]
+	mi := &file_order_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -303,7 +460,7 @@ func (x *BatchOrderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchOrderResponse.ProtoReflect.Descriptor instead.
 func (*BatchOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{4}
+	return file_order_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *BatchOrderResponse) GetResults() []*OrderResponse {
@@ -313,33 +470,255 @@ func (x *BatchOrderResponse) GetResults() []*OrderResponse {
 	return nil
 }
 
+// 交易会话: 心跳超时视为连接断开，开启撤单保护的会话断开或死人开关到期时撤销账户全部挂单
+type SessionRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	SessionId          string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id"`                               // 开启会话时不填，由订单服务生成
+	Account            string                 `protobuf:"bytes,2,opt,name=account,proto3" json:"account"`                                                    // 会话所属账户
+	CancelOnDisconnect bool                   `protobuf:"varint,3,opt,name=cancel_on_disconnect,json=cancelOnDisconnect,proto3" json:"cancel_on_disconnect"` // 开启撤单保护，只在开启会话时生效
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SessionRequest) Reset() {
+	*x = SessionRequest{}
+	mi := &file_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRequest) ProtoMessage() {}
+
+func (x *SessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRequest.ProtoReflect.Descriptor instead.
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *SessionRequest) GetCancelOnDisconnect() bool {
+	if x != nil {
+		return x.CancelOnDisconnect
+	}
+	return false
+}
+
+type CancelAllAfterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id"`
+	Account       string                 `protobuf:"bytes,2,opt,name=account,proto3" json:"account"`
+	Timeout       int64                  `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout"` // 死人开关超时时间(毫秒)，期间未收到心跳则撤销账户全部挂单，0表示关闭
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAllAfterRequest) Reset() {
+	*x = CancelAllAfterRequest{}
+	mi := &file_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAllAfterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAllAfterRequest) ProtoMessage() {}
+
+func (x *CancelAllAfterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAllAfterRequest.ProtoReflect.Descriptor instead.
+func (*CancelAllAfterRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelAllAfterRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *CancelAllAfterRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *CancelAllAfterRequest) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+type SessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id"`
+	ExpireTime    int64                  `protobuf:"varint,2,opt,name=expire_time,json=expireTime,proto3" json:"expire_time"` // 未收到心跳时会话断开的时间(毫秒)
+	CancelTime    int64                  `protobuf:"varint,3,opt,name=cancel_time,json=cancelTime,proto3" json:"cancel_time"` // 死人开关触发撤单的时间(毫秒)，0表示未启用
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionResponse) Reset() {
+	*x = SessionResponse{}
+	mi := &file_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionResponse) ProtoMessage() {}
+
+func (x *SessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionResponse.ProtoReflect.Descriptor instead.
+func (*SessionResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SessionResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionResponse) GetExpireTime() int64 {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return 0
+}
+
+func (x *SessionResponse) GetCancelTime() int64 {
+	if x != nil {
+		return x.CancelTime
+	}
+	return 0
+}
+
 var File_order_proto protoreflect.FileDescriptor
 
 const file_order_proto_rawDesc = "" +
 	"\n" +
-	"\vorder.proto\x12\x05order\"\xc4\x01\n" +
+	"\vorder.proto\x12\x05order\"\xce\x03\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
 	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x03R\bquantity\x12\x12\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\tR\bquantity\x12\x12\n" +
 	"\x04side\x18\x05 \x01(\x05R\x04side\x12\x12\n" +
 	"\x04type\x18\x06 \x01(\x05R\x04type\x12\x1c\n" +
 	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\x12\x1b\n" +
-	"\tclient_id\x18\b \x01(\tR\bclientId\"2\n" +
+	"\tclient_id\x18\b \x01(\tR\bclientId\x12\"\n" +
+	"\rtime_in_force\x18\t \x01(\x05R\vtimeInForce\x12\x1f\n" +
+	"\vexpire_time\x18\n" +
+	" \x01(\x03R\n" +
+	"expireTime\x12\x1b\n" +
+	"\tpost_only\x18\v \x01(\x05R\bpostOnly\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\f \x01(\tR\tstopPrice\x12\x1f\n" +
+	"\vdisplay_qty\x18\r \x01(\tR\n" +
+	"displayQty\x12\x19\n" +
+	"\bstp_mode\x18\x0e \x01(\x05R\astpMode\x12\x1b\n" +
+	"\tquote_qty\x18\x0f \x01(\tR\bquoteQty\x12\x14\n" +
+	"\x05async\x18\x10 \x01(\bR\x05async\x12\x18\n" +
+	"\aaccount\x18\x11 \x01(\tR\aaccount\"2\n" +
 	"\fOrderRequest\x12\"\n" +
-	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"`\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"q\n" +
+	"\x04Fill\x12\x19\n" +
+	"\btrade_id\x18\x01 \x01(\x04R\atradeId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\tR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\tR\bquantity\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"\xac\x01\n" +
 	"\rOrderResponse\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\x05R\x06status\x12\x1c\n" +
-	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\"9\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12'\n" +
+	"\x0ffilled_quantity\x18\x04 \x01(\tR\x0efilledQuantity\x12!\n" +
+	"\x05fills\x18\x05 \x03(\v2\v.order.FillR\x05fills\"9\n" +
 	"\x11BatchOrderRequest\x12$\n" +
 	"\x06orders\x18\x01 \x03(\v2\f.order.OrderR\x06orders\"D\n" +
 	"\x12BatchOrderResponse\x12.\n" +
-	"\aresults\x18\x01 \x03(\v2\x14.order.OrderResponseR\aresults2\x91\x01\n" +
+	"\aresults\x18\x01 \x03(\v2\x14.order.OrderResponseR\aresults\"{\n" +
+	"\x0eSessionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x18\n" +
+	"\aaccount\x18\x02 \x01(\tR\aaccount\x120\n" +
+	"\x14cancel_on_disconnect\x18\x03 \x01(\bR\x12cancelOnDisconnect\"j\n" +
+	"\x15CancelAllAfterRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x18\n" +
+	"\aaccount\x18\x02 \x01(\tR\aaccount\x12\x18\n" +
+	"\atimeout\x18\x03 \x01(\x03R\atimeout\"r\n" +
+	"\x0fSessionResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1f\n" +
+	"\vexpire_time\x18\x02 \x01(\x03R\n" +
+	"expireTime\x12\x1f\n" +
+	"\vcancel_time\x18\x03 \x01(\x03R\n" +
+	"cancelTime2\x92\x03\n" +
 	"\fOrderService\x128\n" +
 	"\vCreateOrder\x12\x13.order.OrderRequest\x1a\x14.order.OrderResponse\x12G\n" +
-	"\x10CreateBatchOrder\x12\x18.order.BatchOrderRequest\x1a\x19.order.BatchOrderResponseB\tZ\a./orderb\x06proto3"
+	"\x10CreateBatchOrder\x12\x18.order.BatchOrderRequest\x1a\x19.order.BatchOrderResponse\x12<\n" +
+	"\vOpenSession\x12\x15.order.SessionRequest\x1a\x16.order.SessionResponse\x12:\n" +
+	"\tHeartbeat\x12\x15.order.SessionRequest\x1a\x16.order.SessionResponse\x12=\n" +
+	"\fCloseSession\x12\x15.order.SessionRequest\x1a\x16.order.SessionResponse\x12F\n" +
+	"\x0eCancelAllAfter\x12\x1c.order.CancelAllAfterRequest\x1a\x16.order.SessionResponseB\tZ\a./orderb\x06proto3"
 
 var (
 	file_order_proto_rawDescOnce sync.Once
@@ -353,27 +732,40 @@ func file_order_proto_rawDescGZIP() []byte {
 	return file_order_proto_rawDescData
 }
 
-var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_order_proto_goTypes = []any{
-	(*Order)(nil),              // 0: order.Order
-	(*OrderRequest)(nil),       // 1: order.OrderRequest
-	(*OrderResponse)(nil),      // 2: order.OrderResponse
-	(*BatchOrderRequest)(nil),  // 3: order.BatchOrderRequest
-	(*BatchOrderResponse)(nil), // 4: order.BatchOrderResponse
+	(*Order)(nil),                 // 0: order.Order
+	(*OrderRequest)(nil),          // 1: order.OrderRequest
+	(*Fill)(nil),                  // 2: order.Fill
+	(*OrderResponse)(nil),         // 3: order.OrderResponse
+	(*BatchOrderRequest)(nil),     // 4: order.BatchOrderRequest
+	(*BatchOrderResponse)(nil),    // 5: order.BatchOrderResponse
+	(*SessionRequest)(nil),        // 6: order.SessionRequest
+	(*CancelAllAfterRequest)(nil), // 7: order.CancelAllAfterRequest
+	(*SessionResponse)(nil),       // 8: order.SessionResponse
 }
 var file_order_proto_depIdxs = []int32{
-	0, // 0: order.OrderRequest.order:type_name -> order.Order
-	0, // 1: order.BatchOrderRequest.orders:type_name -> order.Order
-	2, // 2: order.BatchOrderResponse.results:type_name -> order.OrderResponse
-	1, // 3: order.OrderService.CreateOrder:input_type -> order.OrderRequest
-	3, // 4: order.OrderService.CreateBatchOrder:input_type -> order.BatchOrderRequest
-	2, // 5: order.OrderService.CreateOrder:output_type -> order.OrderResponse
-	4, // 6: order.OrderService.CreateBatchOrder:output_type -> order.BatchOrderResponse
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: order.OrderRequest.order:type_name -> order.Order
+	2,  // 1: order.OrderResponse.fills:type_name -> order.Fill
+	0,  // 2: order.BatchOrderRequest.orders:type_name -> order.Order
+	3,  // 3: order.BatchOrderResponse.results:type_name -> order.OrderResponse
+	1,  // 4: order.OrderService.CreateOrder:input_type -> order.OrderRequest
+	4,  // 5: order.OrderService.CreateBatchOrder:input_type -> order.BatchOrderRequest
+	6,  // 6: order.OrderService.OpenSession:input_type -> order.SessionRequest
+	6,  // 7: order.OrderService.Heartbeat:input_type -> order.SessionRequest
+	6,  // 8: order.OrderService.CloseSession:input_type -> order.SessionRequest
+	7,  // 9: order.OrderService.CancelAllAfter:input_type -> order.CancelAllAfterRequest
+	3,  // 10: order.OrderService.CreateOrder:output_type -> order.OrderResponse
+	5,  // 11: order.OrderService.CreateBatchOrder:output_type -> order.BatchOrderResponse
+	8,  // 12: order.OrderService.OpenSession:output_type -> order.SessionResponse
+	8,  // 13: order.OrderService.Heartbeat:output_type -> order.SessionResponse
+	8,  // 14: order.OrderService.CloseSession:output_type -> order.SessionResponse
+	8,  // 15: order.OrderService.CancelAllAfter:output_type -> order.SessionResponse
+	10, // [10:16] is the sub-list for method output_type
+	4,  // [4:10] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_order_proto_init() }
@@ -387,7 +779,7 @@ func file_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_proto_rawDesc), len(file_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,10 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	OrderService_CreateOrder_FullMethodName      = "/order.OrderService/CreateOrder"
 	OrderService_CreateBatchOrder_FullMethodName = "/order.OrderService/CreateBatchOrder"
+	OrderService_OpenSession_FullMethodName      = "/order.OrderService/OpenSession"
+	OrderService_Heartbeat_FullMethodName        = "/order.OrderService/Heartbeat"
+	OrderService_CloseSession_FullMethodName     = "/order.OrderService/CloseSession"
+	OrderService_CancelAllAfter_FullMethodName   = "/order.OrderService/CancelAllAfter"
 )
 
 // OrderServiceClient is the client API for OrderService service.
@@ -29,6 +33,10 @@ const (
 type OrderServiceClient interface {
 	CreateOrder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*OrderResponse, error)
 	CreateBatchOrder(ctx context.Context, in *BatchOrderRequest, opts ...grpc.CallOption) (*BatchOrderResponse, error)
+	OpenSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionResponse, error)
+	Heartbeat(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionResponse, error)
+	CloseSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionResponse, error)
+	CancelAllAfter(ctx context.Context, in *CancelAllAfterRequest, opts ...grpc.CallOption) (*SessionResponse, error)
 }
 
 type orderServiceClient struct {
@@ -59,12 +67,56 @@ func (c *orderServiceClient) CreateBatchOrder(ctx context.Context, in *BatchOrde
 	return out, nil
 }
 
+func (c *orderServiceClient) OpenSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionResponse)
+	err := c.cc.Invoke(ctx, OrderService_OpenSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) Heartbeat(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionResponse)
+	err := c.cc.Invoke(ctx, OrderService_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CloseSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionResponse)
+	err := c.cc.Invoke(ctx, OrderService_CloseSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CancelAllAfter(ctx context.Context, in *CancelAllAfterRequest, opts ...grpc.CallOption) (*SessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionResponse)
+	err := c.cc.Invoke(ctx, OrderService_CancelAllAfter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility.
 type OrderServiceServer interface {
 	CreateOrder(context.Context, *OrderRequest) (*OrderResponse, error)
 	CreateBatchOrder(context.Context, *BatchOrderRequest) (*BatchOrderResponse, error)
+	OpenSession(context.Context, *SessionRequest) (*SessionResponse, error)
+	Heartbeat(context.Context, *SessionRequest) (*SessionResponse, error)
+	CloseSession(context.Context, *SessionRequest) (*SessionResponse, error)
+	CancelAllAfter(context.Context, *CancelAllAfterRequest) (*SessionResponse, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -81,6 +133,18 @@ func (UnimplementedOrderServiceServer) CreateOrder(context.Context, *OrderReques
 func (UnimplementedOrderServiceServer) CreateBatchOrder(context.Context, *BatchOrderRequest) (*BatchOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateBatchOrder not implemented")
 }
+func (UnimplementedOrderServiceServer) OpenSession(context.Context, *SessionRequest) (*SessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenSession not implemented")
+}
+func (UnimplementedOrderServiceServer) Heartbeat(context.Context, *SessionRequest) (*SessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedOrderServiceServer) CloseSession(context.Context, *SessionRequest) (*SessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseSession not implemented")
+}
+func (UnimplementedOrderServiceServer) CancelAllAfter(context.Context, *CancelAllAfterRequest) (*SessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAllAfter not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 func (UnimplementedOrderServiceServer) testEmbeddedByValue()                      {}
 
@@ -138,6 +202,78 @@ func _OrderService_CreateBatchOrder_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_OpenSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).OpenSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_OpenSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).OpenSession(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).Heartbeat(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CloseSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CloseSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CloseSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CloseSession(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CancelAllAfter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelAllAfterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CancelAllAfter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CancelAllAfter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CancelAllAfter(ctx, req.(*CancelAllAfterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +289,22 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateBatchOrder",
 			Handler:    _OrderService_CreateBatchOrder_Handler,
 		},
+		{
+			MethodName: "OpenSession",
+			Handler:    _OrderService_OpenSession_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _OrderService_Heartbeat_Handler,
+		},
+		{
+			MethodName: "CloseSession",
+			Handler:    _OrderService_CloseSession_Handler,
+		},
+		{
+			MethodName: "CancelAllAfter",
+			Handler:    _OrderService_CancelAllAfter_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "order.proto",
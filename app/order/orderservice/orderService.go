@@ -14,15 +14,23 @@ import (
 )
 
 type (
-	BatchOrderRequest  = order.BatchOrderRequest
-	BatchOrderResponse = order.BatchOrderResponse
-	Order              = order.Order
-	OrderRequest       = order.OrderRequest
-	OrderResponse      = order.OrderResponse
+	BatchOrderRequest     = order.BatchOrderRequest
+	BatchOrderResponse    = order.BatchOrderResponse
+	CancelAllAfterRequest = order.CancelAllAfterRequest
+	Fill                  = order.Fill
+	Order                 = order.Order
+	OrderRequest          = order.OrderRequest
+	OrderResponse         = order.OrderResponse
+	SessionRequest        = order.SessionRequest
+	SessionResponse       = order.SessionResponse
 
 	OrderService interface {
 		CreateOrder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*OrderResponse, error)
 		CreateBatchOrder(ctx context.Context, in *BatchOrderRequest, opts ...grpc.CallOption) (*BatchOrderResponse, error)
+		OpenSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionResponse, error)
+		Heartbeat(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionResponse, error)
+		CloseSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionResponse, error)
+		CancelAllAfter(ctx context.Context, in *CancelAllAfterRequest, opts ...grpc.CallOption) (*SessionResponse, error)
 	}
 
 	defaultOrderService struct {
@@ -45,3 +53,23 @@ func (m *defaultOrderService) CreateBatchOrder(ctx context.Context, in *BatchOrd
 	client := order.NewOrderServiceClient(m.cli.Conn())
 	return client.CreateBatchOrder(ctx, in, opts...)
 }
+
+func (m *defaultOrderService) OpenSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionResponse, error) {
+	client := order.NewOrderServiceClient(m.cli.Conn())
+	return client.OpenSession(ctx, in, opts...)
+}
+
+func (m *defaultOrderService) Heartbeat(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionResponse, error) {
+	client := order.NewOrderServiceClient(m.cli.Conn())
+	return client.Heartbeat(ctx, in, opts...)
+}
+
+func (m *defaultOrderService) CloseSession(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionResponse, error) {
+	client := order.NewOrderServiceClient(m.cli.Conn())
+	return client.CloseSession(ctx, in, opts...)
+}
+
+func (m *defaultOrderService) CancelAllAfter(ctx context.Context, in *CancelAllAfterRequest, opts ...grpc.CallOption) (*SessionResponse, error) {
+	client := order.NewOrderServiceClient(m.cli.Conn())
+	return client.CancelAllAfter(ctx, in, opts...)
+}
@@ -0,0 +1,179 @@
+package fixed
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// MaxScale 支持的最大小数位数
+const MaxScale int32 = 18
+
+var (
+	ErrInvalidDecimal = errors.New("invalid decimal string")
+	ErrTooPrecise     = errors.New("decimal has more fractional digits than scale")
+	ErrOverflow       = errors.New("decimal overflows int64")
+	ErrInvalidScale   = errors.New("invalid decimal scale")
+)
+
+// pow10 10的n次方
+var pow10 = func() [MaxScale + 1]int64 {
+	var p [MaxScale + 1]int64
+	p[0] = 1
+	for i := 1; i <= int(MaxScale); i++ {
+		p[i] = p[i-1] * 10
+	}
+	return p
+}()
+
+// Pow10 返回10的scale次方
+func Pow10(scale int32) int64 {
+	return pow10[scale]
+}
+
+// Parse 将十进制字符串按scale位小数转换为整数最小单位，例如 Parse("1.23", 2) = 123
+// 空字符串视为0；小数位多于scale时只允许多出的部分为0
+func Parse(s string, scale int32) (int64, error) {
+	if scale < 0 || scale > MaxScale {
+		return 0, ErrInvalidScale
+	}
+	if s == "" {
+		return 0, nil
+	}
+
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasDot := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" || hasDot && strings.Contains(fracPart, ".") {
+		return 0, ErrInvalidDecimal
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, ErrInvalidDecimal
+	}
+
+	// 多出的小数位必须全为0
+	if int32(len(fracPart)) > scale {
+		if strings.Trim(fracPart[scale:], "0") != "" {
+			return 0, ErrTooPrecise
+		}
+		fracPart = fracPart[:scale]
+	}
+	fracPart += strings.Repeat("0", int(scale)-len(fracPart))
+
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	if digits == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, ErrOverflow
+	}
+	if neg {
+		v = -v
+	}
+	return v, nil
+}
+
+// Format 将整数最小单位按scale位小数格式化为十进制字符串，去掉末尾多余的0，例如 Format(12300, 4) = "1.23"
+func Format(v int64, scale int32) string {
+	if scale <= 0 || scale > MaxScale {
+		return strconv.FormatInt(v, 10)
+	}
+
+	neg := v < 0
+	var u uint64
+	if neg {
+		u = uint64(-(v + 1)) + 1 // 兼容math.MinInt64
+	} else {
+		u = uint64(v)
+	}
+
+	p := uint64(pow10[scale])
+	intPart := strconv.FormatUint(u/p, 10)
+	fracPart := strconv.FormatUint(u%p, 10)
+	fracPart = strings.Repeat("0", int(scale)-len(fracPart)) + fracPart
+	fracPart = strings.TrimRight(fracPart, "0")
+
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	b.WriteString(intPart)
+	if fracPart != "" {
+		b.WriteByte('.')
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
+
+// ScaleOf 十进制字符串的小数位数(忽略末尾的0)，格式非法时返回0
+func ScaleOf(s string) int32 {
+	_, frac, ok := strings.Cut(s, ".")
+	if !ok {
+		return 0
+	}
+	return int32(len(strings.TrimRight(frac, "0")))
+}
+
+// Compare 比较两个十进制字符串的大小，a<b返回-1，相等返回0，a>b返回1，空字符串视为0
+func Compare(a, b string) (int, error) {
+	scale := max(ScaleOf(a), ScaleOf(b))
+	if scale > MaxScale {
+		return 0, ErrTooPrecise
+	}
+	x, err := Parse(a, scale)
+	if err != nil {
+		return 0, err
+	}
+	y, err := Parse(b, scale)
+	if err != nil {
+		return 0, err
+	}
+	return cmp.Compare(x, y), nil
+}
+
+// Sign 十进制字符串的符号，负数返回-1，零或空字符串返回0，正数返回1
+func Sign(s string) (int, error) {
+	return Compare(s, "")
+}
+
+// Mul 计算a*b，任一参数为负或结果超出int64时ok为false
+func Mul(a, b int64) (int64, bool) {
+	if a < 0 || b < 0 {
+		return 0, false
+	}
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	if hi != 0 || lo > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(lo), true
+}
+
+// ToFloat 转换为浮点数，仅用于监控指标等不要求精确的场景
+func ToFloat(v int64, scale int32) float64 {
+	if scale <= 0 || scale > MaxScale {
+		return float64(v)
+	}
+	return float64(v) / math.Pow10(int(scale))
+}
+
+// isDigits 是否全部由数字组成
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package fixed
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		scale    int32
+		expected int64
+		err      error
+	}{
+		{"Integer", "100", 2, 10000, nil},
+		{"Fraction", "1.23", 2, 123, nil},
+		{"Short fraction", "0.5", 2, 50, nil},
+		{"Trailing zeros", "1.2300", 2, 123, nil},
+		{"Negative", "-0.01", 2, -1, nil},
+		{"Empty", "", 2, 0, nil},
+		{"No float error", "0.3", 1, 3, nil},
+		{"Too precise", "1.234", 2, 0, ErrTooPrecise},
+		{"Invalid", "1.2.3", 2, 0, ErrInvalidDecimal},
+		{"Letters", "abc", 2, 0, ErrInvalidDecimal},
+		{"Overflow", "92233720368547758.08", 2, 0, ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := Parse(tt.input, tt.scale)
+			if err != tt.err {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if v != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, v)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		value    int64
+		scale    int32
+		expected string
+	}{
+		{10000, 2, "100"},
+		{123, 2, "1.23"},
+		{50, 2, "0.5"},
+		{-1, 2, "-0.01"},
+		{42, 0, "42"},
+	}
+
+	for _, tt := range tests {
+		if got := Format(tt.value, tt.scale); got != tt.expected {
+			t.Errorf("Format(%d, %d): expected %s, got %s", tt.value, tt.scale, tt.expected, got)
+		}
+	}
+}
+
+func TestParseFormatRoundTrip(t *testing.T) {
+	// 0.1+0.2在定点数下精确等于0.3
+	a, _ := Parse("0.1", 8)
+	b, _ := Parse("0.2", 8)
+	c, _ := Parse("0.3", 8)
+	if a+b != c {
+		t.Errorf("Expected 0.1+0.2 == 0.3, got %s", Format(a+b, 8))
+	}
+	if ScaleOf("1.2500") != 2 || ScaleOf("7") != 0 {
+		t.Errorf("Unexpected ScaleOf result")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.5", "1.50", 0},
+		{"0.1", "0.09", 1},
+		{"2", "10", -1},
+		{"-0.5", "", -1},
+		{"", "0", 0},
+	}
+
+	for _, tt := range tests {
+		got, err := Compare(tt.a, tt.b)
+		if err != nil || got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, %v, want %d", tt.a, tt.b, got, err, tt.want)
+		}
+	}
+
+	if _, err := Sign("1.2.3"); err == nil {
+		t.Error("Expected error for malformed decimal")
+	}
+}
+
+func TestMul(t *testing.T) {
+	tests := []struct {
+		a, b int64
+		want int64
+		ok   bool
+	}{
+		{3, 4, 12, true},
+		{0, math.MaxInt64, 0, true},
+		{math.MaxInt64, 1, math.MaxInt64, true},
+		{math.MaxInt64, 2, 0, false},
+		{1 << 32, 1 << 31, 0, false},
+		{-1, 5, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Mul(tt.a, tt.b)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Mul(%d, %d) = %d, %v, want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package types
+
+import "github.com/tsfdsong/tradeengin/app/pkg/fixed"
+
+// 交易对状态，除下架中外与交易阶段一一对应
+const (
+	InstrumentTrading    = "TRADING"     // 连续撮合
+	InstrumentPostOnly   = "POST_ONLY"   // 只接受不会立即成交的限价单
+	InstrumentCancelOnly = "CANCEL_ONLY" // 只接受撤单
+	InstrumentHalted     = "HALTED"      // 暂停交易，拒绝下单及改单，仍接受撤单及批量撤单
+	InstrumentAuction    = "AUCTION"     // 集合竞价
+	InstrumentDelisting  = "DELISTING"   // 下架中，撤销全部挂单后移除交易对
+)
+
+// TradingPhase 交易阶段，决定交易对接受哪些订单操作
+type TradingPhase uint8
+
+const (
+	PhaseContinuous TradingPhase = iota + 1 // 连续撮合
+	PhasePostOnly                           // 只挂单: 会立即成交的订单被拒绝
+	PhaseCancelOnly                         // 只撤单
+	PhaseHalted                             // 暂停
+	PhaseAuction                            // 集合竞价
+)
+
+var phaseStatuses = map[TradingPhase]string{
+	PhaseContinuous: InstrumentTrading,
+	PhasePostOnly:   InstrumentPostOnly,
+	PhaseCancelOnly: InstrumentCancelOnly,
+	PhaseHalted:     InstrumentHalted,
+	PhaseAuction:    InstrumentAuction,
+}
+
+// PhaseOf 交易对状态对应的交易阶段，下架中及未知状态返回false
+func PhaseOf(status string) (TradingPhase, bool) {
+	for phase, s := range phaseStatuses {
+		if s == status {
+			return phase, true
+		}
+	}
+	return 0, false
+}
+
+// Status 交易阶段对应的交易对状态
+func (p TradingPhase) Status() string {
+	return phaseStatuses[p]
+}
+
+func (p TradingPhase) String() string {
+	if status, ok := phaseStatuses[p]; ok {
+		return status
+	}
+	return "UNKNOWN"
+}
+
+// CheckOrder 按交易阶段校验新订单，不接受时返回拒绝原因
+// 只挂单阶段只接受GTC/GTD限价单，是否会立即成交由订单簿在撮合时判断
+func (p TradingPhase) CheckOrder(order *Order) string {
+	switch p {
+	case PhaseContinuous:
+		return ""
+	case PhasePostOnly:
+		tif := order.GetTimeInForce()
+		if order.Type != TypeLimit || (tif != TimeInForceGTC && tif != TimeInForceGTD) {
+			return RejectReasonPostOnlyPhase
+		}
+		return ""
+	case PhaseCancelOnly:
+		return RejectReasonCancelOnly
+	case PhaseAuction:
+		// 集合竞价只累积订单，不接受无法挂单的订单及止损单，Post-Only在竞价撮合时无法保证只做Maker
+		tif := order.GetTimeInForce()
+		if order.Type != TypeLimit || (tif != TimeInForceGTC && tif != TimeInForceGTD) || order.IsPostOnly() {
+			return RejectReasonAuctionPhase
+		}
+		return ""
+	default:
+		return RejectReasonSymbolHalted
+	}
+}
+
+// AcceptsAmend 是否接受改单
+func (p TradingPhase) AcceptsAmend() bool {
+	return p == PhaseContinuous || p == PhasePostOnly || p == PhaseAuction
+}
+
+// InstrumentSpec 交易对规格，价格/数量/金额均为按精度换算后的整数最小单位
+type InstrumentSpec struct {
+	Symbol      string
+	Precision   Precision
+	Status      string
+	TickSize    int64 // 最小价格变动
+	LotSize     int64 // 最小数量变动
+	MinQty      int64 // 单笔最小数量
+	MaxQty      int64 // 单笔最大数量，0表示不限制
+	MinNotional int64 // 单笔最小金额，精度为价格精度+数量精度
+}
+
+// Phase 交易对当前的交易阶段，下架中视为暂停
+func (s *InstrumentSpec) Phase() TradingPhase {
+	if phase, ok := PhaseOf(s.Status); ok {
+		return phase
+	}
+	return PhaseHalted
+}
+
+// Check 按交易对规格校验订单，不满足时返回拒绝原因，通过返回空字符串
+func (s *InstrumentSpec) Check(order *Order) string {
+	if reason := s.Phase().CheckOrder(order); reason != "" {
+		return reason
+	}
+
+	// 价格必须是tick的整数倍
+	if order.Price%s.TickSize != 0 || order.StopPrice%s.TickSize != 0 {
+		return RejectReasonTickSize
+	}
+
+	// 按金额下单的市价单数量在撮合时确定，只校验金额
+	if order.IsQuoteOrder() {
+		if order.QuoteQty < s.MinNotional {
+			return RejectReasonMinNotional
+		}
+		return ""
+	}
+
+	if order.Quantity%s.LotSize != 0 || order.DisplayQty%s.LotSize != 0 {
+		return RejectReasonLotSize
+	}
+	if order.Quantity < s.MinQty {
+		return RejectReasonMinQty
+	}
+	if s.MaxQty > 0 && order.Quantity > s.MaxQty {
+		return RejectReasonMaxQty
+	}
+
+	// 市价单无法预知成交价，只校验带价格的订单
+	if order.Price > 0 {
+		return s.checkNotional(order.Price, order.Quantity)
+	}
+	return ""
+}
+
+// CheckAmend 按交易对规格校验改单后的价格及数量，price/qty为0表示不修改，通过返回空字符串
+// 剩余数量由成交产生，不修改数量时不再校验lot及数量范围；修改价格或数量后的金额仍需满足最小金额
+func (s *InstrumentSpec) CheckAmend(order *Order, price, qty int64) string {
+	if price > 0 && price%s.TickSize != 0 {
+		return RejectReasonTickSize
+	}
+
+	if qty > 0 {
+		if qty%s.LotSize != 0 {
+			return RejectReasonLotSize
+		}
+		if qty < s.MinQty {
+			return RejectReasonMinQty
+		}
+		if s.MaxQty > 0 && qty > s.MaxQty {
+			return RejectReasonMaxQty
+		}
+	}
+
+	if price == 0 && qty == 0 {
+		return ""
+	}
+	if price == 0 {
+		price = order.Price
+	}
+	if qty == 0 {
+		qty = order.VisibleQty + order.HiddenQty
+	}
+	return s.checkNotional(price, qty)
+}
+
+// checkNotional 校验金额不低于最小金额，价格*数量超出int64时拒绝
+func (s *InstrumentSpec) checkNotional(price, qty int64) string {
+	notional, ok := fixed.Mul(price, qty)
+	if !ok {
+		return RejectReasonNotionalOverflow
+	}
+	if notional < s.MinNotional {
+		return RejectReasonMinNotional
+	}
+	return ""
+}
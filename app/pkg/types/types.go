@@ -1,7 +1,10 @@
 package types
 
 import (
+	"slices"
 	"sync"
+
+	"github.com/tsfdsong/tradeengin/app/pkg/fixed"
 )
 
 // 订单方向常量
@@ -12,30 +15,131 @@ const (
 
 // 订单类型常量
 const (
-	TypeLimitValue  int8 = 1
-	TypeMarketValue int8 = 2
+	TypeLimitValue      int8 = 1
+	TypeMarketValue     int8 = 2
+	TypeStopMarketValue int8 = 3 // 止损市价单，触发后按市价单撮合
+	TypeStopLimitValue  int8 = 4 // 止损限价单，触发后按限价单撮合
+)
+
+// 订单有效期(Time In Force)常量
+const (
+	TimeInForceGTCValue int8 = 1 // 一直有效直至成交或撤销
+	TimeInForceIOCValue int8 = 2 // 立即成交，剩余部分撤销
+	TimeInForceFOKValue int8 = 3 // 全部成交，否则全部撤销
+	TimeInForceGTDValue int8 = 4 // 有效至指定时间
+)
+
+// 只做Maker(Post-Only)模式常量
+const (
+	PostOnlyRejectValue int8 = 1 // 会吃单时拒绝
+	PostOnlySlideValue  int8 = 2 // 会吃单时调整到对手最优价内一个tick
+)
+
+// 自成交预防(Self-Trade Prevention)模式常量，同一ClientID的买卖单相遇时生效
+const (
+	STPCancelNewestValue    int8 = 1 // 撤销新订单(吃单方)剩余数量
+	STPCancelOldestValue    int8 = 2 // 撤销旧订单(挂单方)
+	STPCancelBothValue      int8 = 3 // 双方都撤销
+	STPDecrementCancelValue int8 = 4 // 双方同时减去重叠数量，数量归零的一方撤销
 )
 
 var (
 	SideBuy  int8 = SideBuyValue
 	SideSell int8 = SideSellValue
 
-	TypeLimit  int8 = TypeLimitValue
-	TypeMarket int8 = TypeMarketValue
+	TypeLimit      int8 = TypeLimitValue
+	TypeMarket     int8 = TypeMarketValue
+	TypeStopMarket int8 = TypeStopMarketValue
+	TypeStopLimit  int8 = TypeStopLimitValue
+
+	TimeInForceGTC int8 = TimeInForceGTCValue
+	TimeInForceIOC int8 = TimeInForceIOCValue
+	TimeInForceFOK int8 = TimeInForceFOKValue
+	TimeInForceGTD int8 = TimeInForceGTDValue
+
+	PostOnlyReject int8 = PostOnlyRejectValue
+	PostOnlySlide  int8 = PostOnlySlideValue
+
+	STPCancelNewest    int8 = STPCancelNewestValue
+	STPCancelOldest    int8 = STPCancelOldestValue
+	STPCancelBoth      int8 = STPCancelBothValue
+	STPDecrementCancel int8 = STPDecrementCancelValue
 )
 
+// Precision 交易对精度，价格和数量在撮合内部以整数最小单位表示，对外以十进制字符串传输
+type Precision struct {
+	PriceScale    int32 // 价格小数位数
+	QuantityScale int32 // 数量小数位数
+}
+
+// QuoteScale 成交金额(价格*数量)的小数位数
+func (p Precision) QuoteScale() int32 {
+	return p.PriceScale + p.QuantityScale
+}
+
+// ParsePrice 十进制价格字符串转换为tick
+func (p Precision) ParsePrice(s string) (int64, error) {
+	return fixed.Parse(s, p.PriceScale)
+}
+
+// FormatPrice tick转换为十进制价格字符串
+func (p Precision) FormatPrice(v int64) string {
+	return fixed.Format(v, p.PriceScale)
+}
+
+// ParseQty 十进制数量字符串转换为最小数量单位
+func (p Precision) ParseQty(s string) (int64, error) {
+	return fixed.Parse(s, p.QuantityScale)
+}
+
+// FormatQty 最小数量单位转换为十进制数量字符串
+func (p Precision) FormatQty(v int64) string {
+	return fixed.Format(v, p.QuantityScale)
+}
+
+// PriceFloat 价格转换为浮点数，仅用于监控展示
+func (p Precision) PriceFloat(v int64) float64 {
+	return fixed.ToFloat(v, p.PriceScale)
+}
+
+// QtyFloat 数量转换为浮点数，仅用于监控展示
+func (p Precision) QtyFloat(v int64) float64 {
+	return fixed.ToFloat(v, p.QuantityScale)
+}
+
+// ParseQuote 十进制金额字符串转换为最小金额单位
+func (p Precision) ParseQuote(s string) (int64, error) {
+	return fixed.Parse(s, p.QuoteScale())
+}
+
+// FormatQuote 最小金额单位转换为十进制金额字符串
+func (p Precision) FormatQuote(v int64) string {
+	return fixed.Format(v, p.QuoteScale())
+}
+
 // Order 订单结构 - 内存对齐优化
 type Order struct {
-	ID        uint64  `json:"id"`
-	Symbol    string  `json:"symbol"`
-	Price     float64 `json:"price"`
-	Quantity  int64   `json:"quantity"`
-	Side      int8    `json:"side"`
-	Type      int8    `json:"type"`
-	Timestamp int64   `json:"timestamp"`
-	ClientID  string  `json:"clientId"`
-	Version   uint32  `json:"version"`
-	_         [4]byte // 填充对齐到128字节
+	ID          uint64  `json:"id"`
+	Symbol      string  `json:"symbol"`
+	Price       int64   `json:"price"` // 价格，按交易对价格精度换算的整数最小单位(tick)
+	Quantity    int64   `json:"quantity"`
+	Side        int8    `json:"side"`
+	Type        int8    `json:"type"`
+	Timestamp   int64   `json:"timestamp"`
+	ClientID    string  `json:"clientId"`
+	Account     string  `json:"account,omitempty"` // 下单账户，可按账户批量撤单
+	Version     uint32  `json:"version"`
+	TimeInForce int8    `json:"timeInForce"` // 订单有效期，0按GTC处理
+	ExpireTime  int64   `json:"expireTime"`  // GTD订单过期时间(纳秒)
+	PostOnly    int8    `json:"postOnly"`    // 只做Maker模式，0表示普通订单
+	StopPrice   int64   `json:"stopPrice"`   // 止损单触发价(tick)
+	DisplayQty  int64   `json:"displayQty"`  // 冰山单每次展示的数量，0表示普通订单
+	STPMode     int8    `json:"stpMode"`     // 自成交预防模式，0使用交易对默认模式
+	QuoteQty    int64   `json:"quoteQty"`    // 按计价货币金额下单的市价单金额(价格精度+数量精度)，此时Quantity为0
+	VisibleQty  int64   `json:"-"`           // 订单簿中当前展示的剩余数量
+	HiddenQty   int64   `json:"-"`           // 冰山单尚未展示的剩余数量
+	Arrival     uint64  `json:"-"`           // 订单簿分配的排队序号，同一交易对内越大越晚进入价格层级队列
+	_           [4]byte // 填充对齐到128字节
 }
 
 // Reset 重置Order对象，用于对象池回收
@@ -48,7 +152,18 @@ func (o *Order) Reset() {
 	o.Type = 0
 	o.Timestamp = 0
 	o.ClientID = ""
+	o.Account = ""
 	o.Version = 0
+	o.TimeInForce = 0
+	o.ExpireTime = 0
+	o.PostOnly = 0
+	o.StopPrice = 0
+	o.DisplayQty = 0
+	o.STPMode = 0
+	o.QuoteQty = 0
+	o.VisibleQty = 0
+	o.HiddenQty = 0
+	o.Arrival = 0
 }
 
 // IsValid 检查订单是否有效
@@ -56,21 +171,71 @@ func (o *Order) IsValid() bool {
 	if o.Symbol == "" {
 		return false
 	}
-	if o.Quantity <= 0 {
+	// 按金额下单的市价单只指定金额，其余订单必须指定数量；成交数量在撮合时才确定，不支持FOK
+	if o.QuoteQty != 0 {
+		if o.QuoteQty < 0 || o.Quantity != 0 || o.Type != TypeMarket || o.GetTimeInForce() == TimeInForceFOK {
+			return false
+		}
+	} else if o.Quantity <= 0 {
 		return false
 	}
 	if o.Side != SideBuy && o.Side != SideSell {
 		return false
 	}
-	if o.Type != TypeLimit && o.Type != TypeMarket {
+	if o.Type != TypeLimit && o.Type != TypeMarket && o.Type != TypeStopMarket && o.Type != TypeStopLimit {
+		return false
+	}
+	if (o.Type == TypeLimit || o.Type == TypeStopLimit) && o.Price <= 0 {
+		return false
+	}
+	if o.IsStop() && o.StopPrice <= 0 {
 		return false
 	}
-	if o.Type == TypeLimit && o.Price <= 0 {
+	switch o.GetTimeInForce() {
+	case TimeInForceGTC, TimeInForceIOC, TimeInForceFOK:
+	case TimeInForceGTD:
+		if o.ExpireTime <= 0 {
+			return false
+		}
+	default:
+		return false
+	}
+	// Post-Only只适用于会挂单的限价单
+	switch o.PostOnly {
+	case 0:
+	case PostOnlyReject, PostOnlySlide:
+		if o.Type != TypeLimit {
+			return false
+		}
+		if tif := o.GetTimeInForce(); tif == TimeInForceIOC || tif == TimeInForceFOK {
+			return false
+		}
+	default:
+		return false
+	}
+	// 冰山单只适用于会挂单的限价单
+	if o.DisplayQty < 0 {
+		return false
+	}
+	if o.DisplayQty > 0 {
+		if o.Type != TypeLimit && o.Type != TypeStopLimit {
+			return false
+		}
+		if o.DisplayQty > o.Quantity {
+			return false
+		}
+	}
+	if o.STPMode != 0 && !IsValidSTPMode(o.STPMode) {
 		return false
 	}
 	return true
 }
 
+// IsValidSTPMode 是否是有效的自成交预防模式
+func IsValidSTPMode(mode int8) bool {
+	return mode >= STPCancelNewestValue && mode <= STPDecrementCancelValue
+}
+
 // IsBuy 是否是买单
 func (o *Order) IsBuy() bool {
 	return o.Side == SideBuy
@@ -91,6 +256,49 @@ func (o *Order) IsMarket() bool {
 	return o.Type == TypeMarket
 }
 
+// IsStop 是否是未触发的止损单
+func (o *Order) IsStop() bool {
+	return o.Type == TypeStopMarket || o.Type == TypeStopLimit
+}
+
+// IsQuoteOrder 是否是按计价货币金额下单的市价单
+func (o *Order) IsQuoteOrder() bool {
+	return o.QuoteQty > 0
+}
+
+// IsIceberg 是否是冰山单
+func (o *Order) IsIceberg() bool {
+	return o.DisplayQty > 0
+}
+
+// Trigger 止损单触发，转换为对应的市价单或限价单
+func (o *Order) Trigger() {
+	switch o.Type {
+	case TypeStopMarket:
+		o.Type = TypeMarket
+	case TypeStopLimit:
+		o.Type = TypeLimit
+	}
+}
+
+// GetTimeInForce 获取订单有效期，未设置时默认为GTC
+func (o *Order) GetTimeInForce() int8 {
+	if o.TimeInForce == 0 {
+		return TimeInForceGTC
+	}
+	return o.TimeInForce
+}
+
+// IsPostOnly 是否是只做Maker订单
+func (o *Order) IsPostOnly() bool {
+	return o.PostOnly != 0
+}
+
+// IsExpired GTD订单在now(纳秒)时是否已过期
+func (o *Order) IsExpired(now int64) bool {
+	return o.GetTimeInForce() == TimeInForceGTD && o.ExpireTime > 0 && o.ExpireTime <= now
+}
+
 // // 确保Order结构128字节对齐
 // func init() {
 // 	if unsafe.Sizeof(Order{}) != 128 {
@@ -99,14 +307,14 @@ func (o *Order) IsMarket() bool {
 // }
 
 type Trade struct {
-	TradeID      uint64  `json:"tradeId"`
-	TakerOrderID uint64  `json:"takerOrderId"`
-	MakerOrderID uint64  `json:"makerOrderId"`
-	Symbol       string  `json:"symbol"`
-	Price        float64 `json:"price"`
-	Quantity     int64   `json:"quantity"`
-	Timestamp    int64   `json:"timestamp"`
-	TakerSide    int8    `json:"takerSide"` // 新增: Taker方向
+	TradeID      uint64 `json:"tradeId"`
+	TakerOrderID uint64 `json:"takerOrderId"`
+	MakerOrderID uint64 `json:"makerOrderId"`
+	Symbol       string `json:"symbol"`
+	Price        int64  `json:"price"`
+	Quantity     int64  `json:"quantity"`
+	Timestamp    int64  `json:"timestamp"`
+	TakerSide    int8   `json:"takerSide"` // 新增: Taker方向，集合竞价成交没有主动方，为0
 }
 
 // Reset 重置Trade对象
@@ -121,10 +329,51 @@ func (t *Trade) Reset() {
 	t.TakerSide = 0
 }
 
+// 订单拒绝原因
+const (
+	RejectReasonGTDExpired  = "gtd_expired"
+	RejectReasonFOKUnfilled = "fok_unfilled"
+	RejectReasonPostOnly    = "post_only_would_take"
+	RejectReasonSelfTrade   = "self_trade_prevented"
+	RejectReasonSlippage    = "max_slippage_exceeded"
+
+	RejectReasonSymbolHalted     = "symbol_halted"
+	RejectReasonCancelOnly       = "symbol_cancel_only"
+	RejectReasonPostOnlyPhase    = "symbol_post_only"
+	RejectReasonAuctionPhase     = "symbol_auction"
+	RejectReasonTickSize         = "invalid_tick_size"
+	RejectReasonLotSize          = "invalid_lot_size"
+	RejectReasonMinQty           = "quantity_below_min"
+	RejectReasonMaxQty           = "quantity_above_max"
+	RejectReasonMinNotional      = "notional_below_min"
+	RejectReasonNotionalOverflow = "notional_overflow"
+)
+
+// SelfTradeEvent 自成交预防事件，同一账户的买卖单相遇时代替成交输出
+type SelfTradeEvent struct {
+	Symbol            string `json:"symbol"`
+	TakerOrderID      uint64 `json:"takerOrderId"`
+	MakerOrderID      uint64 `json:"makerOrderId"`
+	ClientID          string `json:"clientId"`
+	Price             int64  `json:"price"`
+	Mode              int8   `json:"mode"`              // 生效的自成交预防模式
+	TakerCancelledQty int64  `json:"takerCancelledQty"` // 吃单方被撤销的数量
+	MakerCancelledQty int64  `json:"makerCancelledQty"` // 挂单方被撤销的数量
+	MakerRemoved      bool   `json:"makerRemoved"`      // 挂单是否已从订单簿移除
+	Timestamp         int64  `json:"timestamp"`
+}
+
 type MatchResult struct {
-	Trades    []*Trade `json:"trades"`
-	Order     *Order   `json:"order"`
-	Timestamp int64    `json:"timestamp"`
+	Trades         []*Trade          `json:"trades"`
+	Order          *Order            `json:"order"`
+	Timestamp      int64             `json:"timestamp"`
+	RestingQty     int64             `json:"restingQty"`     // 仍在订单簿(含止损触发簿)中的剩余数量
+	CancelledQty   int64             `json:"cancelledQty"`   // 因IOC/FOK/GTD过期/市价剩余被撤销的数量
+	RejectReason   string            `json:"rejectReason"`   // 订单被整体拒绝的原因
+	Triggered      []*MatchResult    `json:"triggered"`      // 本次成交触发的止损单撮合结果，按触发顺序排列
+	SelfTrades     []*SelfTradeEvent `json:"selfTrades"`     // 自成交预防事件
+	RemainingQuote int64             `json:"remainingQuote"` // 按金额下单的市价单未用完(已撤销)的金额
+	Expired        []uint64          `json:"expired"`        // 撮合前撤销的已过期GTD挂单
 }
 
 // Reset 重置MatchResult对象
@@ -132,6 +381,13 @@ func (m *MatchResult) Reset() {
 	m.Trades = m.Trades[:0]
 	m.Order = nil
 	m.Timestamp = 0
+	m.RestingQty = 0
+	m.CancelledQty = 0
+	m.RejectReason = ""
+	m.Triggered = nil
+	m.SelfTrades = nil
+	m.RemainingQuote = 0
+	m.Expired = nil
 }
 
 // HasTrades 是否有成交
@@ -148,17 +404,56 @@ func (m *MatchResult) TotalFilledQty() int64 {
 	return total
 }
 
+// TotalNotional 总成交金额，精度为价格精度+数量精度
+func (m *MatchResult) TotalNotional() int64 {
+	var total int64
+	for _, t := range m.Trades {
+		total += t.Price * t.Quantity
+	}
+	return total
+}
+
+// Clone 深拷贝撮合结果(订单、成交、自成交事件)，副本不属于对象池
+// 被触发的止损单结果属于其他订单，不做拷贝
+func (m *MatchResult) Clone() *MatchResult {
+	clone := *m
+	clone.Triggered = nil
+
+	if m.Order != nil {
+		order := *m.Order
+		clone.Order = &order
+	}
+
+	clone.Trades = make([]*Trade, len(m.Trades))
+	for i, t := range m.Trades {
+		trade := *t
+		clone.Trades[i] = &trade
+	}
+
+	if len(m.SelfTrades) > 0 {
+		clone.SelfTrades = make([]*SelfTradeEvent, len(m.SelfTrades))
+		for i, st := range m.SelfTrades {
+			event := *st
+			clone.SelfTrades[i] = &event
+		}
+	}
+	clone.Expired = slices.Clone(m.Expired)
+
+	return &clone
+}
+
 type OrderBook struct {
-	Symbol string       `json:"symbol"`
-	Bids   []PriceLevel `json:"bids"`
-	Asks   []PriceLevel `json:"asks"`
-	Time   int64        `json:"time"`
+	Symbol   string       `json:"symbol"`
+	UpdateID uint64       `json:"updateId"` // 快照对应的订单簿版本，与增量深度的更新ID对应
+	Bids     []PriceLevel `json:"bids"`
+	Asks     []PriceLevel `json:"asks"`
+	Time     int64        `json:"time"`
 }
 
 type PriceLevel struct {
-	Price    float64 `json:"price"`
-	Quantity int64   `json:"quantity"`
-	Count    int     `json:"count"`
+	Price    int64 `json:"price"`
+	Quantity int64 `json:"quantity"`
+	Count    int   `json:"count"`
 }
 
 // 对象池
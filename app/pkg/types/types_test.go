@@ -6,22 +6,26 @@ import (
 
 func TestOrder_Reset(t *testing.T) {
 	order := &Order{
-		ID:        12345,
-		Symbol:    "BTCUSDT",
-		Price:     50000.0,
-		Quantity:  100,
-		Side:      SideBuy,
-		Type:      TypeLimit,
-		Timestamp: 1234567890,
-		ClientID:  "client123",
-		Version:   1,
+		ID:          12345,
+		Symbol:      "BTCUSDT",
+		Price:       50000.0,
+		Quantity:    100,
+		Side:        SideBuy,
+		Type:        TypeLimit,
+		Timestamp:   1234567890,
+		ClientID:    "client123",
+		Account:     "acct1",
+		Version:     1,
+		TimeInForce: TimeInForceGTD,
+		ExpireTime:  1234567890,
 	}
 
 	order.Reset()
 
 	if order.ID != 0 || order.Symbol != "" || order.Price != 0 ||
 		order.Quantity != 0 || order.Side != 0 || order.Type != 0 ||
-		order.Timestamp != 0 || order.ClientID != "" || order.Version != 0 {
+		order.Timestamp != 0 || order.ClientID != "" || order.Account != "" || order.Version != 0 ||
+		order.TimeInForce != 0 || order.ExpireTime != 0 {
 		t.Error("Reset should clear all fields")
 	}
 }
@@ -87,6 +91,120 @@ func TestOrder_IsValid(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "Valid GTD order with expire time",
+			order: Order{
+				Symbol:      "BTCUSDT",
+				Price:       50000.0,
+				Quantity:    100,
+				Side:        SideBuy,
+				Type:        TypeLimit,
+				TimeInForce: TimeInForceGTD,
+				ExpireTime:  1234567890,
+			},
+			expected: true,
+		},
+		{
+			name: "Invalid - GTD order without expire time",
+			order: Order{
+				Symbol:      "BTCUSDT",
+				Price:       50000.0,
+				Quantity:    100,
+				Side:        SideBuy,
+				Type:        TypeLimit,
+				TimeInForce: TimeInForceGTD,
+			},
+			expected: false,
+		},
+		{
+			name: "Invalid - unknown time in force",
+			order: Order{
+				Symbol:      "BTCUSDT",
+				Price:       50000.0,
+				Quantity:    100,
+				Side:        SideBuy,
+				Type:        TypeLimit,
+				TimeInForce: 9,
+			},
+			expected: false,
+		},
+		{
+			name: "Invalid - post-only market order",
+			order: Order{
+				Symbol:   "BTCUSDT",
+				Quantity: 100,
+				Side:     SideBuy,
+				Type:     TypeMarket,
+				PostOnly: PostOnlyReject,
+			},
+			expected: false,
+		},
+		{
+			name: "Valid stop market order",
+			order: Order{
+				Symbol:    "BTCUSDT",
+				Quantity:  100,
+				Side:      SideSell,
+				Type:      TypeStopMarket,
+				StopPrice: 49000.0,
+			},
+			expected: true,
+		},
+		{
+			name: "Invalid - stop order without stop price",
+			order: Order{
+				Symbol:   "BTCUSDT",
+				Price:    50000.0,
+				Quantity: 100,
+				Side:     SideBuy,
+				Type:     TypeStopLimit,
+			},
+			expected: false,
+		},
+		{
+			name: "Invalid - iceberg display larger than quantity",
+			order: Order{
+				Symbol:     "BTCUSDT",
+				Price:      50000.0,
+				Quantity:   100,
+				Side:       SideBuy,
+				Type:       TypeLimit,
+				DisplayQty: 200,
+			},
+			expected: false,
+		},
+		{
+			name: "Valid quote quantity market order",
+			order: Order{
+				Symbol:   "BTCUSDT",
+				Side:     SideBuy,
+				Type:     TypeMarket,
+				QuoteQty: 1000.0,
+			},
+			expected: true,
+		},
+		{
+			name: "Invalid - quote quantity FOK order",
+			order: Order{
+				Symbol:      "BTCUSDT",
+				Side:        SideBuy,
+				Type:        TypeMarket,
+				QuoteQty:    1000.0,
+				TimeInForce: TimeInForceFOK,
+			},
+			expected: false,
+		},
+		{
+			name: "Invalid - quote quantity limit order",
+			order: Order{
+				Symbol:   "BTCUSDT",
+				Price:    50000.0,
+				Side:     SideBuy,
+				Type:     TypeLimit,
+				QuoteQty: 1000.0,
+			},
+			expected: false,
+		},
 		{
 			name: "Invalid - limit order without price",
 			order: Order{
@@ -174,6 +292,134 @@ func TestMatchResult_TotalFilledQty(t *testing.T) {
 	}
 }
 
+func TestMatchResult_Clone(t *testing.T) {
+	result := GetMatchResultFromPool()
+	result.Order = &Order{ID: 1, Quantity: 100}
+	result.Trades = append(result.Trades, &Trade{TradeID: 1, Quantity: 60})
+	result.Triggered = []*MatchResult{{}}
+
+	clone := result.Clone()
+	PutMatchResultToPool(result)
+
+	if clone.Order.ID != 1 || len(clone.Trades) != 1 || clone.Trades[0].Quantity != 60 {
+		t.Errorf("Clone should survive pool reuse, got %+v", clone)
+	}
+	if clone.Triggered != nil {
+		t.Error("Clone should not carry triggered results")
+	}
+}
+
+func TestPrecision(t *testing.T) {
+	p := Precision{PriceScale: 2, QuantityScale: 4}
+
+	price, err := p.ParsePrice("50000.25")
+	if err != nil || price != 5000025 {
+		t.Fatalf("Expected price 5000025, got %d, %v", price, err)
+	}
+	qty, err := p.ParseQty("0.015")
+	if err != nil || qty != 150 {
+		t.Fatalf("Expected qty 150, got %d, %v", qty, err)
+	}
+	if quote := p.FormatQuote(price * qty); quote != "750.00375" {
+		t.Errorf("Expected notional 750.00375, got %s", quote)
+	}
+	if _, err := p.ParsePrice("1.001"); err == nil {
+		t.Error("Expected error for price finer than tick")
+	}
+}
+
+func TestInstrumentSpec_Check(t *testing.T) {
+	spec := &InstrumentSpec{
+		Symbol:      "BTCUSDT",
+		Precision:   Precision{PriceScale: 2, QuantityScale: 4},
+		Status:      InstrumentTrading,
+		TickSize:    10,        // 0.10
+		LotSize:     10,        // 0.0010
+		MinQty:      10,        // 0.0010
+		MaxQty:      1000000,   // 100
+		MinNotional: 5_000_000, // 5.000000
+	}
+
+	tests := []struct {
+		name   string
+		order  Order
+		reason string
+	}{
+		{"valid limit", Order{Price: 5000000, Quantity: 100, Type: TypeLimit}, ""},
+		{"off tick", Order{Price: 5000005, Quantity: 100, Type: TypeLimit}, RejectReasonTickSize},
+		{"off tick stop", Order{StopPrice: 4999995, Quantity: 100, Type: TypeStopMarket}, RejectReasonTickSize},
+		{"off lot", Order{Price: 5000000, Quantity: 105, Type: TypeLimit}, RejectReasonLotSize},
+		{"below min qty", Order{Quantity: 0, Type: TypeMarket}, RejectReasonMinQty},
+		{"above max qty", Order{Quantity: 1000010, Type: TypeMarket}, RejectReasonMaxQty},
+		{"below min notional", Order{Price: 10000, Quantity: 100, Type: TypeLimit}, RejectReasonMinNotional},
+		{"market skips notional", Order{Quantity: 10, Type: TypeMarket}, ""},
+		{"quote below min notional", Order{QuoteQty: 4_000_000, Type: TypeMarket}, RejectReasonMinNotional},
+		{"notional overflow", Order{Price: 9_000_000_000_000_000_000, Quantity: 100, Type: TypeLimit}, RejectReasonNotionalOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := spec.Check(&tt.order); got != tt.reason {
+				t.Errorf("Expected reason %q, got %q", tt.reason, got)
+			}
+		})
+	}
+
+	spec.Status = InstrumentHalted
+	if got := spec.Check(&Order{Price: 5000000, Quantity: 100, Type: TypeLimit}); got != RejectReasonSymbolHalted {
+		t.Errorf("Expected halted symbol to reject, got %q", got)
+	}
+
+	phases := []struct {
+		status string
+		order  Order
+		reason string
+	}{
+		{InstrumentPostOnly, Order{Price: 5000000, Quantity: 100, Type: TypeLimit}, ""},
+		{InstrumentPostOnly, Order{Quantity: 100, Type: TypeMarket}, RejectReasonPostOnlyPhase},
+		{InstrumentPostOnly, Order{Price: 5000000, Quantity: 100, Type: TypeLimit, TimeInForce: TimeInForceIOC}, RejectReasonPostOnlyPhase},
+		{InstrumentCancelOnly, Order{Price: 5000000, Quantity: 100, Type: TypeLimit}, RejectReasonCancelOnly},
+		{InstrumentAuction, Order{Price: 5000000, Quantity: 100, Type: TypeLimit}, ""},
+		{InstrumentAuction, Order{Quantity: 100, Type: TypeMarket}, RejectReasonAuctionPhase},
+		{InstrumentAuction, Order{Price: 5000000, Quantity: 100, Type: TypeLimit, PostOnly: PostOnlyReject}, RejectReasonAuctionPhase},
+		{InstrumentDelisting, Order{Price: 5000000, Quantity: 100, Type: TypeLimit}, RejectReasonSymbolHalted},
+	}
+	for _, tt := range phases {
+		spec.Status = tt.status
+		if got := spec.Check(&tt.order); got != tt.reason {
+			t.Errorf("%s: expected reason %q, got %q", tt.status, tt.reason, got)
+		}
+	}
+}
+
+func TestInstrumentSpec_CheckAmend(t *testing.T) {
+	spec := &InstrumentSpec{TickSize: 10, LotSize: 10, MinQty: 20, MaxQty: 1000000, MinNotional: 5_000_000}
+	// 部分成交后剩余5，低于最小数量
+	order := &Order{Price: 5000000, Quantity: 100, VisibleQty: 5, Type: TypeLimit}
+
+	tests := []struct {
+		name   string
+		price  int64
+		qty    int64
+		reason string
+	}{
+		{"unchanged", 0, 0, ""},
+		{"off tick", 5000005, 0, RejectReasonTickSize},
+		{"off lot", 0, 105, RejectReasonLotSize},
+		{"below min qty", 0, 10, RejectReasonMinQty},
+		{"above max qty", 0, 1000010, RejectReasonMaxQty},
+		{"price only keeps leaves", 2000000, 0, ""},
+		{"below min notional", 10000, 0, RejectReasonMinNotional},
+		{"notional overflow", 9_000_000_000_000_000_000, 0, RejectReasonNotionalOverflow},
+		{"valid", 5000000, 200, ""},
+	}
+	for _, tt := range tests {
+		if got := spec.CheckAmend(order, tt.price, tt.qty); got != tt.reason {
+			t.Errorf("%s: expected reason %q, got %q", tt.name, tt.reason, got)
+		}
+	}
+}
+
 func TestOrderPool(t *testing.T) {
 	// 获取对象
 	order1 := GetOrderFromPool()
@@ -14,3 +14,25 @@ const DB_ERROR uint32 = 100005
 const DB_UPDATE_AFFECTED_ZERO_ERROR uint32 = 100006
 
 //用户模块
+
+//撮合模块
+const ORDER_POST_ONLY_REJECT uint32 = 300001
+const ORDER_SYMBOL_HALTED uint32 = 300002
+const ORDER_INVALID_TICK_SIZE uint32 = 300003
+const ORDER_INVALID_LOT_SIZE uint32 = 300004
+const ORDER_QTY_BELOW_MIN uint32 = 300005
+const ORDER_QTY_ABOVE_MAX uint32 = 300006
+const ORDER_NOTIONAL_BELOW_MIN uint32 = 300007
+const ORDER_MATCH_TIMEOUT uint32 = 300008
+const ORDER_REPLICATION_TIMEOUT uint32 = 300009
+const MATCH_NOT_PRIMARY uint32 = 300010
+const MATCH_SYMBOL_EXISTS uint32 = 300011
+const ORDER_SYMBOL_CANCEL_ONLY uint32 = 300012
+const ORDER_SYMBOL_POST_ONLY uint32 = 300013
+const ORDER_SYMBOL_AUCTION uint32 = 300014
+const ORDER_NOTIONAL_OVERFLOW uint32 = 300015
+const ORDER_STOP_AMEND uint32 = 300016
+
+//订单模块
+const ORDER_SESSION_NOT_FOUND uint32 = 400001
+const ORDER_SESSION_CANCEL_DISABLED uint32 = 400002
@@ -11,6 +11,24 @@ func init() {
 	message[TOKEN_GENERATE_ERROR] = "生成token失败"
 	message[DB_ERROR] = "数据库繁忙,请稍后再试"
 	message[DB_UPDATE_AFFECTED_ZERO_ERROR] = "更新数据影响行数为0"
+	message[ORDER_POST_ONLY_REJECT] = "只做Maker订单会立即成交，已拒绝"
+	message[ORDER_SYMBOL_HALTED] = "交易对暂停交易"
+	message[ORDER_INVALID_TICK_SIZE] = "价格不是最小价格变动的整数倍"
+	message[ORDER_INVALID_LOT_SIZE] = "数量不是最小数量变动的整数倍"
+	message[ORDER_QTY_BELOW_MIN] = "下单数量低于最小数量"
+	message[ORDER_QTY_ABOVE_MAX] = "下单数量超过最大数量"
+	message[ORDER_NOTIONAL_BELOW_MIN] = "下单金额低于最小金额"
+	message[ORDER_MATCH_TIMEOUT] = "等待撮合结果超时，请查询订单状态"
+	message[ORDER_REPLICATION_TIMEOUT] = "等待备节点确认超时，请查询订单状态"
+	message[MATCH_NOT_PRIMARY] = "撮合服务为备节点，暂不接收请求"
+	message[MATCH_SYMBOL_EXISTS] = "交易对已存在"
+	message[ORDER_SYMBOL_CANCEL_ONLY] = "交易对当前只接受撤单"
+	message[ORDER_SYMBOL_POST_ONLY] = "交易对当前只接受不会立即成交的限价单"
+	message[ORDER_SYMBOL_AUCTION] = "集合竞价期间只接受GTC/GTD限价单"
+	message[ORDER_NOTIONAL_OVERFLOW] = "下单金额超出可表示范围"
+	message[ORDER_STOP_AMEND] = "未触发的止损单不支持改单，请撤单后重新下单"
+	message[ORDER_SESSION_NOT_FOUND] = "交易会话不存在或已断开"
+	message[ORDER_SESSION_CANCEL_DISABLED] = "当前API Key未开启断线撤单"
 }
 
 func MapErrMsg(errcode uint32) string {